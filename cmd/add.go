@@ -5,6 +5,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
 	"strings"
@@ -22,6 +23,12 @@ var (
 	addType         string
 	addTaskName     string
 	addActivityName string
+	addEstimate     bool
+	addMaxCost      float64
+	addRender       bool
+	addNoExtract    bool
+	addFromHTML     bool
+	addCapture      bool
 )
 
 var addCmd = &cobra.Command{
@@ -32,7 +39,28 @@ URLs may be provided as arguments or piped via stdin (one per line).
 
   --type link (default)   Save as a standalone link.
   --type task             Create (or find) a task and associate this link.
-  --type activity         Create (or find) an activity and associate this link.`,
+  --type activity         Create (or find) an activity and associate this link.
+
+  --estimate              Print the projected AI summary cost of the URLs not
+                          saved yet, then exit without fetching anything.
+  --max-cost <usd>        Stop once AI summaries have cost this much, e.g.
+                          1.00. Checked between URLs, so the last one may
+                          take the total slightly over.
+  --render                Fetch pages through the RENDER_URL headless-browser
+                          service, for sites that build their content with
+                          JavaScript. Without it, pages whose text comes out
+                          nearly empty are rendered automatically when
+                          RENDER_URL is set.
+  --no-extract            Save just the page title, without content or an AI
+                          summary, for URLs kept only as references such as
+                          a repository or a tool's homepage.
+  --from-html             Read the page's HTML from stdin instead of fetching
+                          it, and save it under the one URL given, e.g.
+                          cat page.html | lm add --from-html <url>. For pages
+                          behind a login or paywall, saved from a browser.
+  --capture               Save just the URL and title now, for saving links
+                          quickly; 'lm process' fetches and summarises them
+                          later.`,
 	Args: cobra.ArbitraryArgs,
 	RunE: runAdd,
 }
@@ -43,6 +71,12 @@ func init() {
 	addCmd.Flags().StringVar(&addType, "type", "link", "Association type: link, task, or activity")
 	addCmd.Flags().StringVar(&addTaskName, "task-name", "", "Task name when --type task (defaults to the page title)")
 	addCmd.Flags().StringVar(&addActivityName, "activity-name", "", "Activity name when --type activity (defaults to the page title)")
+	addCmd.Flags().BoolVar(&addEstimate, "estimate", false, "Estimate the AI summary cost and exit without adding anything")
+	addCmd.Flags().Float64Var(&addMaxCost, "max-cost", 0, "Stop once AI summaries have cost this many US dollars (0 for no limit)")
+	addCmd.Flags().BoolVar(&addRender, "render", false, "Fetch pages through the RENDER_URL headless-browser service")
+	addCmd.Flags().BoolVar(&addNoExtract, "no-extract", false, "Save only the page title, without content or summary")
+	addCmd.Flags().BoolVar(&addFromHTML, "from-html", false, "Read the page's HTML from stdin instead of fetching the URL")
+	addCmd.Flags().BoolVar(&addCapture, "capture", false, "Save only the URL and title now and leave the rest to 'lm process'")
 	rootCmd.AddCommand(addCmd)
 }
 
@@ -55,6 +89,15 @@ func runAdd(cmd *cobra.Command, args []string) error {
 	default:
 		return fmt.Errorf("invalid --type %q: must be link, task, or activity", addType)
 	}
+	if err := validateMaxCost(addMaxCost); err != nil {
+		return err
+	}
+	if addFromHTML && (len(args) != 1 || addRender || addNoExtract) {
+		return fmt.Errorf("--from-html takes exactly one URL and cannot be combined with --render or --no-extract")
+	}
+	if addCapture && (addNoExtract || addFromHTML) {
+		return fmt.Errorf("--capture cannot be combined with --no-extract or --from-html")
+	}
 
 	// Load env / config
 	if dir, err := configDir(); err == nil {
@@ -65,20 +108,24 @@ func runAdd(cmd *cobra.Command, args []string) error {
 	db := database.New(dbPath)
 	defer db.Close()
 
-	apiKey := apiKeyFromEnv()
-
-	fetcher := services.NewFetcher()
-	extractor := services.NewExtractor()
-	var summarizer *services.Summarizer
-	if apiKey != "" {
-		summarizer = services.NewSummarizer(apiKey)
-	}
-
 	// Collect URLs: positional args first, then stdin if it is a pipe.
 	urls := append([]string(nil), args...)
 
+	var pageHTML string
 	stat, _ := os.Stdin.Stat()
-	if stat.Mode()&os.ModeCharDevice == 0 {
+	if addFromHTML {
+		if stat.Mode()&os.ModeCharDevice != 0 {
+			return fmt.Errorf("--from-html reads the page from stdin: cat page.html | lm add --from-html <url>")
+		}
+		html, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return fmt.Errorf("failed to read stdin: %w", err)
+		}
+		if strings.TrimSpace(string(html)) == "" {
+			return fmt.Errorf("no HTML on stdin")
+		}
+		pageHTML = string(html)
+	} else if stat.Mode()&os.ModeCharDevice == 0 {
 		scanner := bufio.NewScanner(os.Stdin)
 		for scanner.Scan() {
 			line := strings.TrimSpace(scanner.Text())
@@ -92,33 +139,92 @@ func runAdd(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("no URLs provided: pass as arguments or pipe via stdin")
 	}
 
+	apiKey := apiKeyFromEnv()
+
+	if addEstimate {
+		var est costEstimate
+		// Reference-only links are never summarised.
+		if !addNoExtract && !addCapture {
+			for _, url := range urls {
+				est.addNewURL(ctx, db, url, true)
+			}
+		}
+		return est.emit(apiKey)
+	}
+
+	fetcher, err := renderingFetcher(addRender)
+	if err != nil {
+		return err
+	}
+	extractor := extractorFromEnv()
+	var summarizer *services.Summarizer
+	if apiKey != "" && !addNoExtract && !addCapture {
+		summarizer = checkedSummarizer(ctx, apiKey)
+	}
+	var webhook *services.Webhook
+	if webhookURL := webhookURLFromEnv(); webhookURL != "" {
+		webhook = services.NewWebhook(webhookURL)
+	}
+	opts := addOptions{
+		db:            db,
+		fetcher:       fetcher,
+		extractor:     extractor,
+		summarizer:    summarizer,
+		webhook:       webhook,
+		statusRule:    statusRuleFromEnv(),
+		categoryRules: categoryRulesFromEnv(),
+		defaults:      linkDefaultsFromEnv(),
+		source:        "cli",
+		category:      addCategory,
+		linkType:      addType,
+		taskName:      addTaskName,
+		activityName:  addActivityName,
+		noExtract:     addNoExtract,
+		capture:       addCapture,
+		html:          pageHTML,
+	}
+
 	// Process each URL, accumulating token usage across all of them.
 	var grandInputTok, grandOutputTok int
 	var processed, skipped int
 	multi := len(urls) > 1
+	results := make([]urlResult, 0, len(urls))
 
+	intr := watchInterrupt()
+	defer intr.stop()
 	for i, url := range urls {
+		if intr.requested() {
+			results = append(results, interruptSkipped(processed, urls[i:])...)
+			skipped += len(urls) - i
+			break
+		}
+		if costCapReached(addMaxCost, grandInputTok, grandOutputTok) {
+			results = append(results, costCapSkipped(addMaxCost, processed, urls[i:])...)
+			skipped += len(urls) - i
+			break
+		}
 		if multi {
 			slog.Info("processing URL", "index", i+1, "total", len(urls), "url", url)
 		}
-		inTok, outTok, err := addURL(ctx, db, fetcher, extractor, summarizer, url)
-		grandInputTok += inTok
-		grandOutputTok += outTok
+		res, err := addURL(ctx, opts, url, parseTags(addTags))
+		grandInputTok += res.InputTokens
+		grandOutputTok += res.OutputTokens
 		if err != nil {
 			slog.Error("failed to add URL", "url", url, "error", err)
+			results = append(results, urlResult{URL: url, Status: "failed", Error: err.Error()})
 			skipped++
 			continue
 		}
+		results = append(results, res.output(url))
 		processed++
 	}
 
-	if multi {
+	if multi || intr.requested() {
 		slog.Info("batch complete", "processed", processed, "skipped", skipped)
 	}
 
 	if grandInputTok+grandOutputTok > 0 {
-		cost := float64(grandInputTok)*0.15/1_000_000.0 +
-			float64(grandOutputTok)*0.60/1_000_000.0
+		cost := services.LLMCost(grandInputTok, grandOutputTok)
 		slog.Info("LLM usage total",
 			"input_tokens", grandInputTok,
 			"output_tokens", grandOutputTok,
@@ -126,51 +232,135 @@ func runAdd(cmd *cobra.Command, args []string) error {
 		)
 	}
 
+	if err := emit(results, nil); err != nil {
+		return err
+	}
+	if intr.requested() {
+		return errInterrupted
+	}
 	return nil
 }
 
-// addURL fetches, extracts, summarises, and saves a single URL.
-// It returns the number of LLM input and output tokens consumed.
-func addURL(ctx context.Context, db *database.Database, fetcher *services.Fetcher, extractor *services.Extractor, summarizer *services.Summarizer, url string) (inputTok, outputTok int, err error) {
+// addResult describes what addURL did with a URL.
+type addResult struct {
+	Link         models.Link
+	Existing     bool   // the URL (or its canonical form) was already saved
+	Warning      string // a problem worth a second look, e.g. a thin extraction
+	InputTokens  int
+	OutputTokens int
+}
+
+// output converts the result for --json.
+func (r addResult) output(url string) urlResult {
+	status := "added"
+	if r.Existing {
+		status = "exists"
+	}
+	return urlResult{URL: url, ID: r.Link.ID, Title: r.Link.Title.String, Status: status, Warning: r.Warning}
+}
+
+// addOptions holds what addURL needs besides the URL: the services each
+// link goes through and how it is to be saved. runAdd and runImport build
+// one for the whole run.
+type addOptions struct {
+	db            *database.Database
+	fetcher       *services.Fetcher
+	extractor     *services.Extractor
+	summarizer    *services.Summarizer
+	webhook       *services.Webhook
+	statusRule    services.StatusRule
+	categoryRules services.CategoryRules
+	defaults      services.LinkDefaults
+
+	source       string // where links came from: "cli", or the import format
+	category     string // overrides the rules and the AI's suggestion
+	linkType     string // "task" or "activity" also files the link under one
+	taskName     string // defaults to the page title
+	activityName string // defaults to the page title
+	noExtract    bool   // keep only the title
+	capture      bool   // keep only the URL and title, for 'lm process'
+	html         string // the page itself, if given, instead of fetching it
+}
+
+// addURL fetches, extracts, summarises, and saves a single URL, then notifies
+// the webhook if one is configured. tags, if any, replace the AI-suggested
+// tags. opts.statusRule picks its status from its length. The first of
+// opts.categoryRules to match picks its category ahead of the AI's
+// suggestion; opts.category overrides both, and opts.defaults covers links
+// left without a category or tags. With opts.noExtract only the title is
+// kept. The result includes the number of LLM tokens consumed.
+func addURL(ctx context.Context, opts addOptions, url string, tags []string) (addResult, error) {
+	db, fetcher, extractor, summarizer := opts.db, opts.fetcher, opts.extractor, opts.summarizer
 	slog.Info("fetching URL", "url", url)
 
 	// Skip duplicates.
-	existing, err := db.Queries.GetLinkByURL(ctx, url)
-	if err == nil {
-		slog.Info("URL already exists", "id", existing.ID, "title", existing.Title.String)
-		return 0, 0, nil
+	if existing, found, err := skipExisting(ctx, db, url); found || err != nil {
+		return addResult{Link: existing, Existing: found}, err
 	}
 
-	html, err := fetcher.FetchURL(ctx, url)
+	fetchPage := services.FetchPage
+	if opts.noExtract || opts.capture {
+		fetchPage = services.FetchTitle
+	}
+	if opts.html != "" {
+		// The page was piped in; save it under the URL given, whatever
+		// canonical URL it declares.
+		fetchPage = func(ctx context.Context, fetcher *services.Fetcher, extractor *services.Extractor, url string) (services.Page, error) {
+			page, err := services.PageFromHTML(extractor, url, opts.html, fetcher.ArchivesHTML())
+			page.Canonical = ""
+			return page, err
+		}
+	}
+	page, err := fetchPage(ctx, fetcher, extractor, url)
+	if err != nil && opts.capture {
+		// Keep the URL anyway; 'lm process' will report if it stays unreachable.
+		slog.Warn("could not fetch title", "url", url, "error", err)
+		page, err = services.Page{}, nil
+	}
 	if err != nil {
-		return 0, 0, fmt.Errorf("fetch failed: %w", err)
+		return addResult{}, err
+	}
+	title, text, canonical := page.Title, page.Text, page.Canonical
+	var warning string
+	if page.Thin {
+		warning = thinExtractionWarning(fetcher)
+		slog.Warn(warning, "url", url, "chars", len(text))
 	}
 
-	slog.Info("extracting content")
-	title, text, err := extractor.ExtractText(html, url)
-	if err != nil {
-		return 0, 0, fmt.Errorf("extraction failed: %w", err)
+	// Prefer the page's canonical URL so variants of it dedup together.
+	if canonical != "" && canonical != url {
+		slog.Info("using canonical URL", "url", canonical)
+		if existing, found, err := skipExisting(ctx, db, canonical); found || err != nil {
+			return addResult{Link: existing, Existing: found}, err
+		}
+		url = canonical
 	}
 	content := extractor.TruncateText(text, 10000)
 
 	var summary, suggestedCat string
 	var suggestedTags []string
+	var inputTok, outputTok int
+	ruleCat := opts.categoryRules.Category(url, title)
 
 	if summarizer != nil {
 		slog.Info("summarising", "url", url)
 		var inTok, outTok int
 
 		summary, inTok, outTok, _ = summarizer.Summarize(ctx, title, text)
+		db.RecordLLMUsage(ctx, services.LLMModel, database.OpSummarize, inTok, outTok, services.LLMCost(inTok, outTok))
 		inputTok += inTok
 		outputTok += outTok
 
-		suggestedCat, suggestedTags, inTok, outTok, _ = summarizer.SuggestMetadata(ctx, title, text)
-		inputTok += inTok
-		outputTok += outTok
+		// With the category and tags already decided there is nothing to suggest.
+		if (ruleCat == "" && strings.TrimSpace(opts.category) == "") || len(tags) == 0 {
+			suggestedCat, suggestedTags, inTok, outTok, _ = summarizer.SuggestMetadata(ctx, title, text)
+			db.RecordLLMUsage(ctx, services.LLMModel, database.OpSuggestMetadata, inTok, outTok, services.LLMCost(inTok, outTok))
+			inputTok += inTok
+			outputTok += outTok
+		}
 
 		if inputTok+outputTok > 0 {
-			cost := float64(inputTok)*0.15/1_000_000.0 +
-				float64(outputTok)*0.60/1_000_000.0
+			cost := services.LLMCost(inputTok, outputTok)
 			slog.Info("LLM usage",
 				"url", url,
 				"input_tokens", inputTok,
@@ -181,68 +371,74 @@ func addURL(ctx context.Context, db *database.Database, fetcher *services.Fetche
 	}
 
 	// Save link.
+	words := services.WordCount(text)
 	link, err := db.Queries.CreateLink(ctx, models.CreateLinkParams{
-		Url:     url,
-		Title:   sql.NullString{String: title, Valid: title != ""},
-		Content: sql.NullString{String: content, Valid: content != ""},
-		Summary: sql.NullString{String: summary, Valid: summary != ""},
-		Status:  "read_later",
+		Url:           url,
+		Title:         sql.NullString{String: title, Valid: title != ""},
+		Content:       sql.NullString{String: content, Valid: content != ""},
+		Summary:       sql.NullString{String: summary, Valid: summary != ""},
+		Status:        opts.statusRule.Status(words),
+		Domain:        services.DomainFromURL(url),
+		ContentLength: words,
+		Source:        opts.source,
 	})
 	if err != nil {
-		return inputTok, outputTok, fmt.Errorf("failed to save link: %w", err)
+		return addResult{InputTokens: inputTok, OutputTokens: outputTok}, fmt.Errorf("failed to save link: %w", err)
+	}
+	_ = db.Queries.UpdateLinkFetchedAt(ctx, link.ID)
+	db.ArchiveHTML(ctx, link.ID, page.HTML)
+	db.SaveImages(ctx, link.ID, storedImages(page.Images))
+	if summary != "" {
+		_ = db.Queries.UpdateLinkSummarizedAt(ctx, link.ID)
+		inTok, outTok := refreshShortSummary(ctx, db, summarizer, link.ID, title, summary)
+		inputTok += inTok
+		outputTok += outTok
+	}
+
+	if opts.capture {
+		if err := db.Queries.AddLinkCapture(ctx, link.ID); err != nil {
+			slog.Warn("could not mark link for processing", "id", link.ID, "error", err)
+		}
 	}
 
 	slog.Info("link saved", "id", link.ID, "title", link.Title.String)
 
-	// Category: flag value takes priority over AI suggestion.
-	catName := strings.TrimSpace(addCategory)
+	// Category: flag value takes priority over a rule, then AI suggestion,
+	// then the default. Captures are left for 'lm process' to file.
+	catName := strings.TrimSpace(opts.category)
+	if catName == "" {
+		catName = ruleCat
+	}
 	if catName == "" {
 		catName = strings.TrimSpace(suggestedCat)
 	}
+	if !opts.capture {
+		catName = opts.defaults.CategoryOr(catName)
+	}
 	if catName != "" {
-		cat, catErr := db.Queries.GetCategoryByName(ctx, catName)
-		if catErr != nil {
-			cat, catErr = db.Queries.CreateCategory(ctx, models.CreateCategoryParams{
-				Name:        catName,
-				Description: sql.NullString{Valid: false},
-			})
-			if catErr != nil {
-				slog.Warn("could not create category", "name", catName, "error", catErr)
-			}
-		}
-		if catErr == nil {
-			_ = db.Queries.LinkCategory(ctx, models.LinkCategoryParams{LinkID: link.ID, CategoryID: cat.ID})
-			slog.Info("category assigned", "name", cat.Name)
-		}
+		assignCategory(ctx, db, link.ID, catName)
 	}
 
-	// Tags: flag value takes priority over AI suggestion.
-	tagList := parseTags(addTags)
+	// Tags: given tags take priority over AI suggestion.
+	tagList := append([]string(nil), tags...)
 	if len(tagList) == 0 {
 		tagList = suggestedTags
 	}
-	for _, tagName := range tagList {
-		if tagName == "" {
-			continue
-		}
-		t, tagErr := db.Queries.GetTagByName(ctx, tagName)
-		if tagErr != nil {
-			t, tagErr = db.Queries.CreateTag(ctx, tagName)
-			if tagErr != nil {
-				slog.Warn("could not create tag", "name", tagName, "error", tagErr)
-				continue
-			}
-		}
-		_ = db.Queries.LinkTag(ctx, models.LinkTagParams{LinkID: link.ID, TagID: t.ID})
+	if page.Tag != "" {
+		tagList = append(tagList, page.Tag)
 	}
+	if !opts.capture {
+		tagList = opts.defaults.TagsOr(tagList)
+	}
+	assignTags(ctx, db, link.ID, tagList)
 	if len(tagList) > 0 {
 		slog.Info("tags assigned", "tags", strings.Join(tagList, ", "))
 	}
 
 	// Task / Activity association.
-	switch addType {
+	switch opts.linkType {
 	case "task":
-		taskName := strings.TrimSpace(addTaskName)
+		taskName := strings.TrimSpace(opts.taskName)
 		if taskName == "" {
 			taskName = title
 		}
@@ -261,7 +457,7 @@ func addURL(ctx context.Context, db *database.Database, fetcher *services.Fetche
 		}
 
 	case "activity":
-		actName := strings.TrimSpace(addActivityName)
+		actName := strings.TrimSpace(opts.activityName)
 		if actName == "" {
 			actName = title
 		}
@@ -284,7 +480,96 @@ func addURL(ctx context.Context, db *database.Database, fetcher *services.Fetche
 		slog.Info("summary generated", "summary", summary)
 	}
 
-	return inputTok, outputTok, nil
+	// A failed delivery is logged but does not fail the add.
+	if opts.webhook != nil {
+		if err := opts.webhook.LinkAdded(ctx, services.LinkAddedEvent{
+			URL:     url,
+			Title:   title,
+			Summary: summary,
+			Tags:    tagList,
+		}); err != nil {
+			slog.Warn("webhook delivery failed", "url", url, "error", err)
+		}
+	}
+
+	return addResult{Link: link, InputTokens: inputTok, OutputTokens: outputTok, Warning: warning}, nil
+}
+
+// skipExisting returns the saved link for url, if any. Re-adding a trashed
+// link brings it back rather than failing on the unique URL.
+func skipExisting(ctx context.Context, db *database.Database, url string) (models.Link, bool, error) {
+	existing, err := db.Queries.GetLinkByURL(ctx, url)
+	if err != nil {
+		return models.Link{}, false, nil
+	}
+	if existing.DeletedAt.Valid {
+		if err := db.Queries.RestoreLink(ctx, existing.ID); err != nil {
+			return existing, true, fmt.Errorf("restore failed: %w", err)
+		}
+		slog.Info("restored link from trash", "id", existing.ID, "title", existing.Title.String)
+		return existing, true, nil
+	}
+	slog.Info("URL already exists", "id", existing.ID, "title", existing.Title.String)
+	return existing, true, nil
+}
+
+// thinExtractionWarning explains a near-empty extraction and how to retry
+// it with the fetcher's rendering options.
+func thinExtractionWarning(fetcher *services.Fetcher) string {
+	const msg = "extraction may have failed: content is very short"
+	switch {
+	case fetcher.AlwaysRenders():
+		return msg + "; check the page in a browser, it may need a login"
+	case fetcher.CanRender():
+		return msg + "; retry with 'lm refetch --render'"
+	default:
+		return msg + "; set RENDER_URL and retry with 'lm refetch --render' if the site needs JavaScript"
+	}
+}
+
+// assignCategory files linkID under catName, creating it if needed, and applies its default tags.
+func assignCategory(ctx context.Context, db *database.Database, linkID int64, catName string) {
+	cat, err := db.Queries.GetCategoryByName(ctx, catName)
+	if err != nil {
+		cat, err = db.Queries.CreateCategory(ctx, models.CreateCategoryParams{
+			Name:        catName,
+			Description: sql.NullString{Valid: false},
+		})
+		if err != nil {
+			// Another import worker may have just created it.
+			if cat, err = db.Queries.GetCategoryByName(ctx, catName); err != nil {
+				slog.Warn("could not create category", "name", catName, "error", err)
+				return
+			}
+		}
+	}
+	_ = db.Queries.LinkCategory(ctx, models.LinkCategoryParams{LinkID: linkID, CategoryID: cat.ID})
+	slog.Info("category assigned", "name", cat.Name)
+	if err := db.ApplyCategoryDefaultTags(ctx, linkID, cat); err != nil {
+		slog.Warn("could not apply category default tags", "name", cat.Name, "error", err)
+	}
+}
+
+// assignTags tags a link, creating tags that do not exist yet. Failures are
+// logged and skipped.
+func assignTags(ctx context.Context, db *database.Database, linkID int64, tagList []string) {
+	for _, tagName := range tagList {
+		if tagName == "" {
+			continue
+		}
+		t, err := db.Queries.GetTagByName(ctx, tagName)
+		if err != nil {
+			t, err = db.Queries.CreateTag(ctx, tagName)
+			if err != nil {
+				// Another import worker may have just created it.
+				if t, err = db.Queries.GetTagByName(ctx, tagName); err != nil {
+					slog.Warn("could not create tag", "name", tagName, "error", err)
+					continue
+				}
+			}
+		}
+		_ = db.Queries.LinkTag(ctx, models.LinkTagParams{LinkID: linkID, TagID: t.ID})
+	}
 }
 
 func parseTags(raw string) []string {
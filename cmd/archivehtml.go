@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"mccwk.com/lm/internal/database"
+	"mccwk.com/lm/internal/models"
+	"mccwk.com/lm/internal/services"
+)
+
+var (
+	archiveHTMLAll bool
+	archiveHTMLDir string
+)
+
+var archiveHTMLCmd = &cobra.Command{
+	Use:   "archive-html [url|id...]",
+	Short: "Write saved links as standalone HTML files",
+	Long: `Write each link as a single self-contained HTML file that opens in any
+browser without lm: the title, URL, dates, status, categories, tags, and
+summary, followed by the saved content rendered from markdown.
+
+Links may be given by URL or numeric ID, or use --all for every link
+outside the trash. Files are named <id>-<title>.html; re-running
+overwrites them with the link's current content.
+
+  --all          Archive every saved link.
+  --dir <path>   Directory to write to (default ./archive); created if
+                 missing.
+
+Only text is archived: images in the content still load from their
+original sites.`,
+	Args: cobra.ArbitraryArgs,
+	RunE: runArchiveHTML,
+}
+
+func init() {
+	archiveHTMLCmd.Flags().BoolVar(&archiveHTMLAll, "all", false, "Archive every saved link")
+	archiveHTMLCmd.Flags().StringVar(&archiveHTMLDir, "dir", "archive", "Directory to write the HTML files to")
+	rootCmd.AddCommand(archiveHTMLCmd)
+}
+
+// archiveResult is the JSON form of one link written by archive-html.
+type archiveResult struct {
+	ID    int64  `json:"id,omitempty"`
+	URL   string `json:"url"`
+	Title string `json:"title,omitempty"`
+	Path  string `json:"path,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+func runArchiveHTML(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	if archiveHTMLAll == (len(args) > 0) {
+		return fmt.Errorf("pass one or more URLs or IDs, or --all")
+	}
+
+	if dir, err := configDir(); err == nil {
+		_ = loadEnvFile(dir)
+	}
+
+	db := database.New(dbPathFromEnv())
+	defer db.Close()
+
+	if err := os.MkdirAll(archiveHTMLDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", archiveHTMLDir, err)
+	}
+
+	var results []archiveResult
+	if archiveHTMLAll {
+		// LIMIT -1 means no limit in SQLite.
+		links, err := db.Queries.ListLinks(ctx, models.ListLinksParams{Limit: -1, Offset: 0})
+		if err != nil {
+			return fmt.Errorf("failed to list links: %w", err)
+		}
+		for _, link := range links {
+			results = append(results, archiveLinkHTML(ctx, db, link))
+		}
+	} else {
+		for _, arg := range args {
+			link, err := lookupLink(ctx, db, arg)
+			if errors.Is(err, sql.ErrNoRows) {
+				results = append(results, archiveResult{URL: arg, Error: "no matching link"})
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("lookup failed: %w", err)
+			}
+			results = append(results, archiveLinkHTML(ctx, db, link))
+		}
+	}
+
+	var written, failed int
+	for _, r := range results {
+		if r.Error != "" {
+			failed++
+			slog.Error("failed to archive link", "url", r.URL, "error", r.Error)
+		} else {
+			written++
+		}
+	}
+
+	return emit(results, func() {
+		for _, r := range results {
+			if r.Error == "" {
+				printf("%s\n", r.Path)
+			}
+		}
+		printf("Archived %d links to %s", written, archiveHTMLDir)
+		if failed > 0 {
+			printf(" (%d failed)", failed)
+		}
+		printf("\n")
+	})
+}
+
+// archiveLinkHTML writes link to the archive directory.
+func archiveLinkHTML(ctx context.Context, db *database.Database, link models.Link) archiveResult {
+	result := archiveResult{ID: link.ID, URL: link.Url, Title: link.Title.String}
+
+	page, err := archivePage(ctx, db, link)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	path := filepath.Join(archiveHTMLDir, services.ArchiveFilename(link.ID, link.Title.String))
+	f, err := os.Create(path)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	if err := services.WriteArchiveHTML(f, page); err != nil {
+		f.Close()
+		result.Error = err.Error()
+		return result
+	}
+	if err := f.Close(); err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.Path = path
+	return result
+}
+
+// archivePage returns link, with its categories and tags, as a page for
+// services.WriteArchiveHTML.
+func archivePage(ctx context.Context, db *database.Database, link models.Link) (services.ArchivePage, error) {
+	categories, tags, err := db.LinkLabels(ctx, link.ID)
+	if err != nil {
+		return services.ArchivePage{}, err
+	}
+	page := services.ArchivePage{
+		URL:        link.Url,
+		Title:      link.Title.String,
+		Summary:    link.Summary.String,
+		Content:    link.Content.String,
+		Status:     link.Status,
+		Categories: categories,
+		Tags:       tags,
+		Added:      link.CreatedAt,
+	}
+	if link.FetchedAt.Valid {
+		page.Fetched = link.FetchedAt.Time
+	}
+	return page, nil
+}
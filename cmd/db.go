@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+
+	"github.com/spf13/cobra"
+)
+
+var dbShellSqlite string
+
+var dbCmd = &cobra.Command{
+	Use:   "db",
+	Short: "Work with the database directly",
+	Long: `Low-level access to the lm database, for ad-hoc queries the other
+commands do not cover.
+
+  lm db shell    Open an interactive SQLite prompt on the database.`,
+	Args: cobra.NoArgs,
+}
+
+var dbShellCmd = &cobra.Command{
+	Use:   "shell",
+	Short: "Open an interactive SQLite prompt on the database",
+	Long: `Open the sqlite3 command-line shell on the configured database (DB_PATH,
+or lm.db in the config directory), for ad-hoc queries the other commands
+do not support.
+
+Changes made here bypass lm entirely: nothing checks them, and they cannot
+be undone. Copy the database file first if you mean to edit anything.
+
+With --json, the connection details are printed instead of starting a
+shell, for use with another SQLite client.
+
+  --sqlite3 <cmd>     SQLite shell to run (default sqlite3).`,
+	Args: cobra.NoArgs,
+	RunE: runDBShell,
+}
+
+func init() {
+	dbShellCmd.Flags().StringVar(&dbShellSqlite, "sqlite3", "sqlite3", "SQLite shell executable to run")
+	dbCmd.AddCommand(dbShellCmd)
+	rootCmd.AddCommand(dbCmd)
+}
+
+// dbInfo is the JSON form of 'lm db shell'.
+type dbInfo struct {
+	Driver string `json:"driver"`
+	Path   string `json:"path"`
+}
+
+func runDBShell(cmd *cobra.Command, args []string) error {
+	if dir, err := configDir(); err == nil {
+		_ = loadEnvFile(dir)
+	}
+	path := dbPathFromEnv()
+
+	// sqlite3 would quietly create an empty database at a mistyped path.
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("no database at %s (run 'lm init' or check DB_PATH)", path)
+	}
+	if jsonOutput {
+		return emit(dbInfo{Driver: "sqlite", Path: path}, nil)
+	}
+
+	bin, err := exec.LookPath(dbShellSqlite)
+	if err != nil {
+		return fmt.Errorf("%s not found: install the SQLite command-line shell, or open %s with another SQLite client", dbShellSqlite, path)
+	}
+
+	slog.Warn("editing the database directly bypasses lm and cannot be undone; back it up first", "path", path)
+	shell := exec.Command(bin, path)
+	shell.Stdin = os.Stdin
+	shell.Stdout = os.Stdout
+	shell.Stderr = os.Stderr
+	return shell.Run()
+}
@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"mccwk.com/lm/internal/database"
+	"mccwk.com/lm/internal/models"
+	"mccwk.com/lm/internal/services"
+)
+
+var (
+	dedupeDryRun bool
+	dedupeYes    bool
+)
+
+var dedupeCmd = &cobra.Command{
+	Use:   "dedupe",
+	Short: "Merge links saved more than once under different URLs",
+	Long: `Find links saved under different forms of the same URL and merge each set
+into one. URLs count as the same when they differ only in http or https,
+"www.", a default port, a trailing slash, a #fragment, tracking parameters
+such as utm_source or fbclid, or the order of their query parameters.
+
+The link of each set saved first (the lowest ID) is kept. It gains the
+others' tags, categories, tasks, activities, and open history, and the
+longest summary among them; the others move to the trash. Everything is
+merged in one transaction, so an error leaves the library as it was.
+
+  --dry-run   List the duplicates without changing anything.
+  --yes       Skip the confirmation prompt.`,
+	Args: cobra.NoArgs,
+	RunE: runDedupe,
+}
+
+func init() {
+	dedupeCmd.Flags().BoolVar(&dedupeDryRun, "dry-run", false, "Show the duplicates without merging them")
+	dedupeCmd.Flags().BoolVarP(&dedupeYes, "yes", "y", false, "Merge without asking for confirmation")
+	rootCmd.AddCommand(dedupeCmd)
+}
+
+func runDedupe(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	if dir, err := configDir(); err == nil {
+		_ = loadEnvFile(dir)
+	}
+
+	db := database.New(dbPathFromEnv())
+	defer db.Close()
+
+	links, err := db.Queries.ListLinksAfterID(ctx, 0)
+	if err != nil {
+		return fmt.Errorf("failed to list links: %w", err)
+	}
+	groups := duplicateGroups(links)
+	out := toDedupeOutput(groups)
+	if len(groups) == 0 {
+		return emit(out, func() { fmt.Println("No duplicate links found.") })
+	}
+
+	var dupes int
+	for _, g := range groups {
+		dupes += len(g.Dupes)
+	}
+	if dedupeDryRun {
+		return emit(out, func() {
+			fmt.Printf("Would merge %d duplicate(s) into %d link(s):\n\n", dupes, len(groups))
+			printDuplicateGroups(groups)
+		})
+	}
+
+	if !dedupeYes {
+		if jsonOutput {
+			return fmt.Errorf("--json needs --yes or --dry-run")
+		}
+		printDuplicateGroups(groups)
+		in := bufio.NewReader(os.Stdin)
+		question := fmt.Sprintf("Merge %d duplicate(s) into %d link(s)?", dupes, len(groups))
+		if !promptYesNo(in, question, false) {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+	}
+
+	if err := db.MergeDuplicates(ctx, groups); err != nil {
+		return err
+	}
+	out.Merged = true
+	return emit(out, func() {
+		fmt.Printf("Merged %d duplicate(s) into %d link(s); the duplicates are in the trash.\n", dupes, len(groups))
+	})
+}
+
+// duplicateGroups groups links, in ID order, by services.DedupeKey and
+// returns the groups with more than one link, keeping the first of each.
+func duplicateGroups(links []models.Link) []database.DuplicateGroup {
+	byKey := make(map[string]int)
+	var groups []database.DuplicateGroup
+	for _, l := range links {
+		key := services.DedupeKey(l.Url)
+		if i, ok := byKey[key]; ok {
+			groups[i].Dupes = append(groups[i].Dupes, l)
+			continue
+		}
+		byKey[key] = len(groups)
+		groups = append(groups, database.DuplicateGroup{Keep: l})
+	}
+
+	dupes := groups[:0]
+	for _, g := range groups {
+		if len(g.Dupes) > 0 {
+			dupes = append(dupes, g)
+		}
+	}
+	return dupes
+}
+
+func printDuplicateGroups(groups []database.DuplicateGroup) {
+	for _, g := range groups {
+		title := g.Keep.Title.String
+		if title == "" {
+			title = g.Keep.Url
+		}
+		fmt.Printf("%d. %s\n", g.Keep.ID, title)
+		fmt.Printf("   keep   %s\n", g.Keep.Url)
+		for _, d := range g.Dupes {
+			fmt.Printf("   merge  %s (%d)\n", d.Url, d.ID)
+		}
+		if best := g.SummarySource(); best.ID != g.Keep.ID {
+			fmt.Printf("   summary from %d\n", best.ID)
+		}
+		fmt.Println()
+	}
+}
+
+// dedupeOutput is the JSON form of 'lm dedupe'.
+type dedupeOutput struct {
+	Merged bool                `json:"merged"`
+	Groups []dedupeGroupOutput `json:"groups"`
+}
+
+type dedupeGroupOutput struct {
+	Keep       linkOutput   `json:"keep"`
+	Duplicates []linkOutput `json:"duplicates"`
+}
+
+func toDedupeOutput(groups []database.DuplicateGroup) dedupeOutput {
+	out := dedupeOutput{Groups: make([]dedupeGroupOutput, 0, len(groups))}
+	for _, g := range groups {
+		out.Groups = append(out.Groups, dedupeGroupOutput{
+			Keep:       toLinkOutput(g.Keep),
+			Duplicates: toLinkOutputs(g.Dupes),
+		})
+	}
+	return out
+}
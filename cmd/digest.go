@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"mccwk.com/lm/internal/database"
+	"mccwk.com/lm/internal/models"
+)
+
+var (
+	digestSince time.Duration
+	digestEmail string
+)
+
+var digestCmd = &cobra.Command{
+	Use:   "digest",
+	Short: "Summarise the links saved recently",
+	Long: `Print the links added in the last day (or --since), grouped by category
+with their summaries, as Markdown for pasting into a journal.
+
+  --since <d>       How far back to look (default 24h), e.g. 168h for a week.
+  --email <addr>    Email the digest to addr (comma-separate several) instead
+                    of printing it. Requires SMTP_HOST and SMTP_FROM, plus
+                    SMTP_PORT (default 587), SMTP_USERNAME, and SMTP_PASSWORD
+                    as your server needs.`,
+	Args: cobra.NoArgs,
+	RunE: runDigest,
+}
+
+func init() {
+	digestCmd.Flags().DurationVar(&digestSince, "since", 24*time.Hour, "How far back to look, e.g. 168h")
+	digestCmd.Flags().StringVar(&digestEmail, "email", "", "Email the digest to this address instead of printing it")
+	rootCmd.AddCommand(digestCmd)
+}
+
+func runDigest(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	if digestSince <= 0 {
+		return fmt.Errorf("invalid --since %s: must be positive", digestSince)
+	}
+
+	if dir, err := configDir(); err == nil {
+		_ = loadEnvFile(dir)
+	}
+
+	mailer := mailerFromEnv()
+	if digestEmail != "" && mailer == nil {
+		return fmt.Errorf("--email requires SMTP_HOST to be set")
+	}
+
+	db := database.New(dbPathFromEnv())
+	defer db.Close()
+
+	links, err := db.Queries.ListLinksAddedSince(ctx, fmt.Sprintf("-%d seconds", int64(digestSince.Seconds())))
+	if err != nil {
+		return fmt.Errorf("digest failed: %w", err)
+	}
+
+	since := time.Now().Add(-digestSince)
+	subject := fmt.Sprintf("lm digest: %d links since %s", len(links), since.Format("Mon Jan 2 15:04"))
+	out := digestOutput{Since: since, Links: toLinkOutputs(links)}
+	if len(links) == 0 {
+		return emit(out, func() {
+			fmt.Printf("No links saved since %s.\n", since.Format("Mon Jan 2 15:04"))
+		})
+	}
+
+	body, err := buildDigest(ctx, db, links, since)
+	if err != nil {
+		return err
+	}
+
+	if digestEmail == "" {
+		return emit(out, func() { fmt.Print(body) })
+	}
+	if err := mailer.Send(parseAddresses(digestEmail), subject, body); err != nil {
+		return err
+	}
+	out.EmailedTo = parseAddresses(digestEmail)
+	return emit(out, func() {
+		fmt.Printf("Digest of %d links sent to %s\n", len(links), digestEmail)
+	})
+}
+
+// digestOutput is the JSON form of 'lm digest'.
+type digestOutput struct {
+	Since     time.Time    `json:"since"`
+	EmailedTo []string     `json:"emailed_to,omitempty"`
+	Links     []linkOutput `json:"links"`
+}
+
+// buildDigest renders links as Markdown grouped by their first category, with
+// uncategorized links last.
+func buildDigest(ctx context.Context, db *database.Database, links []models.Link, since time.Time) (string, error) {
+	const uncategorized = "Uncategorized"
+	groups := map[string][]models.Link{}
+	for _, l := range links {
+		name := uncategorized
+		categories, err := db.Queries.GetCategoriesForLink(ctx, l.ID)
+		if err != nil {
+			return "", fmt.Errorf("failed to load categories for link %d: %w", l.ID, err)
+		}
+		if len(categories) > 0 {
+			name = categories[0].Name
+		}
+		groups[name] = append(groups[name], l)
+	}
+
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		if name != uncategorized {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	if _, ok := groups[uncategorized]; ok {
+		names = append(names, uncategorized)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Links saved since %s\n", since.Format("Mon Jan 2 15:04"))
+	for _, name := range names {
+		fmt.Fprintf(&b, "\n## %s\n\n", name)
+		for _, l := range groups[name] {
+			title := l.Title.String
+			if title == "" {
+				title = l.Url
+			}
+			fmt.Fprintf(&b, "- [%s](%s)\n", title, l.Url)
+			if summary := strings.TrimSpace(l.Summary.String); summary != "" {
+				fmt.Fprintf(&b, "  %s\n", strings.ReplaceAll(summary, "\n", "\n  "))
+			}
+		}
+	}
+	return b.String(), nil
+}
+
+// parseAddresses splits a comma-separated list of email addresses.
+func parseAddresses(raw string) []string {
+	var out []string
+	for _, a := range strings.Split(raw, ",") {
+		if a = strings.TrimSpace(a); a != "" {
+			out = append(out, a)
+		}
+	}
+	return out
+}
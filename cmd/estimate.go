@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+
+	"mccwk.com/lm/internal/database"
+	"mccwk.com/lm/internal/services"
+)
+
+// costEstimate is the --estimate result of a bulk command: the LLM work it
+// would do and what that would cost, worked out without calling the API.
+// Tokens are counted at about four characters each.
+type costEstimate struct {
+	Links        int     `json:"links"`         // links that would be sent to the LLM
+	Skipped      int     `json:"skipped"`       // links that would not, e.g. already saved
+	ContentChars int     `json:"content_chars"` // page text counted, when it is known
+	InputTokens  int     `json:"input_tokens"`
+	OutputTokens int     `json:"output_tokens"`
+	CostUSD      float64 `json:"cost_usd"`
+	// UpperBound is set when some pages have not been fetched yet, so they
+	// were counted as long enough to fill the prompt.
+	UpperBound bool `json:"upper_bound"`
+}
+
+// addSummary counts one Summarize call on textLen characters of page text.
+func (e *costEstimate) addSummary(title string, textLen int) {
+	in, out := services.EstimateSummarize(title, textLen)
+	e.InputTokens += in
+	e.OutputTokens += out
+}
+
+// addMetadata counts one SuggestMetadata call on textLen characters of page
+// text.
+func (e *costEstimate) addMetadata(title string, textLen int) {
+	in, out := services.EstimateSuggestMetadata(title, textLen)
+	e.InputTokens += in
+	e.OutputTokens += out
+}
+
+// addNewURL counts a URL that would be fetched and summarised if it is not
+// saved yet, as 'lm add' does. withMetadata adds the category and tag
+// suggestion request.
+func (e *costEstimate) addNewURL(ctx context.Context, db *database.Database, url string, withMetadata bool) {
+	if _, err := db.Queries.GetLinkByURL(ctx, url); err == nil {
+		e.Skipped++
+		return
+	}
+	e.Links++
+	e.UpperBound = true
+	e.addSummary("", math.MaxInt)
+	if withMetadata {
+		e.addMetadata("", math.MaxInt)
+	}
+}
+
+// emit prints the estimate. apiKey is only checked for presence, to point
+// out when nothing would be summarised at all.
+func (e costEstimate) emit(apiKey string) error {
+	if apiKey == "" {
+		slog.Warn("no OPENAI_API_KEY configured: these links would be saved without summaries")
+	}
+	e.CostUSD = services.LLMCost(e.InputTokens, e.OutputTokens)
+	return emit(e, func() {
+		fmt.Printf("Links to summarise: %d", e.Links)
+		if e.Skipped > 0 {
+			fmt.Printf(" (%d skipped)", e.Skipped)
+		}
+		fmt.Println()
+		if e.ContentChars > 0 {
+			fmt.Printf("Content:            %d characters\n", e.ContentChars)
+		}
+		fmt.Printf("Estimated tokens:   ~%d input, ~%d output\n", e.InputTokens, e.OutputTokens)
+		qualifier := "~"
+		if e.UpperBound {
+			qualifier = "up to "
+		}
+		fmt.Printf("Estimated cost:     %s$%.4f (GPT-4o-mini)\n", qualifier, e.CostUSD)
+		if e.UpperBound {
+			fmt.Println("\nPages not fetched yet are counted at the prompt's size limit.")
+		}
+	})
+}
+
+// costCapReached reports whether LLM spend so far has reached maxCost US
+// dollars. Zero means no cap.
+func costCapReached(maxCost float64, inputTokens, outputTokens int) bool {
+	return maxCost > 0 && services.LLMCost(inputTokens, outputTokens) >= maxCost
+}
+
+// costCapSkipped logs that a run stopped at its cost cap and returns results
+// marking the URLs it did not get to as skipped.
+func costCapSkipped(maxCost float64, processed int, remaining []string) []urlResult {
+	slog.Warn("cost cap reached, stopping",
+		"max_cost_usd", fmt.Sprintf("$%.2f", maxCost),
+		"processed", processed,
+		"remaining", len(remaining),
+	)
+	results := make([]urlResult, 0, len(remaining))
+	for _, url := range remaining {
+		results = append(results, urlResult{URL: url, Status: "skipped", Error: "cost cap reached"})
+	}
+	return results
+}
+
+// validateMaxCost checks a --max-cost value.
+func validateMaxCost(maxCost float64) error {
+	if maxCost < 0 {
+		return fmt.Errorf("invalid --max-cost %.2f: must not be negative", maxCost)
+	}
+	return nil
+}
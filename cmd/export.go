@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"mccwk.com/lm/internal/database"
+	"mccwk.com/lm/internal/models"
+)
+
+var (
+	exportFormat  string
+	exportOutput  string
+	exportSinceID int64
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export saved links",
+	Long: `Export every saved link (excluding the trash), newest first.
+
+  --format csv      One row per link with columns
+                    url,title,summary,category,tags,status,created_at.
+                    Multiple categories or tags are joined with ";".
+  --output <file>   Write to file instead of stdout.
+  --since-id <n>    Only export links with an ID above n, oldest first, and
+                    print the highest ID exported to stderr. Pass that ID
+                    next time to export only what was added since:
+
+    lm export --since-id 0 > links.csv       # Last exported ID: 812
+    lm export --since-id 812 | lm import --format lm
+
+                    'lm import' skips URLs already saved, so re-exporting
+                    an overlapping range is harmless.`,
+	Args: cobra.NoArgs,
+	RunE: runExport,
+}
+
+func init() {
+	exportCmd.Flags().StringVar(&exportFormat, "format", "csv", "Export format: csv")
+	exportCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "File to write to (default stdout)")
+	exportCmd.Flags().Int64Var(&exportSinceID, "since-id", 0, "Only export links with an ID above this, for incremental syncs")
+	rootCmd.AddCommand(exportCmd)
+}
+
+func runExport(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	if exportFormat != "csv" {
+		return fmt.Errorf("invalid --format %q: must be csv", exportFormat)
+	}
+	incremental := cmd.Flags().Changed("since-id")
+	if exportSinceID < 0 {
+		return fmt.Errorf("invalid --since-id %d: must not be negative", exportSinceID)
+	}
+
+	if dir, err := configDir(); err == nil {
+		_ = loadEnvFile(dir)
+	}
+
+	db := database.New(dbPathFromEnv())
+	defer db.Close()
+
+	var links []models.Link
+	var err error
+	if incremental {
+		links, err = db.Queries.ListLinksAfterID(ctx, exportSinceID)
+	} else {
+		// LIMIT -1 means no limit in SQLite.
+		links, err = db.Queries.ListLinks(ctx, models.ListLinksParams{Limit: -1, Offset: 0})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to list links: %w", err)
+	}
+
+	out := io.Writer(os.Stdout)
+	if exportOutput != "" {
+		f, err := os.Create(exportOutput)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer f.Close()
+		out = f
+	}
+
+	if err := exportCSV(ctx, db, links, out); err != nil {
+		return err
+	}
+	if exportOutput != "" {
+		fmt.Fprintf(os.Stderr, "Exported %d links to %s\n", len(links), exportOutput)
+	}
+	if incremental {
+		// Links come oldest first, so the last has the highest ID. With
+		// nothing new, the next run starts from the same place.
+		lastID := exportSinceID
+		if len(links) > 0 {
+			lastID = links[len(links)-1].ID
+		}
+		fmt.Fprintf(os.Stderr, "Last exported ID: %d\n", lastID)
+	}
+	return nil
+}
+
+// exportCSV writes links as CSV with a header row.
+func exportCSV(ctx context.Context, db *database.Database, links []models.Link, out io.Writer) error {
+	w := csv.NewWriter(out)
+	if err := w.Write([]string{"url", "title", "summary", "category", "tags", "status", "created_at"}); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+
+	for _, link := range links {
+		categories, err := db.Queries.GetCategoriesForLink(ctx, link.ID)
+		if err != nil {
+			return fmt.Errorf("failed to load categories for link %d: %w", link.ID, err)
+		}
+		catNames := make([]string, 0, len(categories))
+		for _, c := range categories {
+			catNames = append(catNames, c.Name)
+		}
+
+		tags, err := db.Queries.GetTagsForLink(ctx, link.ID)
+		if err != nil {
+			return fmt.Errorf("failed to load tags for link %d: %w", link.ID, err)
+		}
+		tagNames := make([]string, 0, len(tags))
+		for _, t := range tags {
+			tagNames = append(tagNames, t.Name)
+		}
+
+		if err := w.Write([]string{
+			link.Url,
+			link.Title.String,
+			link.Summary.String,
+			strings.Join(catNames, ";"),
+			strings.Join(tagNames, ";"),
+			link.Status,
+			link.CreatedAt.Format(time.RFC3339),
+		}); err != nil {
+			return fmt.Errorf("failed to write CSV: %w", err)
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,504 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+
+	"mccwk.com/lm/internal/database"
+	"mccwk.com/lm/internal/importer"
+	"mccwk.com/lm/internal/models"
+	"mccwk.com/lm/internal/services"
+)
+
+var (
+	importFormat   string
+	importFetch    bool
+	importEstimate bool
+	importMaxCost  float64
+	importRender   bool
+	importJobs     int
+
+	importCheckpointPath string
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import --format <format> [file]",
+	Short: "Import links exported from another tool",
+	Long: `Import links from a file, or from stdin when no file is given.
+
+  --format pocket   Pocket's ril_export.html or CSV export. Tags are kept,
+                    and read/archived items are saved with status "archived"
+                    (unread ones as "read_later").
+  --format instapaper
+                    Instapaper's HTML or CSV export. Folders become
+                    categories, and archived items are saved as "archived"
+                    and starred ones as "remember". From the CSV, the saved
+                    date is kept as the link's added date, and any
+                    highlighted selection as its content.
+  --format urls     One URL per line, optionally followed by inline tags:
+                      https://example.com/post #golang #tools
+                    Blank lines and lines starting with # are skipped.
+  --format lm       The CSV written by 'lm export', for syncing one library
+                    into another. Tags and archived status are kept.
+
+Pocket, Instapaper, and lm links are saved without fetching unless --fetch
+is given, which fetches (and, if an API key is configured, summarises) each
+new link as it is imported, keeping an Instapaper selection quoted above the
+page text. URL lists are always fetched and summarised, like 'lm add'.
+URLs that are already saved are skipped. --estimate prints the projected AI
+summary cost of the import and exits without saving or fetching anything.
+--jobs <n> imports up to n links at once (default 4), logging progress and
+the running cost as each one finishes.
+--max-cost <usd> stops the import once AI summaries have cost that much; it
+is checked before each URL starts, so the pages already being fetched may
+take the total over by up to --jobs pages' worth.
+--render fetches pages through the RENDER_URL headless-browser service, as
+for 'lm add'.
+--checkpoint <file> appends each URL to file once it has been imported (or
+found already saved). Running the same import again with the same file
+skips those URLs without looking them up, so an interrupted import resumes
+where it stopped; URLs that failed are tried again.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runImport,
+}
+
+func init() {
+	importCmd.Flags().StringVar(&importFormat, "format", "", "Import format: pocket, instapaper, urls, or lm")
+	importCmd.Flags().BoolVar(&importFetch, "fetch", false, "Fetch and summarise each imported link")
+	importCmd.Flags().BoolVar(&importEstimate, "estimate", false, "Estimate the AI summary cost and exit without importing anything")
+	importCmd.Flags().Float64Var(&importMaxCost, "max-cost", 0, "Stop once AI summaries have cost this many US dollars (0 for no limit)")
+	importCmd.Flags().BoolVar(&importRender, "render", false, "Fetch pages through the RENDER_URL headless-browser service")
+	importCmd.Flags().IntVarP(&importJobs, "jobs", "j", 4, "Number of links to fetch at once")
+	importCmd.Flags().StringVar(&importCheckpointPath, "checkpoint", "", "File recording imported URLs, so an interrupted import can resume")
+	rootCmd.AddCommand(importCmd)
+}
+
+func runImport(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	var parse func(io.Reader) ([]importer.Item, error)
+	switch importFormat {
+	case "pocket":
+		parse = importer.Pocket
+	case "urls":
+		parse = importer.URLList
+	case "instapaper":
+		parse = importer.Instapaper
+	case "lm":
+		parse = importer.LM
+	case "":
+		return fmt.Errorf("--format is required: pocket, instapaper, urls, or lm")
+	default:
+		return fmt.Errorf("invalid --format %q: must be pocket, instapaper, urls, or lm", importFormat)
+	}
+	if err := validateMaxCost(importMaxCost); err != nil {
+		return err
+	}
+	if importJobs < 1 {
+		return fmt.Errorf("invalid --jobs %d: must be at least 1", importJobs)
+	}
+	// URL lists go through the full add pipeline.
+	viaAdd := importFormat == "urls"
+
+	in := io.Reader(os.Stdin)
+	if len(args) == 1 {
+		f, err := os.Open(args[0])
+		if err != nil {
+			return fmt.Errorf("failed to open import file: %w", err)
+		}
+		defer f.Close()
+		in = f
+	} else if stat, _ := os.Stdin.Stat(); stat.Mode()&os.ModeCharDevice != 0 {
+		return fmt.Errorf("no input: pass a file or pipe the export via stdin")
+	}
+
+	items, err := parse(in)
+	if err != nil {
+		return err
+	}
+	// The same URL twice would have two workers racing to save it.
+	items, repeats := uniqueItems(items)
+	if repeats > 0 {
+		slog.Info("skipped URLs repeated in the import file", "count", repeats)
+	}
+
+	if dir, err := configDir(); err == nil {
+		_ = loadEnvFile(dir)
+	}
+
+	db := database.New(dbPathFromEnv())
+	defer db.Close()
+
+	if importEstimate {
+		var est costEstimate
+		for _, item := range items {
+			switch {
+			case item.URL == "":
+				est.Skipped++
+			case viaAdd || importFetch:
+				// Fetched imports are summarised; only URL lists also
+				// get suggested tags and a category.
+				est.addNewURL(ctx, db, item.URL, viaAdd)
+			default:
+				est.Skipped++
+			}
+		}
+		return est.emit(apiKeyFromEnv())
+	}
+
+	var fetcher *services.Fetcher
+	var extractor *services.Extractor
+	var summarizer *services.Summarizer
+	if importFetch || viaAdd {
+		if fetcher, err = renderingFetcher(importRender); err != nil {
+			return err
+		}
+		extractor = extractorFromEnv()
+		if apiKey := apiKeyFromEnv(); apiKey != "" {
+			summarizer = checkedSummarizer(ctx, apiKey)
+		}
+	}
+	var webhook *services.Webhook
+	if webhookURL := webhookURLFromEnv(); webhookURL != "" && viaAdd {
+		webhook = services.NewWebhook(webhookURL)
+	}
+
+	checkpoint, err := openCheckpoint(importCheckpointPath)
+	if err != nil {
+		return err
+	}
+	defer checkpoint.Close()
+
+	// SQLite takes one writer at a time, so the workers share a single
+	// connection and their writes queue instead of failing as busy.
+	db.Conn.SetMaxOpenConns(1)
+
+	run := importRun{
+		addOptions: addOptions{
+			db:            db,
+			fetcher:       fetcher,
+			extractor:     extractor,
+			summarizer:    summarizer,
+			webhook:       webhook,
+			statusRule:    statusRuleFromEnv(),
+			categoryRules: categoryRulesFromEnv(),
+			defaults:      linkDefaultsFromEnv(),
+			source:        importFormat,
+		},
+		viaAdd: viaAdd,
+		total:  len(items),
+	}
+	slots := make([]*urlResult, len(items))
+	jobs := make(chan struct{}, importJobs)
+	var wg sync.WaitGroup
+	var resumed int
+	capAt := len(items)
+	intr := watchInterrupt()
+	defer intr.stop()
+	for i, item := range items {
+		if item.URL == "" {
+			continue
+		}
+		if checkpoint.has(item.URL) {
+			slots[i] = &urlResult{URL: item.URL, Status: "skipped"}
+			resumed++
+			continue
+		}
+		// Wait for a free worker before checking the cap, so the check sees
+		// the cost of every page finished so far. At Ctrl+C, the pages in
+		// flight finish and no more start.
+		jobs <- struct{}{}
+		if run.capReached() || intr.requested() {
+			<-jobs
+			capAt = i
+			break
+		}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-jobs
+				wg.Done()
+			}()
+			res, inTok, outTok := run.item(ctx, item)
+			slots[i] = &res
+			if res.Status != "failed" {
+				checkpoint.record(item.URL)
+			}
+			run.finish(inTok, outTok)
+		}()
+	}
+	wg.Wait()
+	if resumed > 0 {
+		slog.Info("skipped URLs already in the checkpoint", "count", resumed, "checkpoint", importCheckpointPath)
+	}
+
+	var imported, skipped int
+	results := make([]urlResult, 0, len(items))
+	for _, res := range slots[:capAt] {
+		switch {
+		case res == nil:
+			skipped++
+			continue
+		case res.Status == "failed" || res.Status == "skipped":
+			skipped++
+		default:
+			imported++
+		}
+		results = append(results, *res)
+	}
+	var remaining []string
+	for _, rest := range items[capAt:] {
+		if rest.URL != "" && !checkpoint.has(rest.URL) {
+			remaining = append(remaining, rest.URL)
+		}
+	}
+	if len(remaining) > 0 {
+		if intr.requested() {
+			results = append(results, interruptSkipped(imported, remaining)...)
+		} else {
+			results = append(results, costCapSkipped(importMaxCost, imported, remaining)...)
+		}
+		skipped += len(remaining)
+	}
+	grandInputTok, grandOutputTok := run.inputTok, run.outputTok
+
+	slog.Info("import complete", "imported", imported, "skipped", skipped)
+
+	if grandInputTok+grandOutputTok > 0 {
+		cost := services.LLMCost(grandInputTok, grandOutputTok)
+		slog.Info("LLM usage total",
+			"input_tokens", grandInputTok,
+			"output_tokens", grandOutputTok,
+			"cost_usd", fmt.Sprintf("$%.5f", cost),
+		)
+	}
+
+	if err := emit(results, nil); err != nil {
+		return err
+	}
+	if intr.requested() {
+		return errInterrupted
+	}
+	return nil
+}
+
+// importItem saves an imported link with its title, tags, read state, and
+// whatever else the export has of its folder, saved date, and highlighted
+// selection, with the import format as its source.
+func importItem(ctx context.Context, db *database.Database, item importer.Item) (models.Link, error) {
+	status := "read_later"
+	switch {
+	case item.Read:
+		status = "archived"
+	case item.Starred:
+		status = "remember"
+	}
+	link, err := db.Queries.CreateLink(ctx, models.CreateLinkParams{
+		Url:           item.URL,
+		Title:         sql.NullString{String: item.Title, Valid: item.Title != ""},
+		Content:       sql.NullString{String: item.Selection, Valid: item.Selection != ""},
+		Status:        status,
+		Domain:        services.DomainFromURL(item.URL),
+		ContentLength: services.WordCount(item.Selection),
+		Source:        importFormat,
+	})
+	if err != nil {
+		return models.Link{}, fmt.Errorf("failed to save link: %w", err)
+	}
+	if !item.Added.IsZero() {
+		err := db.Queries.UpdateLinkCreatedAt(ctx, models.UpdateLinkCreatedAtParams{
+			CreatedAt: item.Added.UTC(),
+			ID:        link.ID,
+		})
+		if err != nil {
+			slog.Warn("failed to set imported link's added date", "id", link.ID, "error", err)
+		}
+	}
+	if item.Category != "" {
+		assignCategory(ctx, db, link.ID, item.Category)
+	}
+	assignTags(ctx, db, link.ID, item.Tags)
+	slog.Info("link imported", "id", link.ID, "title", item.Title, "status", status)
+	return link, nil
+}
+
+// uniqueItems drops items whose URL appeared earlier in items, keeping the
+// first, and returns how many it dropped.
+func uniqueItems(items []importer.Item) ([]importer.Item, int) {
+	seen := make(map[string]bool, len(items))
+	unique := items[:0]
+	for _, item := range items {
+		if item.URL != "" && seen[item.URL] {
+			continue
+		}
+		seen[item.URL] = true
+		unique = append(unique, item)
+	}
+	return unique, len(items) - len(unique)
+}
+
+// importRun holds what the import workers share: the services each link goes
+// through, and the running token totals for the cost cap and progress.
+type importRun struct {
+	addOptions
+	viaAdd bool // URL lists go through the full add pipeline
+	total  int
+
+	mu        sync.Mutex
+	done      int
+	inputTok  int
+	outputTok int
+}
+
+// item imports one link, fetching it if asked, and returns its result and
+// the LLM tokens used.
+func (r *importRun) item(ctx context.Context, item importer.Item) (urlResult, int, int) {
+	if r.viaAdd {
+		slog.Info("processing URL", "url", item.URL)
+		res, err := addURL(ctx, r.addOptions, item.URL, item.Tags)
+		if err != nil {
+			slog.Error("failed to add URL", "url", item.URL, "error", err)
+			return urlResult{URL: item.URL, Status: "failed", Error: err.Error()}, res.InputTokens, res.OutputTokens
+		}
+		return res.output(item.URL), res.InputTokens, res.OutputTokens
+	}
+
+	if existing, err := r.db.Queries.GetLinkByURL(ctx, item.URL); err == nil {
+		slog.Info("URL already exists", "url", item.URL)
+		return urlResult{URL: item.URL, ID: existing.ID, Title: existing.Title.String, Status: "skipped"}, 0, 0
+	}
+
+	link, err := importItem(ctx, r.db, item)
+	if err != nil {
+		slog.Error("failed to import URL", "url", item.URL, "error", err)
+		return urlResult{URL: item.URL, Status: "failed", Error: err.Error()}, 0, 0
+	}
+	res := urlResult{URL: item.URL, ID: link.ID, Title: link.Title.String, Status: "imported"}
+	if r.fetcher == nil {
+		return res, 0, 0
+	}
+
+	slog.Info("processing URL", "url", item.URL)
+	inTok, outTok, err := refetchURL(ctx, r.db, r.fetcher, r.extractor, r.summarizer, item.URL)
+	if err != nil {
+		// The link is kept; it can be refetched later.
+		slog.Warn("failed to fetch imported URL", "url", item.URL, "error", err)
+	} else if item.Selection != "" {
+		keepSelection(ctx, r.db, link.ID, item.Selection)
+	}
+	return res, inTok, outTok
+}
+
+// keepSelection puts a highlighted selection back, as a quote above the page
+// text, once fetching the link has replaced its content with the page's.
+// The word count stays the page's own.
+func keepSelection(ctx context.Context, db *database.Database, linkID int64, selection string) {
+	link, err := db.Queries.GetLink(ctx, linkID)
+	if err != nil {
+		slog.Warn("failed to keep imported selection", "id", linkID, "error", err)
+		return
+	}
+	var quoted strings.Builder
+	for _, line := range strings.Split(selection, "\n") {
+		quoted.WriteString("> " + line + "\n")
+	}
+	content := quoted.String()
+	if link.Content.String != "" {
+		content += "\n" + link.Content.String
+	}
+	err = db.Queries.SetLinkContent(ctx, models.SetLinkContentParams{
+		Content:       sql.NullString{String: content, Valid: true},
+		ContentLength: link.ContentLength,
+		ID:            linkID,
+	})
+	if err != nil {
+		slog.Warn("failed to keep imported selection", "id", linkID, "error", err)
+	}
+}
+
+// finish adds a finished link's tokens to the totals and logs progress.
+func (r *importRun) finish(inputTok, outputTok int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.done++
+	r.inputTok += inputTok
+	r.outputTok += outputTok
+	slog.Info("import progress",
+		"done", r.done,
+		"total", r.total,
+		"cost_usd", fmt.Sprintf("$%.5f", services.LLMCost(r.inputTok, r.outputTok)),
+	)
+}
+
+// capReached reports whether the summaries so far have reached --max-cost.
+func (r *importRun) capReached() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return costCapReached(importMaxCost, r.inputTok, r.outputTok)
+}
+
+// importCheckpoint is the --checkpoint file: one URL per line for each link
+// an earlier run got through. A nil checkpoint records nothing.
+type importCheckpoint struct {
+	mu   sync.Mutex
+	f    *os.File
+	done map[string]bool
+}
+
+// openCheckpoint reads the URLs already in the checkpoint file at path,
+// creating it if needed, and opens it for appending. It returns nil if path
+// is empty.
+func openCheckpoint(path string) (*importCheckpoint, error) {
+	if path == "" {
+		return nil, nil
+	}
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open checkpoint: %w", err)
+	}
+	c := &importCheckpoint{f: f, done: make(map[string]bool)}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if url := strings.TrimSpace(scanner.Text()); url != "" {
+			c.done[url] = true
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to read checkpoint: %w", err)
+	}
+	return c, nil
+}
+
+// has reports whether an earlier run got through url.
+func (c *importCheckpoint) has(url string) bool {
+	return c != nil && c.done[url]
+}
+
+// record appends url to the checkpoint.
+func (c *importCheckpoint) record(url string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, err := fmt.Fprintln(c.f, url); err != nil {
+		slog.Warn("failed to update checkpoint", "error", err)
+	}
+}
+
+func (c *importCheckpoint) Close() error {
+	if c == nil {
+		return nil
+	}
+	return c.f.Close()
+}
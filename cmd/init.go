@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"mccwk.com/lm/internal/database"
+)
+
+var initCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Interactively create the lm config and database",
+	Long: `Set up lm for first use.
+
+Prompts for the database location and an optional OpenAI API key, writes
+them to ~/.config/lm/.env, and creates the database schema.`,
+	Args: cobra.NoArgs,
+	RunE: runInit,
+}
+
+func init() {
+	rootCmd.AddCommand(initCmd)
+}
+
+func runInit(cmd *cobra.Command, args []string) error {
+	dir, err := configDir()
+	if err != nil {
+		return fmt.Errorf("failed to get config directory: %w", err)
+	}
+	envPath := filepath.Join(dir, ".env")
+	in := bufio.NewReader(os.Stdin)
+
+	if _, err := os.Stat(envPath); err == nil {
+		if !promptYesNo(in, fmt.Sprintf("%s already exists. Overwrite?", envPath), false) {
+			fmt.Println("Leaving existing config unchanged.")
+			return nil
+		}
+	}
+
+	defaultDB := filepath.Join(dir, "lm.db")
+	dbPath := promptLine(in, "Database path", defaultDB)
+	if strings.HasPrefix(dbPath, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			dbPath = filepath.Join(home, dbPath[2:])
+		}
+	}
+	apiKey := promptLine(in, "OpenAI API key (optional, enables summaries)", "")
+
+	var env strings.Builder
+	env.WriteString("# Written by lm init\n")
+	if dbPath != defaultDB {
+		fmt.Fprintf(&env, "DB_PATH=%s\n", dbPath)
+	} else {
+		env.WriteString("# DB_PATH=\n")
+	}
+	if apiKey != "" {
+		fmt.Fprintf(&env, "OPENAI_API_KEY=%s\n", apiKey)
+	} else {
+		env.WriteString("# OPENAI_API_KEY=\n")
+	}
+	if err := os.WriteFile(envPath, []byte(env.String()), 0600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", envPath, err)
+	}
+	fmt.Printf("Wrote %s\n", envPath)
+
+	if err := os.MkdirAll(filepath.Dir(dbPath), 0700); err != nil {
+		return fmt.Errorf("failed to create database directory: %w", err)
+	}
+	db := database.New(dbPath)
+	db.Close()
+	fmt.Printf("Database ready at %s\n", dbPath)
+	fmt.Println("Run `lm` to start.")
+	return nil
+}
+
+// promptLine asks for a value, returning def when the answer is empty.
+func promptLine(in *bufio.Reader, label, def string) string {
+	if def != "" {
+		fmt.Printf("%s [%s]: ", label, def)
+	} else {
+		fmt.Printf("%s: ", label)
+	}
+	line, _ := in.ReadString('\n')
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return def
+	}
+	return line
+}
+
+// promptYesNo asks a yes/no question, returning def on an empty answer.
+func promptYesNo(in *bufio.Reader, question string, def bool) bool {
+	hint := "y/N"
+	if def {
+		hint = "Y/n"
+	}
+	fmt.Printf("%s [%s]: ", question, hint)
+	line, _ := in.ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(line)) {
+	case "y", "yes":
+		return true
+	case "n", "no":
+		return false
+	}
+	return def
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"errors"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// errInterrupted ends a run that stopped early at Ctrl+C, after its results
+// are printed, so it still exits with an error.
+var errInterrupted = errors.New("interrupted")
+
+// interrupt catches Ctrl+C (and SIGTERM) during a long run over many URLs.
+// The first signal only asks the run to stop: the URL in flight finishes, so
+// nothing is left half-saved, and the run reports what it did. A second one
+// exits at once as usual.
+type interrupt struct {
+	sigs        chan os.Signal
+	interrupted chan struct{}
+	done        chan struct{}
+}
+
+// watchInterrupt starts catching signals. Call stop once the run is over.
+func watchInterrupt() *interrupt {
+	i := &interrupt{
+		sigs:        make(chan os.Signal, 1),
+		interrupted: make(chan struct{}),
+		done:        make(chan struct{}),
+	}
+	signal.Notify(i.sigs, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		select {
+		case <-i.sigs:
+			signal.Stop(i.sigs)
+			slog.Warn("interrupted: stopping after the current URL (press Ctrl+C again to quit now)")
+			close(i.interrupted)
+		case <-i.done:
+		}
+	}()
+	return i
+}
+
+// requested reports whether the run has been asked to stop.
+func (i *interrupt) requested() bool {
+	select {
+	case <-i.interrupted:
+		return true
+	default:
+		return false
+	}
+}
+
+// stop restores the default signal handling.
+func (i *interrupt) stop() {
+	signal.Stop(i.sigs)
+	close(i.done)
+}
+
+// interruptSkipped logs that a run stopped at Ctrl+C and returns results
+// marking the URLs it did not get to as skipped.
+func interruptSkipped(processed int, remaining []string) []urlResult {
+	slog.Warn("interrupted, stopping", "processed", processed, "remaining", len(remaining))
+	results := make([]urlResult, 0, len(remaining))
+	for _, url := range remaining {
+		results = append(results, urlResult{URL: url, Status: "skipped", Error: "interrupted"})
+	}
+	return results
+}
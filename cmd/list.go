@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"mccwk.com/lm/internal/database"
+	"mccwk.com/lm/internal/models"
+	"mccwk.com/lm/internal/services"
+)
+
+var (
+	listDomain   string
+	listSource   string
+	listStatus   string
+	listLimit    int64
+	listMinWords int64
+	listMaxWords int64
+	listArchived bool
+	listFlagged  bool
+	listOpened   bool
+)
+
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List saved links",
+	Long: `List links stored in the database, newest first.
+
+  --domain <host>     Only list links from the given site (e.g. github.com).
+  --source <source>   Only list links that came from the given source: cli
+                      ('lm add'), manual (the TUI), or an import format
+                      (pocket, urls, lm).
+  --status <status>   Only list links with the given status
+                      (read_later, remember, archived).
+  --min-words <n>     Only list links with at least n words of content.
+  --max-words <n>     Only list links with at most n words of content.
+  --include-archived  Include archived links, which are skipped unless
+                      --status archived is given.
+  --needs-attention   Only list links flagged as needing attention (! in
+                      the TUI).
+  --opened            List the links opened most recently ('lm open' or the
+                      TUI), last opened first, archived ones included.
+  --limit <n>         Maximum number of links to list (default 50).`,
+	Args: cobra.NoArgs,
+	RunE: runList,
+}
+
+func init() {
+	listCmd.Flags().StringVar(&listDomain, "domain", "", "Filter by site domain, e.g. github.com")
+	listCmd.Flags().StringVar(&listSource, "source", "", "Filter by where links came from, e.g. cli, manual, or pocket")
+	listCmd.Flags().StringVar(&listStatus, "status", "", "Filter by status: read_later, remember, or archived")
+	listCmd.Flags().Int64Var(&listMinWords, "min-words", 0, "Only list links with at least this many words")
+	listCmd.Flags().Int64Var(&listMaxWords, "max-words", 0, "Only list links with at most this many words")
+	listCmd.Flags().BoolVar(&listArchived, "include-archived", false, "Include archived links")
+	listCmd.Flags().BoolVar(&listFlagged, "needs-attention", false, "Only list links flagged as needing attention")
+	listCmd.Flags().BoolVar(&listOpened, "opened", false, "List recently opened links, last opened first")
+	listCmd.Flags().Int64VarP(&listLimit, "limit", "n", 50, "Maximum number of links to list")
+	rootCmd.AddCommand(listCmd)
+}
+
+func runList(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	switch listStatus {
+	case "", "read_later", "remember", "archived":
+	default:
+		return fmt.Errorf("invalid --status %q: must be read_later, remember, or archived", listStatus)
+	}
+
+	// Load env / config
+	if dir, err := configDir(); err == nil {
+		_ = loadEnvFile(dir)
+	}
+
+	db := database.New(dbPathFromEnv())
+	defer db.Close()
+
+	var links []models.Link
+	var err error
+	if listOpened {
+		links, err = db.Queries.ListRecentlyOpenedLinks(ctx, listLimit)
+	} else {
+		// Every filter is applied in the query, ahead of the limit.
+		links, err = db.Queries.ListLinksFiltered(ctx, models.ListLinksFilteredParams{
+			Domain:          normalizeDomain(listDomain),
+			Source:          listSource,
+			Status:          listStatus,
+			IncludeArchived: listArchived,
+			MinWords:        listMinWords,
+			MaxWords:        listMaxWords,
+			NeedsAttention:  listFlagged,
+			Limit:           listLimit,
+			Offset:          0,
+		})
+	}
+	if err != nil {
+		return fmt.Errorf("list failed: %w", err)
+	}
+	if listOpened {
+		links = filterOpened(links)
+	}
+
+	return emit(toLinkOutputs(links), func() {
+		if len(links) == 0 {
+			fmt.Println("No links found.")
+			return
+		}
+
+		for _, l := range links {
+			title := l.Title.String
+			if title == "" {
+				title = l.Url
+			}
+			if l.NeedsAttention {
+				title = "⚑ " + title
+			}
+			switch services.ExpiryState(l.ExpiresAt, time.Now()) {
+			case services.ExpiryExpired:
+				title += " (expired)"
+			case services.ExpiryExpiring:
+				title += " (expires " + l.ExpiresAt.Time.Local().Format("2006-01-02") + ")"
+			}
+			fmt.Printf("%d. %s\n", l.ID, title)
+			if l.ContentLength > 0 {
+				fmt.Printf("   %s (%d words)\n", l.Url, l.ContentLength)
+			} else {
+				fmt.Printf("   %s\n", l.Url)
+			}
+		}
+	})
+}
+
+// filterOpened applies the list filters to the recently opened links, which
+// come from the open history rather than a filtered query. Archived links
+// are kept.
+func filterOpened(links []models.Link) []models.Link {
+	domain := normalizeDomain(listDomain)
+	filtered := links[:0]
+	for _, l := range links {
+		switch {
+		case listDomain != "" && l.Domain != domain,
+			listSource != "" && !sourceMatches(l.Source, listSource),
+			listStatus != "" && l.Status != listStatus,
+			l.ContentLength < listMinWords,
+			listMaxWords > 0 && l.ContentLength > listMaxWords,
+			listFlagged && !l.NeedsAttention:
+			continue
+		}
+		filtered = append(filtered, l)
+	}
+	return filtered
+}
+
+// sourceMatches reports whether a link's source is want, or one of its
+// sub-sources, e.g. "rss:golang-blog" for "rss".
+func sourceMatches(source, want string) bool {
+	return source == want || strings.HasPrefix(source, want+":")
+}
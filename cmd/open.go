@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"mccwk.com/lm/internal/database"
+	"mccwk.com/lm/internal/models"
+	"mccwk.com/lm/internal/services"
+)
+
+var (
+	openRandom  bool
+	openUnread  bool
+	openBrowser string
+)
+
+var openCmd = &cobra.Command{
+	Use:   "open [url|id]",
+	Short: "Open a saved link in the browser",
+	Long: `Open a link stored in the database in the browser.
+
+The link may be given by its URL or its numeric ID.
+
+  --random            Open a random link instead of a named one.
+  --unread            With --random, only pick from read-later links.
+  --browser <cmd>     Browser command to use; overrides the BROWSER
+                      environment variable. Defaults to the system opener.
+
+A browser command may include quoted arguments, as in a shell; the URL
+replaces any %s or is added last. Several commands separated by ":" are
+tried in turn until one starts.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runOpen,
+}
+
+func init() {
+	openCmd.Flags().BoolVar(&openRandom, "random", false, "Open a random link")
+	openCmd.Flags().BoolVar(&openUnread, "unread", false, "With --random, only pick from read-later links")
+	openCmd.Flags().StringVar(&openBrowser, "browser", "", "Browser executable to open the link with (overrides $BROWSER)")
+	rootCmd.AddCommand(openCmd)
+}
+
+func runOpen(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	if !openRandom && len(args) == 0 {
+		return fmt.Errorf("no link provided: pass a URL or ID, or use --random")
+	}
+	if openRandom && len(args) > 0 {
+		return fmt.Errorf("--random cannot be combined with a URL or ID")
+	}
+
+	// Load env / config
+	if dir, err := configDir(); err == nil {
+		_ = loadEnvFile(dir)
+	}
+
+	db := database.New(dbPathFromEnv())
+	defer db.Close()
+
+	var link models.Link
+	var err error
+	switch {
+	case openRandom && openUnread:
+		link, err = db.Queries.GetRandomLinkByStatus(ctx, "read_later")
+	case openRandom:
+		link, err = db.Queries.GetRandomLink(ctx)
+	default:
+		link, err = lookupLink(ctx, db, args[0])
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		printf("No matching link found.\n")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("lookup failed: %w", err)
+	}
+
+	title := link.Title.String
+	if title == "" {
+		title = link.Url
+	}
+	printf("Opening: %s\n   %s\n", title, link.Url)
+
+	browserCmd := openBrowser
+	if browserCmd == "" {
+		browserCmd = browserFromEnv()
+	}
+	if err := services.OpenURL(browserCmd, link.Url); err != nil {
+		return fmt.Errorf("failed to open browser: %w", err)
+	}
+	db.RecordOpen(ctx, link.ID)
+	return emit(toLinkOutput(link), nil)
+}
+
+// lookupLink finds a link by numeric ID or, failing that, by exact URL.
+func lookupLink(ctx context.Context, db *database.Database, ref string) (models.Link, error) {
+	if id, err := strconv.ParseInt(ref, 10, 64); err == nil {
+		return db.Queries.GetLink(ctx, id)
+	}
+	return db.Queries.GetLinkByURL(ctx, ref)
+}
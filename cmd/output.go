@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"mccwk.com/lm/internal/models"
+)
+
+// Global output modes. Command results go through printf and emit so every
+// command honours them the same way; progress chatter goes through slog,
+// which setupLogging sends to stderr with --json and limits to warnings with
+// --quiet.
+var (
+	jsonOutput  bool
+	quietOutput bool
+)
+
+// printf writes human-readable output. It is suppressed with --json.
+func printf(format string, args ...any) {
+	if !jsonOutput {
+		fmt.Printf(format, args...)
+	}
+}
+
+// emit writes a command's result: as indented JSON with --json, otherwise by
+// calling human.
+func emit(v any, human func()) error {
+	if !jsonOutput {
+		if human != nil {
+			human()
+		}
+		return nil
+	}
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return fmt.Errorf("failed to write JSON: %w", err)
+	}
+	return nil
+}
+
+// urlResult is the JSON form of one URL processed by add, refetch, or import.
+type urlResult struct {
+	URL     string `json:"url"`
+	ID      int64  `json:"id,omitempty"`
+	Title   string `json:"title,omitempty"`
+	Status  string `json:"status"` // added, exists, updated, imported, skipped, or failed
+	Error   string `json:"error,omitempty"`
+	Warning string `json:"warning,omitempty"` // e.g. the extracted content is suspiciously short
+}
+
+// linkOutput is the JSON form of a link in command results.
+type linkOutput struct {
+	ID             int64      `json:"id"`
+	URL            string     `json:"url"`
+	Title          string     `json:"title"`
+	Summary        string     `json:"summary,omitempty"`
+	SummaryShort   string     `json:"summary_short,omitempty"`
+	Status         string     `json:"status"`
+	Domain         string     `json:"domain"`
+	Source         string     `json:"source,omitempty"`
+	ContentLength  int64      `json:"content_length"`
+	NeedsAttention bool       `json:"needs_attention,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	DeletedAt      *time.Time `json:"deleted_at,omitempty"`
+	ExpiresAt      *time.Time `json:"expires_at,omitempty"`
+}
+
+func toLinkOutput(l models.Link) linkOutput {
+	out := linkOutput{
+		ID:             l.ID,
+		URL:            l.Url,
+		Title:          l.Title.String,
+		Summary:        l.Summary.String,
+		SummaryShort:   l.SummaryShort.String,
+		Status:         l.Status,
+		Domain:         l.Domain,
+		Source:         l.Source,
+		ContentLength:  l.ContentLength,
+		NeedsAttention: l.NeedsAttention,
+		CreatedAt:      l.CreatedAt,
+	}
+	if l.DeletedAt.Valid {
+		out.DeletedAt = &l.DeletedAt.Time
+	}
+	if l.ExpiresAt.Valid {
+		out.ExpiresAt = &l.ExpiresAt.Time
+	}
+	return out
+}
+
+func toLinkOutputs(links []models.Link) []linkOutput {
+	out := make([]linkOutput, 0, len(links))
+	for _, l := range links {
+		out = append(out, toLinkOutput(l))
+	}
+	return out
+}
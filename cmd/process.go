@@ -0,0 +1,194 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"mccwk.com/lm/internal/database"
+	"mccwk.com/lm/internal/models"
+	"mccwk.com/lm/internal/services"
+)
+
+var (
+	processMaxCost float64
+	processRender  bool
+)
+
+var processCmd = &cobra.Command{
+	Use:   "process",
+	Short: "Fetch and summarise links saved with 'lm add --capture'",
+	Long: `Finish the links saved with 'lm add --capture': fetch and extract each
+page, summarise it (if an API key is configured), set its status from its
+length as AUTO_STATUS says, and pick a category and tags the way 'lm add'
+would, where none were given at capture. Oldest captures go first.
+
+Run it whenever convenient, or from cron for a background pass. Links that
+fail stay captured and are tried again next time; refetching a captured
+link in any other way also finishes it.
+
+  --max-cost <usd>    Stop once AI summaries have cost this much.
+  --render            Fetch pages through the RENDER_URL headless-browser
+                      service, as for 'lm add'.`,
+	Args: cobra.NoArgs,
+	RunE: runProcess,
+}
+
+func init() {
+	processCmd.Flags().Float64Var(&processMaxCost, "max-cost", 0, "Stop once AI summaries have cost this many US dollars (0 for no limit)")
+	processCmd.Flags().BoolVar(&processRender, "render", false, "Fetch pages through the RENDER_URL headless-browser service")
+	rootCmd.AddCommand(processCmd)
+}
+
+func runProcess(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	if err := validateMaxCost(processMaxCost); err != nil {
+		return err
+	}
+
+	if dir, err := configDir(); err == nil {
+		_ = loadEnvFile(dir)
+	}
+
+	db := database.New(dbPathFromEnv())
+	defer db.Close()
+
+	links, err := db.Queries.ListCapturedLinks(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list captured links: %w", err)
+	}
+	if len(links) == 0 {
+		return emit([]urlResult{}, func() { printf("No captured links to process\n") })
+	}
+
+	fetcher, err := renderingFetcher(processRender)
+	if err != nil {
+		return err
+	}
+	extractor := extractorFromEnv()
+	var summarizer *services.Summarizer
+	if apiKey := apiKeyFromEnv(); apiKey != "" {
+		summarizer = checkedSummarizer(ctx, apiKey)
+	}
+	statusRule := statusRuleFromEnv()
+	categoryRules := categoryRulesFromEnv()
+	defaults := linkDefaultsFromEnv()
+
+	var grandInputTok, grandOutputTok int
+	var processed, skipped int
+	results := make([]urlResult, 0, len(links))
+
+	intr := watchInterrupt()
+	defer intr.stop()
+	for i, link := range links {
+		if intr.requested() || costCapReached(processMaxCost, grandInputTok, grandOutputTok) {
+			remaining := make([]string, 0, len(links)-i)
+			for _, l := range links[i:] {
+				remaining = append(remaining, l.Url)
+			}
+			if intr.requested() {
+				results = append(results, interruptSkipped(processed, remaining)...)
+			} else {
+				results = append(results, costCapSkipped(processMaxCost, processed, remaining)...)
+			}
+			skipped += len(remaining)
+			break
+		}
+		slog.Info("processing URL", "index", i+1, "total", len(links), "url", link.Url)
+		inTok, outTok, err := processCapture(ctx, db, fetcher, extractor, summarizer, statusRule, categoryRules, defaults, link)
+		grandInputTok += inTok
+		grandOutputTok += outTok
+		if err != nil {
+			slog.Error("failed to process URL", "url", link.Url, "error", err)
+			results = append(results, urlResult{URL: link.Url, ID: link.ID, Status: "failed", Error: err.Error()})
+			skipped++
+			continue
+		}
+		res := urlResult{URL: link.Url, ID: link.ID, Status: "updated"}
+		if updated, err := db.Queries.GetLink(ctx, link.ID); err == nil {
+			res.Title = updated.Title.String
+		}
+		results = append(results, res)
+		processed++
+	}
+
+	slog.Info("batch complete", "processed", processed, "skipped", skipped)
+	if grandInputTok+grandOutputTok > 0 {
+		cost := services.LLMCost(grandInputTok, grandOutputTok)
+		slog.Info("LLM usage total",
+			"input_tokens", grandInputTok,
+			"output_tokens", grandOutputTok,
+			"cost_usd", fmt.Sprintf("$%.5f", cost),
+		)
+	}
+
+	if err := emit(results, nil); err != nil {
+		return err
+	}
+	if intr.requested() {
+		return errInterrupted
+	}
+	return nil
+}
+
+// processCapture refetches a captured link, which also clears its capture,
+// then gives it the status, category, and tags 'lm add' would have, down to
+// the defaults. A status changed since capture is kept, as are any
+// categories or tags it has.
+func processCapture(ctx context.Context, db *database.Database, fetcher *services.Fetcher, extractor *services.Extractor, summarizer *services.Summarizer, statusRule services.StatusRule, categoryRules services.CategoryRules, defaults services.LinkDefaults, link models.Link) (inputTok, outputTok int, err error) {
+	inputTok, outputTok, err = refetchURL(ctx, db, fetcher, extractor, summarizer, link.Url)
+	if err != nil {
+		return inputTok, outputTok, err
+	}
+	link, err = db.Queries.GetLink(ctx, link.ID)
+	if err != nil {
+		return inputTok, outputTok, fmt.Errorf("failed to reload link: %w", err)
+	}
+	title := link.Title.String
+
+	if link.Status == services.DefaultStatus {
+		if status := statusRule.Status(link.ContentLength); status != link.Status {
+			_ = db.Queries.UpdateLinkStatus(ctx, models.UpdateLinkStatusParams{ID: link.ID, Status: status})
+		}
+	}
+
+	categories, err := db.Queries.GetCategoriesForLink(ctx, link.ID)
+	if err != nil {
+		return inputTok, outputTok, fmt.Errorf("failed to load categories: %w", err)
+	}
+	tags, err := db.Queries.GetTagsForLink(ctx, link.ID)
+	if err != nil {
+		return inputTok, outputTok, fmt.Errorf("failed to load tags: %w", err)
+	}
+
+	var catName string
+	if len(categories) == 0 {
+		catName = categoryRules.Category(link.Url, title)
+	}
+	var suggestedTags []string
+	if summarizer != nil && ((len(categories) == 0 && catName == "") || len(tags) == 0) {
+		var suggestedCat string
+		var inTok, outTok int
+		suggestedCat, suggestedTags, inTok, outTok, _ = summarizer.SuggestMetadata(ctx, title, link.Content.String)
+		db.RecordLLMUsage(ctx, services.LLMModel, database.OpSuggestMetadata, inTok, outTok, services.LLMCost(inTok, outTok))
+		inputTok += inTok
+		outputTok += outTok
+		if len(categories) == 0 && catName == "" {
+			catName = strings.TrimSpace(suggestedCat)
+		}
+	}
+	if len(categories) == 0 {
+		catName = defaults.CategoryOr(catName)
+	}
+	if catName != "" {
+		assignCategory(ctx, db, link.ID, catName)
+	}
+	if len(tags) == 0 {
+		assignTags(ctx, db, link.ID, defaults.TagsOr(suggestedTags))
+	}
+	return inputTok, outputTok, nil
+}
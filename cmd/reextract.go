@@ -0,0 +1,201 @@
+package cmd
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/spf13/cobra"
+
+	"mccwk.com/lm/internal/database"
+	"mccwk.com/lm/internal/models"
+	"mccwk.com/lm/internal/services"
+)
+
+var (
+	reextractAll       bool
+	reextractNoSummary bool
+	reextractMaxCost   float64
+)
+
+var reextractCmd = &cobra.Command{
+	Use:   "reextract [url...]",
+	Short: "Re-extract links from their archived HTML without fetching",
+	Long: `Re-run extraction over the HTML archived when links were last fetched, so
+extractor improvements (or a new LINK_URLS style) reach links already saved
+without fetching anything again. Titles, content, and (if an API key is
+configured) summaries are updated; tags, categories, and status are kept.
+
+HTML is only archived while ARCHIVE_HTML=true is set. Links fetched without
+it have nothing to re-extract; use 'lm refetch' for those.
+
+  --all               Re-extract every link with archived HTML.
+  --no-summary        Keep the existing summaries and make no API calls.
+  --max-cost <usd>    Stop once AI summaries have cost this much.`,
+	Args: cobra.ArbitraryArgs,
+	RunE: runReextract,
+}
+
+func init() {
+	reextractCmd.Flags().BoolVar(&reextractAll, "all", false, "Re-extract every link with archived HTML")
+	reextractCmd.Flags().BoolVar(&reextractNoSummary, "no-summary", false, "Keep existing summaries instead of regenerating them")
+	reextractCmd.Flags().Float64Var(&reextractMaxCost, "max-cost", 0, "Stop once AI summaries have cost this many US dollars (0 for no limit)")
+	rootCmd.AddCommand(reextractCmd)
+}
+
+func runReextract(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	if reextractAll == (len(args) > 0) {
+		return fmt.Errorf("pass URLs to re-extract, or --all")
+	}
+	if err := validateMaxCost(reextractMaxCost); err != nil {
+		return err
+	}
+
+	if dir, err := configDir(); err == nil {
+		_ = loadEnvFile(dir)
+	}
+
+	db := database.New(dbPathFromEnv())
+	defer db.Close()
+
+	// Collect the links, noting URLs that are not saved at all.
+	var links []models.Link
+	var results []urlResult
+	if reextractAll {
+		var err error
+		links, err = db.Queries.ListArchivedLinks(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list archived links: %w", err)
+		}
+	} else {
+		for _, url := range args {
+			link, err := db.Queries.GetLinkByURL(ctx, url)
+			if err != nil {
+				results = append(results, urlResult{URL: url, Status: "failed", Error: "URL not found in database"})
+				continue
+			}
+			links = append(links, link)
+		}
+	}
+
+	extractor := extractorFromEnv()
+	var summarizer *services.Summarizer
+	if apiKey := apiKeyFromEnv(); apiKey != "" && !reextractNoSummary {
+		summarizer = checkedSummarizer(ctx, apiKey)
+	}
+
+	var grandInputTok, grandOutputTok int
+	var processed, skipped int
+	intr := watchInterrupt()
+	defer intr.stop()
+	for i, link := range links {
+		if intr.requested() || costCapReached(reextractMaxCost, grandInputTok, grandOutputTok) {
+			remaining := make([]string, 0, len(links)-i)
+			for _, l := range links[i:] {
+				remaining = append(remaining, l.Url)
+			}
+			if intr.requested() {
+				results = append(results, interruptSkipped(processed, remaining)...)
+			} else {
+				results = append(results, costCapSkipped(reextractMaxCost, processed, remaining)...)
+			}
+			skipped += len(remaining)
+			break
+		}
+
+		slog.Info("re-extracting", "index", i+1, "total", len(links), "url", link.Url)
+		inTok, outTok, err := reextractLink(ctx, db, extractor, summarizer, link)
+		grandInputTok += inTok
+		grandOutputTok += outTok
+		if err != nil {
+			slog.Error("failed to re-extract URL", "url", link.Url, "error", err)
+			results = append(results, urlResult{URL: link.Url, ID: link.ID, Status: "failed", Error: err.Error()})
+			skipped++
+			continue
+		}
+		results = append(results, urlResult{URL: link.Url, ID: link.ID, Title: link.Title.String, Status: "updated"})
+		processed++
+	}
+
+	slog.Info("re-extract complete", "processed", processed, "skipped", skipped)
+
+	if grandInputTok+grandOutputTok > 0 {
+		cost := services.LLMCost(grandInputTok, grandOutputTok)
+		slog.Info("LLM usage total",
+			"input_tokens", grandInputTok,
+			"output_tokens", grandOutputTok,
+			"cost_usd", fmt.Sprintf("$%.5f", cost),
+		)
+	}
+
+	if results == nil {
+		results = []urlResult{}
+	}
+	if err := emit(results, nil); err != nil {
+		return err
+	}
+	if intr.requested() {
+		return errInterrupted
+	}
+	return nil
+}
+
+// reextractLink extracts link again from its archived HTML and saves the new
+// title, content, and, with a summarizer, summary. A failed summary keeps
+// the existing one.
+func reextractLink(ctx context.Context, db *database.Database, extractor *services.Extractor, summarizer *services.Summarizer, link models.Link) (inputTok, outputTok int, err error) {
+	html, err := db.Queries.GetLinkArchive(ctx, link.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, 0, fmt.Errorf("no archived HTML (use 'lm refetch' instead)")
+	}
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to load archived HTML: %w", err)
+	}
+
+	title, text, _, err := extractor.ExtractText(html, link.Url)
+	if err != nil {
+		return 0, 0, fmt.Errorf("extraction failed: %w", err)
+	}
+	if title == "" || link.CustomTitle {
+		title = link.Title.String
+	}
+	content := extractor.TruncateText(text, 10000)
+
+	summary, summarized := link.Summary.String, false
+	if summarizer != nil {
+		s, inTok, outTok, err := summarizer.Summarize(ctx, title, text)
+		db.RecordLLMUsage(ctx, services.LLMModel, database.OpSummarize, inTok, outTok, services.LLMCost(inTok, outTok))
+		inputTok, outputTok = inTok, outTok
+		if err != nil {
+			slog.Warn("summary failed, keeping the existing one", "url", link.Url, "error", err)
+		} else if s != "" {
+			summary = s
+			summarized = true
+			_ = db.Queries.UpdateLinkSummarizedAt(ctx, link.ID)
+		}
+	}
+
+	_, err = db.Queries.UpdateLink(ctx, models.UpdateLinkParams{
+		ID:            link.ID,
+		Title:         sql.NullString{String: title, Valid: title != ""},
+		Content:       sql.NullString{String: content, Valid: content != ""},
+		Summary:       sql.NullString{String: summary, Valid: summary != ""},
+		Status:        link.Status,
+		ContentLength: services.WordCount(text),
+	})
+	if err != nil {
+		return inputTok, outputTok, fmt.Errorf("failed to update link: %w", err)
+	}
+	db.SaveImages(ctx, link.ID, storedImages(extractor.ExtractImages(html, link.Url)))
+	_ = db.Queries.SetLinkNeedsAttention(ctx, models.SetLinkNeedsAttentionParams{ID: link.ID})
+	if summarized {
+		inTok, outTok := refreshShortSummary(ctx, db, summarizer, link.ID, title, summary)
+		inputTok += inTok
+		outputTok += outTok
+	}
+	return inputTok, outputTok, nil
+}
@@ -6,6 +6,7 @@ import (
 	"database/sql"
 	"fmt"
 	"log/slog"
+	"math"
 	"os"
 	"strings"
 
@@ -16,6 +17,12 @@ import (
 	"mccwk.com/lm/internal/services"
 )
 
+var (
+	refetchEstimate bool
+	refetchMaxCost  float64
+	refetchRender   bool
+)
+
 var refetchCmd = &cobra.Command{
 	Use:   "refetch [url...]",
 	Short: "Re-fetch, re-extract, and re-summarise existing links",
@@ -23,20 +30,34 @@ var refetchCmd = &cobra.Command{
 
 Fetches fresh HTML, converts it to Markdown, and (if an API key is
 configured) generates a new AI summary. The link's title, content, and
-summary are updated in-place; tags, categories, and status are preserved.
+summary are updated in-place; tags, categories, status, and titles edited
+in the TUI are preserved.
+
+URLs may be provided as arguments or piped via stdin (one per line).
 
-URLs may be provided as arguments or piped via stdin (one per line).`,
+With --estimate, nothing is fetched: the projected AI summary cost is
+worked out from the content already saved for each link. --max-cost <usd>
+stops the run once AI summaries have cost that much; it is checked between
+URLs, so the last one may take the total slightly over. --render fetches
+pages through the RENDER_URL headless-browser service, as for 'lm add'.`,
 	Args: cobra.ArbitraryArgs,
 	RunE: runRefetch,
 }
 
 func init() {
+	refetchCmd.Flags().BoolVar(&refetchEstimate, "estimate", false, "Estimate the AI summary cost and exit without refetching anything")
+	refetchCmd.Flags().Float64Var(&refetchMaxCost, "max-cost", 0, "Stop once AI summaries have cost this many US dollars (0 for no limit)")
+	refetchCmd.Flags().BoolVar(&refetchRender, "render", false, "Fetch pages through the RENDER_URL headless-browser service")
 	rootCmd.AddCommand(refetchCmd)
 }
 
 func runRefetch(cmd *cobra.Command, args []string) error {
 	ctx := context.Background()
 
+	if err := validateMaxCost(refetchMaxCost); err != nil {
+		return err
+	}
+
 	if dir, err := configDir(); err == nil {
 		_ = loadEnvFile(dir)
 	}
@@ -44,14 +65,6 @@ func runRefetch(cmd *cobra.Command, args []string) error {
 	db := database.New(dbPathFromEnv())
 	defer db.Close()
 
-	apiKey := apiKeyFromEnv()
-	fetcher := services.NewFetcher()
-	extractor := services.NewExtractor()
-	var summarizer *services.Summarizer
-	if apiKey != "" {
-		summarizer = services.NewSummarizer(apiKey)
-	}
-
 	// Collect URLs from args and stdin.
 	urls := append([]string(nil), args...)
 	stat, _ := os.Stdin.Stat()
@@ -69,11 +82,57 @@ func runRefetch(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("no URLs provided: pass as arguments or pipe via stdin")
 	}
 
+	apiKey := apiKeyFromEnv()
+
+	if refetchEstimate {
+		var est costEstimate
+		for _, url := range urls {
+			link, err := db.Queries.GetLinkByURL(ctx, url)
+			if err != nil {
+				est.Skipped++
+				continue
+			}
+			est.Links++
+			textLen := len(link.Content.String)
+			if textLen == 0 {
+				// Never fetched, e.g. imported without --fetch.
+				est.UpperBound = true
+				textLen = math.MaxInt
+			}
+			est.ContentChars += len(link.Content.String)
+			est.addSummary(link.Title.String, textLen)
+		}
+		return est.emit(apiKey)
+	}
+
+	fetcher, err := renderingFetcher(refetchRender)
+	if err != nil {
+		return err
+	}
+	extractor := extractorFromEnv()
+	var summarizer *services.Summarizer
+	if apiKey != "" {
+		summarizer = checkedSummarizer(ctx, apiKey)
+	}
+
 	var grandInputTok, grandOutputTok int
 	var processed, skipped int
 	multi := len(urls) > 1
+	results := make([]urlResult, 0, len(urls))
 
+	intr := watchInterrupt()
+	defer intr.stop()
 	for i, url := range urls {
+		if intr.requested() {
+			results = append(results, interruptSkipped(processed, urls[i:])...)
+			skipped += len(urls) - i
+			break
+		}
+		if costCapReached(refetchMaxCost, grandInputTok, grandOutputTok) {
+			results = append(results, costCapSkipped(refetchMaxCost, processed, urls[i:])...)
+			skipped += len(urls) - i
+			break
+		}
 		if multi {
 			slog.Info("processing URL", "index", i+1, "total", len(urls), "url", url)
 		}
@@ -82,19 +141,24 @@ func runRefetch(cmd *cobra.Command, args []string) error {
 		grandOutputTok += outTok
 		if err != nil {
 			slog.Error("failed to refetch URL", "url", url, "error", err)
+			results = append(results, urlResult{URL: url, Status: "failed", Error: err.Error()})
 			skipped++
 			continue
 		}
+		res := urlResult{URL: url, Status: "updated"}
+		if link, err := db.Queries.GetLinkByURL(ctx, url); err == nil {
+			res.ID, res.Title = link.ID, link.Title.String
+		}
+		results = append(results, res)
 		processed++
 	}
 
-	if multi {
+	if multi || intr.requested() {
 		slog.Info("batch complete", "processed", processed, "skipped", skipped)
 	}
 
 	if grandInputTok+grandOutputTok > 0 {
-		cost := float64(grandInputTok)*0.15/1_000_000.0 +
-			float64(grandOutputTok)*0.60/1_000_000.0
+		cost := services.LLMCost(grandInputTok, grandOutputTok)
 		slog.Info("LLM usage total",
 			"input_tokens", grandInputTok,
 			"output_tokens", grandOutputTok,
@@ -102,6 +166,12 @@ func runRefetch(cmd *cobra.Command, args []string) error {
 		)
 	}
 
+	if err := emit(results, nil); err != nil {
+		return err
+	}
+	if intr.requested() {
+		return errInterrupted
+	}
 	return nil
 }
 
@@ -112,16 +182,18 @@ func refetchURL(ctx context.Context, db *database.Database, fetcher *services.Fe
 	}
 
 	slog.Info("fetching URL", "url", url)
-	html, err := fetcher.FetchURL(ctx, url)
+	page, err := services.FetchPage(ctx, fetcher, extractor, url)
 	if err != nil {
-		return 0, 0, fmt.Errorf("fetch failed: %w", err)
+		return 0, 0, err
 	}
 	_ = db.Queries.UpdateLinkFetchedAt(ctx, existing.ID)
-
-	slog.Info("extracting content")
-	title, text, err := extractor.ExtractText(html, url)
-	if err != nil {
-		return 0, 0, fmt.Errorf("extraction failed: %w", err)
+	title, text := page.Title, page.Text
+	if page.Thin {
+		slog.Warn(thinExtractionWarning(fetcher), "url", url, "chars", len(text))
+	}
+	// A title edited by hand wins over the page's own.
+	if title == "" || existing.CustomTitle {
+		title = existing.Title.String
 	}
 	content := extractor.TruncateText(text, 10000)
 
@@ -130,12 +202,12 @@ func refetchURL(ctx context.Context, db *database.Database, fetcher *services.Fe
 		slog.Info("summarising", "url", url)
 		var inTok, outTok int
 		summary, inTok, outTok, _ = summarizer.Summarize(ctx, title, text)
+		db.RecordLLMUsage(ctx, services.LLMModel, database.OpSummarize, inTok, outTok, services.LLMCost(inTok, outTok))
 		inputTok += inTok
 		outputTok += outTok
 
 		if inputTok+outputTok > 0 {
-			cost := float64(inputTok)*0.15/1_000_000.0 +
-				float64(outputTok)*0.60/1_000_000.0
+			cost := services.LLMCost(inputTok, outputTok)
 			slog.Info("LLM usage",
 				"url", url,
 				"input_tokens", inputTok,
@@ -147,15 +219,25 @@ func refetchURL(ctx context.Context, db *database.Database, fetcher *services.Fe
 	}
 
 	_, err = db.Queries.UpdateLink(ctx, models.UpdateLinkParams{
-		ID:      existing.ID,
-		Title:   sql.NullString{String: title, Valid: title != ""},
-		Content: sql.NullString{String: content, Valid: content != ""},
-		Summary: sql.NullString{String: summary, Valid: summary != ""},
-		Status:  existing.Status,
+		ID:            existing.ID,
+		Title:         sql.NullString{String: title, Valid: title != ""},
+		Content:       sql.NullString{String: content, Valid: content != ""},
+		Summary:       sql.NullString{String: summary, Valid: summary != ""},
+		Status:        existing.Status,
+		ContentLength: services.WordCount(text),
 	})
 	if err != nil {
 		return inputTok, outputTok, fmt.Errorf("failed to update link: %w", err)
 	}
+	db.ArchiveHTML(ctx, existing.ID, page.HTML)
+	db.SaveImages(ctx, existing.ID, storedImages(page.Images))
+	inTok, outTok := refreshShortSummary(ctx, db, summarizer, existing.ID, title, summary)
+	inputTok += inTok
+	outputTok += outTok
+	// A fresh fetch is how a link flagged as needing attention gets fixed,
+	// and leaves nothing for 'lm process' to do.
+	_ = db.Queries.SetLinkNeedsAttention(ctx, models.SetLinkNeedsAttentionParams{ID: existing.ID})
+	_ = db.Queries.DeleteLinkCapture(ctx, existing.ID)
 
 	slog.Info("link updated", "id", existing.ID, "title", title)
 	if summary != "" {
@@ -164,3 +246,38 @@ func refetchURL(ctx context.Context, db *database.Database, fetcher *services.Fe
 
 	return inputTok, outputTok, nil
 }
+
+// refreshShortSummary follows linkID's new summary with a new short summary,
+// or clears the old one, as summarizer.RefreshShortSummary decides, and
+// records the call's usage. Failures are logged rather than returned, since
+// the summary itself was saved. It returns the tokens used.
+func refreshShortSummary(ctx context.Context, db *database.Database, summarizer *services.Summarizer, linkID int64, title, summary string) (inputTok, outputTok int) {
+	short, inputTok, outputTok, err := summarizer.RefreshShortSummary(ctx, title, summary)
+	db.RecordLLMUsage(ctx, services.LLMModel, database.OpShortSummary, inputTok, outputTok, services.LLMCost(inputTok, outputTok))
+	if err != nil {
+		slog.Warn("short summary failed", "link_id", linkID, "error", err)
+		return inputTok, outputTok
+	}
+	err = db.Queries.SetLinkSummaryShort(ctx, models.SetLinkSummaryShortParams{
+		SummaryShort: sql.NullString{String: short, Valid: short != ""},
+		ID:           linkID,
+	})
+	if err != nil {
+		slog.Warn("failed to save short summary", "link_id", linkID, "error", err)
+	}
+	return inputTok, outputTok
+}
+
+// storedImages converts images extracted from a page for db.SaveImages,
+// keeping nil as nil so extractors that do not keep images leave the stored
+// ones alone.
+func storedImages(images []services.Image) []database.Image {
+	if images == nil {
+		return nil
+	}
+	stored := make([]database.Image, len(images))
+	for i, img := range images {
+		stored[i] = database.Image(img)
+	}
+	return stored
+}
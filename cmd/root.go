@@ -1,10 +1,14 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/joho/godotenv"
@@ -13,6 +17,7 @@ import (
 
 	"mccwk.com/lm/internal/database"
 	"mccwk.com/lm/internal/logging"
+	"mccwk.com/lm/internal/services"
 	"mccwk.com/lm/internal/tui"
 )
 
@@ -25,6 +30,10 @@ var (
 var rootCmd = &cobra.Command{
 	Use:   "lm",
 	Short: "Link manager",
+	// Flags are only parsed by now, so apply --debug, --json, and --quiet.
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		setupLogging(nil)
+	},
 	Run: func(cmd *cobra.Command, args []string) {
 		startTUI()
 	},
@@ -41,27 +50,38 @@ func init() {
 	slog.Debug(fmt.Sprintf("Version: %s", VERSION))
 
 	rootCmd.PersistentFlags().BoolVarP(&debug, "debug", "d", false, "Display debugging output")
+	rootCmd.PersistentFlags().BoolVar(&jsonOutput, "json", false, "Print command results as JSON (progress goes to stderr)")
+	rootCmd.PersistentFlags().BoolVarP(&quietOutput, "quiet", "q", false, "Only log warnings and errors")
 
 	setupLogging(nil)
 }
 
 // setupLogging configures the global slog logger.
-// In CLI mode (sink == nil) it writes coloured output to stdout via tint.
+// In CLI mode (sink == nil) it writes coloured output to stdout via tint, or
+// to stderr with --json so stdout holds only the JSON result.
 // In TUI mode (sink != nil) it routes all output to the in-memory sink so
 // that log lines do not corrupt the alternate-screen display.
 func setupLogging(sink *logging.MemorySink) {
 	level := slog.LevelInfo
+	if quietOutput {
+		level = slog.LevelWarn
+	}
 	if debug {
 		level = slog.LevelDebug
 	}
 
+	w := os.Stdout
+	if jsonOutput {
+		w = os.Stderr
+	}
+
 	var handler slog.Handler
 	if sink != nil {
 		handler = sink
 	} else if os.Getenv("MODE") == "production" {
-		handler = slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: level})
+		handler = slog.NewJSONHandler(w, &slog.HandlerOptions{Level: level})
 	} else {
-		handler = tint.NewHandler(os.Stdout, &tint.Options{Level: level})
+		handler = tint.NewHandler(w, &tint.Options{Level: level})
 	}
 
 	slog.SetDefault(slog.New(handler))
@@ -80,8 +100,9 @@ func configDir() (string, error) {
 }
 
 func startTUI() {
+	haveConfig := false
 	if dir, err := configDir(); err == nil {
-		_ = loadEnvFile(dir)
+		haveConfig = loadEnvFile(dir) == nil
 	}
 
 	// In TUI mode route all logs to an in-memory sink so they don't corrupt
@@ -92,7 +113,22 @@ func startTUI() {
 	db := database.New(dbPathFromEnv())
 	defer db.Close()
 
-	model := tui.NewModel(db, apiKeyFromEnv(), logSink)
+	var summarizer *services.Summarizer
+	if apiKey := apiKeyFromEnv(); apiKey != "" {
+		summarizer = newSummarizer(apiKey)
+	}
+	model := tui.NewModel(db, fetcherFromEnv(), summarizer, extractorFromEnv(), logSink)
+	if !haveConfig && os.Getenv("DB_PATH") == "" && apiKeyFromEnv() == "" {
+		model.AddStartupNotice("warning", "No config found. Run `lm init` to set up.")
+	}
+	if webhookURL := webhookURLFromEnv(); webhookURL != "" {
+		model.SetWebhook(services.NewWebhook(webhookURL))
+	}
+	model.SetStatusRule(statusRuleFromEnv())
+	model.SetCategoryRules(categoryRulesFromEnv())
+	model.SetLinkDefaults(linkDefaultsFromEnv())
+	model.SetReadingWidth(readingWidthFromEnv())
+	model.SetBrowser(browserFromEnv())
 	p := tea.NewProgram(model, tea.WithAltScreen())
 
 	if _, err := p.Run(); err != nil {
@@ -123,3 +159,217 @@ func dbPathFromEnv() string {
 func apiKeyFromEnv() string {
 	return os.Getenv("OPENAI_API_KEY")
 }
+
+// apiTokenFromEnv returns the bearer token required by the `lm serve` API.
+func apiTokenFromEnv() string {
+	return os.Getenv("API_TOKEN")
+}
+
+// webhookURLFromEnv returns the URL to POST to when a link is added, or "" if
+// no webhook is configured.
+func webhookURLFromEnv() string {
+	return os.Getenv("WEBHOOK_URL")
+}
+
+// statusRuleFromEnv returns the rule for new links' status set by
+// AUTO_STATUS (e.g. 3000:read_later:archived), or the zero rule, which
+// saves everything as read_later, if it is unset or invalid.
+func statusRuleFromEnv() services.StatusRule {
+	raw := os.Getenv("AUTO_STATUS")
+	if raw == "" {
+		return services.StatusRule{}
+	}
+	rule, err := services.ParseStatusRule(raw)
+	if err != nil {
+		slog.Warn("ignoring AUTO_STATUS", "error", err)
+		return services.StatusRule{}
+	}
+	return rule
+}
+
+// categoryRulesFromEnv returns the rules that file new links under a category
+// without the LLM, as set by CATEGORY_RULES (e.g. github.com=Code;
+// *.edu=Research), or none if it is unset or invalid.
+func categoryRulesFromEnv() services.CategoryRules {
+	raw := os.Getenv("CATEGORY_RULES")
+	if raw == "" {
+		return nil
+	}
+	rules, err := services.ParseCategoryRules(raw)
+	if err != nil {
+		slog.Warn("ignoring CATEGORY_RULES", "error", err)
+		return nil
+	}
+	return rules
+}
+
+// linkDefaultsFromEnv returns the category and tag for new links that get
+// none otherwise, as set by DEFAULT_CATEGORY and DEFAULT_TAG. Unset, they
+// are General and uncategorized; set empty, links are left without.
+func linkDefaultsFromEnv() services.LinkDefaults {
+	defaults := services.StandardLinkDefaults
+	if category, ok := os.LookupEnv("DEFAULT_CATEGORY"); ok {
+		defaults.Category = strings.TrimSpace(category)
+	}
+	if tag, ok := os.LookupEnv("DEFAULT_TAG"); ok {
+		defaults.Tag = strings.ToLower(strings.TrimSpace(tag))
+	}
+	return defaults
+}
+
+// defaultReadingWidth is how wide the TUI's detail text runs when
+// READING_WIDTH is unset.
+const defaultReadingWidth = 100
+
+// readingWidthFromEnv returns the widest, in columns, that the TUI's detail
+// text runs, as set by READING_WIDTH; 0 lets it fill the panel. Unset or
+// invalid values keep the default.
+func readingWidthFromEnv() int {
+	raw := os.Getenv("READING_WIDTH")
+	if raw == "" {
+		return defaultReadingWidth
+	}
+	columns, err := strconv.Atoi(raw)
+	if err != nil || columns < 0 {
+		slog.Warn("ignoring READING_WIDTH", "value", raw)
+		return defaultReadingWidth
+	}
+	return columns
+}
+
+// mailerFromEnv returns a mailer for the SMTP_* settings, or nil if
+// SMTP_HOST is not set.
+func mailerFromEnv() *services.Mailer {
+	host := os.Getenv("SMTP_HOST")
+	if host == "" {
+		return nil
+	}
+	return services.NewMailer(host, os.Getenv("SMTP_PORT"), os.Getenv("SMTP_USERNAME"), os.Getenv("SMTP_PASSWORD"), os.Getenv("SMTP_FROM"))
+}
+
+// checkedSummarizer returns newSummarizer(apiKey) once Validate has checked
+// the key, or nil if OpenAI rejected it. Any other failure is only logged,
+// leaving the summarizer on for each call to try again.
+func checkedSummarizer(ctx context.Context, apiKey string) *services.Summarizer {
+	summarizer := newSummarizer(apiKey)
+	if err := summarizer.Validate(ctx); err != nil {
+		if !summarizer.Enabled() {
+			slog.Warn("summarization disabled", "error", err)
+			return nil
+		}
+		slog.Warn("could not check OpenAI API key; summarizing anyway", "error", err)
+	}
+	return summarizer
+}
+
+// newSummarizer returns a summarizer for apiKey whose calls are limited by
+// LLM_TIMEOUT: a duration such as 90s or 2m, or plain seconds; 0 disables
+// the limit. Unset or invalid values keep the 60s default. Page text is cut
+// as TRUNCATE_AT says, and SHORT_SUMMARIES=true adds a one-line summary to
+// each summary.
+func newSummarizer(apiKey string) *services.Summarizer {
+	summarizer := services.NewSummarizer(apiKey)
+	summarizer.SetTruncateStyle(truncateStyleFromEnv())
+	if raw := os.Getenv("SHORT_SUMMARIES"); raw != "" {
+		short, err := strconv.ParseBool(raw)
+		if err != nil {
+			slog.Warn("ignoring SHORT_SUMMARIES", "value", raw, "error", err)
+		} else {
+			summarizer.SetShortSummaries(short)
+		}
+	}
+	raw := os.Getenv("LLM_TIMEOUT")
+	if raw == "" {
+		return summarizer
+	}
+	timeout, err := time.ParseDuration(raw)
+	if err != nil {
+		secs, serr := strconv.Atoi(raw)
+		if serr != nil || secs < 0 {
+			slog.Warn("ignoring LLM_TIMEOUT", "value", raw, "error", err)
+			return summarizer
+		}
+		timeout = time.Duration(secs) * time.Second
+	}
+	summarizer.SetTimeout(timeout)
+	return summarizer
+}
+
+// extractorFromEnv returns an extractor that keeps link URLs as set by
+// LINK_URLS (strip, inline, or footnotes; strip if unset or invalid), and
+// lists the images in pages if KEEP_IMAGES is true.
+func extractorFromEnv() *services.Extractor {
+	extractor := services.NewExtractor()
+	if raw := os.Getenv("LINK_URLS"); raw != "" {
+		style, err := services.ParseLinkStyle(raw)
+		if err != nil {
+			slog.Warn("ignoring LINK_URLS", "error", err)
+		} else {
+			extractor.SetLinkStyle(style)
+		}
+	}
+	extractor.SetTruncateStyle(truncateStyleFromEnv())
+	if raw := os.Getenv("KEEP_IMAGES"); raw != "" {
+		keep, err := strconv.ParseBool(raw)
+		if err != nil {
+			slog.Warn("ignoring KEEP_IMAGES", "value", raw, "error", err)
+		} else {
+			extractor.SetKeepImages(keep)
+		}
+	}
+	return extractor
+}
+
+// truncateStyleFromEnv returns where long page text is cut, as set by
+// TRUNCATE_AT (word or sentence; word if unset or invalid).
+func truncateStyleFromEnv() services.TruncateStyle {
+	raw := os.Getenv("TRUNCATE_AT")
+	if raw == "" {
+		return services.TruncateWords
+	}
+	style, err := services.ParseTruncateStyle(raw)
+	if err != nil {
+		slog.Warn("ignoring TRUNCATE_AT", "error", err)
+		return services.TruncateWords
+	}
+	return style
+}
+
+// fetcherFromEnv returns a fetcher that renders JavaScript-heavy pages
+// through the RENDER_URL service, if one is set, and keeps fetched HTML for
+// archiving if ARCHIVE_HTML is true.
+func fetcherFromEnv() *services.Fetcher {
+	fetcher := services.NewFetcher()
+	if endpoint := os.Getenv("RENDER_URL"); endpoint != "" {
+		fetcher.SetRenderService(endpoint)
+	}
+	if raw := os.Getenv("ARCHIVE_HTML"); raw != "" {
+		archive, err := strconv.ParseBool(raw)
+		if err != nil {
+			slog.Warn("ignoring ARCHIVE_HTML", "value", raw, "error", err)
+		} else {
+			fetcher.SetArchiveHTML(archive)
+		}
+	}
+	return fetcher
+}
+
+// renderingFetcher returns fetcherFromEnv's fetcher, set to render every page
+// when render is true (a command's --render flag).
+func renderingFetcher(render bool) (*services.Fetcher, error) {
+	fetcher := fetcherFromEnv()
+	if render {
+		if !fetcher.CanRender() {
+			return nil, fmt.Errorf("--render requires RENDER_URL to be set")
+		}
+		fetcher.SetAlwaysRender(true)
+	}
+	return fetcher, nil
+}
+
+// browserFromEnv returns the browser command from the BROWSER env var, or ""
+// to use the system default opener. It is read here only; the CLI and the
+// TUI both pass it to services.OpenURL, which documents its syntax.
+func browserFromEnv() string {
+	return os.Getenv("BROWSER")
+}
@@ -10,12 +10,15 @@ import (
 
 	"mccwk.com/lm/internal/database"
 	"mccwk.com/lm/internal/models"
+	"mccwk.com/lm/internal/services"
 )
 
 var (
 	searchCategory string
 	searchTags     string
 	searchType     string
+	searchDomain   string
+	searchArchived bool
 )
 
 var searchCmd = &cobra.Command{
@@ -25,6 +28,8 @@ var searchCmd = &cobra.Command{
 
   --category <name>   Filter to links in the named category.
   --tags <t1,t2>      Filter to links that have ALL of the listed tags.
+  --domain <host>     Filter to links from the given site (e.g. github.com).
+  --include-archived  Include archived links, which are skipped by default.
   --type link|task|activity
                       Filter by association:
                         link     – standalone links (not in a task or activity)
@@ -38,6 +43,8 @@ func init() {
 	searchCmd.Flags().StringVarP(&searchCategory, "category", "c", "", "Filter by category name")
 	searchCmd.Flags().StringVarP(&searchTags, "tags", "t", "", "Filter by comma-separated tags (link must have all)")
 	searchCmd.Flags().StringVar(&searchType, "type", "", "Filter by type: link, task, or activity")
+	searchCmd.Flags().StringVar(&searchDomain, "domain", "", "Filter by site domain, e.g. github.com")
+	searchCmd.Flags().BoolVar(&searchArchived, "include-archived", false, "Include archived links")
 	rootCmd.AddCommand(searchCmd)
 }
 
@@ -45,12 +52,15 @@ func runSearch(cmd *cobra.Command, args []string) error {
 	query := strings.TrimSpace(args[0])
 	ctx := context.Background()
 
-	if searchType != "" {
-		switch searchType {
-		case "link", "task", "activity":
-		default:
-			return fmt.Errorf("invalid --type %q: must be link, task, or activity", searchType)
-		}
+	opts := searchOptions{
+		category:        searchCategory,
+		tags:            searchTags,
+		linkType:        searchType,
+		domain:          searchDomain,
+		includeArchived: searchArchived,
+	}
+	if err := opts.validate(); err != nil {
+		return err
 	}
 
 	// Load env / config
@@ -61,6 +71,53 @@ func runSearch(cmd *cobra.Command, args []string) error {
 	db := database.New(dbPathFromEnv())
 	defer db.Close()
 
+	links, err := findLinks(ctx, db, query, opts, 100)
+	if err != nil {
+		return err
+	}
+
+	return emit(toLinkOutputs(links), func() {
+		if len(links) == 0 {
+			fmt.Println("No results found.")
+			return
+		}
+
+		fmt.Printf("Found %d result(s):\n\n", len(links))
+		for i, l := range links {
+			title := l.Title.String
+			if title == "" {
+				title = l.Url
+			}
+			fmt.Printf("%d. %s\n", i+1, title)
+			fmt.Printf("   %s\n", l.Url)
+			if l.Summary.Valid && l.Summary.String != "" {
+				fmt.Printf("   %s\n", truncate(l.Summary.String, 120))
+			}
+			fmt.Println()
+		}
+	})
+}
+
+// searchOptions narrows a text search, mirroring the `lm search` flags.
+type searchOptions struct {
+	category        string
+	tags            string
+	linkType        string
+	domain          string
+	includeArchived bool
+}
+
+func (o searchOptions) validate() error {
+	switch o.linkType {
+	case "", "link", "task", "activity":
+		return nil
+	}
+	return fmt.Errorf("invalid --type %q: must be link, task, or activity", o.linkType)
+}
+
+// findLinks returns up to limit links matching query and opts. A limit of -1
+// means no limit.
+func findLinks(ctx context.Context, db *database.Database, query string, opts searchOptions, limit int64) ([]models.Link, error) {
 	// Fetch matching links
 	pattern := "%" + query + "%"
 	links, err := db.Queries.SearchLinks(ctx, models.SearchLinksParams{
@@ -68,23 +125,45 @@ func runSearch(cmd *cobra.Command, args []string) error {
 		Title:   sql.NullString{String: pattern, Valid: true},
 		Content: sql.NullString{String: pattern, Valid: true},
 		Summary: sql.NullString{String: pattern, Valid: true},
-		Limit:   100,
+		Limit:   limit,
 		Offset:  0,
 	})
 	if err != nil {
-		return fmt.Errorf("search failed: %w", err)
+		return nil, fmt.Errorf("search failed: %w", err)
 	}
 
-	// Apply category filter
-	if searchCategory != "" {
-		cat, err := db.Queries.GetCategoryByName(ctx, searchCategory)
+	// Archived links are hidden unless asked for
+	if !opts.includeArchived {
+		filtered := links[:0]
+		for _, l := range links {
+			if l.Status != "archived" {
+				filtered = append(filtered, l)
+			}
+		}
+		links = filtered
+	}
+
+	// Apply domain filter
+	if opts.domain != "" {
+		domain := normalizeDomain(opts.domain)
+		filtered := links[:0]
+		for _, l := range links {
+			if l.Domain == domain {
+				filtered = append(filtered, l)
+			}
+		}
+		links = filtered
+	}
+
+	// Apply category filter; an unknown category matches nothing
+	if opts.category != "" {
+		cat, err := db.Queries.GetCategoryByName(ctx, opts.category)
 		if err != nil {
-			fmt.Printf("Category %q not found.\n", searchCategory)
-			return nil
+			return nil, nil
 		}
 		catLinks, err := db.Queries.GetLinksForCategory(ctx, cat.ID)
 		if err != nil {
-			return fmt.Errorf("category lookup failed: %w", err)
+			return nil, fmt.Errorf("category lookup failed: %w", err)
 		}
 		catIDs := make(map[int64]struct{}, len(catLinks))
 		for _, l := range catLinks {
@@ -100,7 +179,7 @@ func runSearch(cmd *cobra.Command, args []string) error {
 	}
 
 	// Apply tag filter
-	wantTags := parseTags(searchTags)
+	wantTags := parseTags(opts.tags)
 	if len(wantTags) > 0 {
 		filtered := links[:0]
 		for _, l := range links {
@@ -112,10 +191,10 @@ func runSearch(cmd *cobra.Command, args []string) error {
 	}
 
 	// Apply type filter
-	if searchType != "" {
+	if opts.linkType != "" {
 		filtered := links[:0]
 		for _, l := range links {
-			match, err := linkMatchesType(ctx, db, l.ID, searchType)
+			match, err := linkMatchesType(ctx, db, l.ID, opts.linkType)
 			if err == nil && match {
 				filtered = append(filtered, l)
 			}
@@ -123,26 +202,7 @@ func runSearch(cmd *cobra.Command, args []string) error {
 		links = filtered
 	}
 
-	if len(links) == 0 {
-		fmt.Println("No results found.")
-		return nil
-	}
-
-	fmt.Printf("Found %d result(s):\n\n", len(links))
-	for i, l := range links {
-		title := l.Title.String
-		if title == "" {
-			title = l.Url
-		}
-		fmt.Printf("%d. %s\n", i+1, title)
-		fmt.Printf("   %s\n", l.Url)
-		if l.Summary.Valid && l.Summary.String != "" {
-			fmt.Printf("   %s\n", truncate(l.Summary.String, 120))
-		}
-		fmt.Println()
-	}
-
-	return nil
+	return links, nil
 }
 
 func linkHasAllTags(ctx context.Context, db *database.Database, linkID int64, wantTags []string) bool {
@@ -193,6 +253,16 @@ func linkMatchesType(ctx context.Context, db *database.Database, linkID int64, l
 	return true, nil
 }
 
+// normalizeDomain lets users pass a bare host, a "www." host, or a full URL
+// to --domain and matches the stored form either way.
+func normalizeDomain(s string) string {
+	s = strings.TrimSpace(s)
+	if !strings.Contains(s, "://") {
+		s = "https://" + s
+	}
+	return services.DomainFromURL(s)
+}
+
 func truncate(s string, n int) string {
 	if len(s) <= n {
 		return s
@@ -0,0 +1,198 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"mccwk.com/lm/internal/api"
+	"mccwk.com/lm/internal/database"
+	"mccwk.com/lm/internal/models"
+	"mccwk.com/lm/internal/services"
+)
+
+var (
+	serveHTTP            string
+	serveToken           string
+	serveRefetchInterval time.Duration
+	serveStaleAfter      time.Duration
+	serveBatch           int64
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Run lm as a long-lived background service",
+	Long: `Run lm without the TUI, for self-hosted setups.
+
+  --http <addr>           Serve a read-only JSON API on addr (e.g. :8080):
+                            GET /api/links?q=&status=&domain=&limit=&offset=
+                            GET /api/links/{id}
+                            GET /api/tags
+                            GET /api/categories
+  --token <t>             Require "Authorization: Bearer <t>" on API
+                          requests (default: API_TOKEN from the environment).
+  --refetch-interval <d>  Every d (e.g. 24h), refetch links whose content
+                          was last fetched more than --stale-after ago.
+  --stale-after <d>       Age at which a link counts as stale (default 720h).
+  --batch <n>             Maximum links to refetch per pass (default 100).
+
+At least one of --http or --refetch-interval is required. Runs until
+interrupted (SIGINT/SIGTERM); an in-progress refetch finishes its current
+link before exiting.`,
+	Args: cobra.NoArgs,
+	RunE: runServe,
+}
+
+func init() {
+	serveCmd.Flags().StringVar(&serveHTTP, "http", "", "Address to serve the JSON API on, e.g. :8080")
+	serveCmd.Flags().StringVar(&serveToken, "token", "", "Bearer token required by the API (default $API_TOKEN)")
+	serveCmd.Flags().DurationVar(&serveRefetchInterval, "refetch-interval", 0, "How often to refetch stale links, e.g. 24h")
+	serveCmd.Flags().DurationVar(&serveStaleAfter, "stale-after", 30*24*time.Hour, "Refetch links last fetched longer ago than this")
+	serveCmd.Flags().Int64Var(&serveBatch, "batch", 100, "Maximum links to refetch per pass")
+	rootCmd.AddCommand(serveCmd)
+}
+
+func runServe(cmd *cobra.Command, args []string) error {
+	if serveHTTP == "" && serveRefetchInterval <= 0 {
+		return fmt.Errorf("nothing to serve: pass --http and/or --refetch-interval")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if dir, err := configDir(); err == nil {
+		_ = loadEnvFile(dir)
+	}
+
+	db := database.New(dbPathFromEnv())
+	defer db.Close()
+
+	errc := make(chan error, 1)
+	var srv *http.Server
+	if serveHTTP != "" {
+		token := serveToken
+		if token == "" {
+			token = apiTokenFromEnv()
+		}
+		if token == "" {
+			slog.Warn("API has no bearer token; anyone who can reach it can read the library")
+		}
+		srv = &http.Server{
+			Addr:              serveHTTP,
+			Handler:           api.NewServer(db, token),
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+		go func() {
+			slog.Info("API listening", "addr", serveHTTP)
+			if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				errc <- err
+			}
+		}()
+	}
+
+	done := make(chan struct{})
+	if serveRefetchInterval > 0 {
+		go func() {
+			defer close(done)
+			refetchLoop(ctx, db)
+		}()
+	} else {
+		close(done)
+	}
+
+	var err error
+	select {
+	case <-ctx.Done():
+	case err = <-errc:
+		stop()
+	}
+	slog.Info("shutting down")
+	if srv != nil {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdownCtx)
+	}
+	<-done
+	if err != nil {
+		return fmt.Errorf("API server failed: %w", err)
+	}
+	return nil
+}
+
+// refetchLoop refetches stale links every serveRefetchInterval until ctx is
+// cancelled.
+func refetchLoop(ctx context.Context, db *database.Database) {
+	fetcher := fetcherFromEnv()
+	extractor := extractorFromEnv()
+	var summarizer *services.Summarizer
+	if apiKey := apiKeyFromEnv(); apiKey != "" {
+		summarizer = checkedSummarizer(ctx, apiKey)
+	}
+
+	slog.Info("refetch loop started", "interval", serveRefetchInterval, "stale_after", serveStaleAfter)
+	ticker := time.NewTicker(serveRefetchInterval)
+	defer ticker.Stop()
+
+	for {
+		refetchStale(ctx, db, fetcher, extractor, summarizer)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// refetchStale refetches up to serveBatch links whose content is older than
+// serveStaleAfter, stopping early if ctx is cancelled.
+func refetchStale(ctx context.Context, db *database.Database, fetcher *services.Fetcher, extractor *services.Extractor, summarizer *services.Summarizer) {
+	links, err := db.Queries.ListStaleLinks(ctx, models.ListStaleLinksParams{
+		Age:   fmt.Sprintf("-%d seconds", int64(serveStaleAfter.Seconds())),
+		Limit: serveBatch,
+	})
+	if err != nil {
+		slog.Error("failed to list stale links", "error", err)
+		return
+	}
+	if len(links) == 0 {
+		slog.Info("no stale links")
+		return
+	}
+
+	var grandInputTok, grandOutputTok int
+	var processed, skipped int
+	for i, link := range links {
+		if ctx.Err() != nil {
+			break
+		}
+		slog.Info("processing URL", "index", i+1, "total", len(links), "url", link.Url)
+		// Use a fresh context so a shutdown signal lets the current link finish.
+		inTok, outTok, err := refetchURL(context.Background(), db, fetcher, extractor, summarizer, link.Url)
+		grandInputTok += inTok
+		grandOutputTok += outTok
+		if err != nil {
+			slog.Error("failed to refetch URL", "url", link.Url, "error", err)
+			skipped++
+			continue
+		}
+		processed++
+	}
+
+	slog.Info("refetch pass complete", "processed", processed, "skipped", skipped)
+	if grandInputTok+grandOutputTok > 0 {
+		cost := services.LLMCost(grandInputTok, grandOutputTok)
+		slog.Info("LLM usage total",
+			"input_tokens", grandInputTok,
+			"output_tokens", grandOutputTok,
+			"cost_usd", fmt.Sprintf("$%.5f", cost),
+		)
+	}
+}
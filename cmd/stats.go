@@ -0,0 +1,206 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"mccwk.com/lm/internal/database"
+	"mccwk.com/lm/internal/services"
+)
+
+var (
+	statsTop int64
+	statsLLM bool
+)
+
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show a summary of saved links",
+	Long: `Show counts of saved links by status, the sites you save from most, and
+links expiring within the week or already expired.
+
+  --top <n>   Number of domains to list (default 20).
+  --llm       Show AI summary spend instead: today, this month, all time,
+              and month by month. Usage is recorded from when this was
+              added; earlier calls are not counted.`,
+	Args: cobra.NoArgs,
+	RunE: runStats,
+}
+
+func init() {
+	statsCmd.Flags().Int64Var(&statsTop, "top", 20, "Number of top domains to show")
+	statsCmd.Flags().BoolVar(&statsLLM, "llm", false, "Show LLM token usage and cost over time")
+	rootCmd.AddCommand(statsCmd)
+}
+
+func runStats(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	// Load env / config
+	if dir, err := configDir(); err == nil {
+		_ = loadEnvFile(dir)
+	}
+
+	db := database.New(dbPathFromEnv())
+	defer db.Close()
+
+	if statsLLM {
+		return runLLMStats(ctx, db)
+	}
+
+	byStatus, err := db.Queries.CountLinksByStatus(ctx)
+	if err != nil {
+		return fmt.Errorf("status counts failed: %w", err)
+	}
+	var total int64
+	for _, s := range byStatus {
+		total += s.Count
+	}
+
+	domains, err := db.Queries.CountLinksByDomain(ctx, statsTop)
+	if err != nil {
+		return fmt.Errorf("domain counts failed: %w", err)
+	}
+
+	expiring, err := db.Queries.ListExpiringLinks(ctx)
+	if err != nil {
+		return fmt.Errorf("expiring links failed: %w", err)
+	}
+
+	out := statsOutput{Total: total, ByStatus: map[string]int64{}, TopDomains: []domainCount{}, ExpiringSoon: []linkOutput{}}
+	for _, s := range byStatus {
+		out.ByStatus[s.Status] = s.Count
+	}
+	for _, d := range domains {
+		out.TopDomains = append(out.TopDomains, domainCount{Domain: d.Domain, Count: d.Count})
+	}
+	now := time.Now()
+	for _, l := range expiring {
+		switch services.ExpiryState(l.ExpiresAt, now) {
+		case services.ExpiryExpired:
+			out.Expired++
+		case services.ExpiryExpiring:
+			out.ExpiringSoon = append(out.ExpiringSoon, toLinkOutput(l))
+		}
+	}
+
+	return emit(out, func() {
+		fmt.Printf("Links: %d\n", total)
+		for _, s := range byStatus {
+			fmt.Printf("  %-12s %d\n", s.Status, s.Count)
+		}
+
+		if len(domains) > 0 {
+			width := 0
+			for _, d := range domains {
+				width = max(width, len(d.Domain))
+			}
+			fmt.Printf("\nTop domains:\n")
+			for i, d := range domains {
+				fmt.Printf("  %2d. %-*s %d\n", i+1, width, d.Domain, d.Count)
+			}
+		}
+
+		if len(out.ExpiringSoon) > 0 || out.Expired > 0 {
+			fmt.Printf("\nExpiring soon:\n")
+			for _, l := range out.ExpiringSoon {
+				title := l.Title
+				if title == "" {
+					title = l.URL
+				}
+				fmt.Printf("  %s  %d. %s\n", l.ExpiresAt.Local().Format("2006-01-02"), l.ID, title)
+			}
+			if out.Expired > 0 {
+				fmt.Printf("  (%d already expired; search is:expired in the TUI)\n", out.Expired)
+			}
+		}
+	})
+}
+
+// statsOutput is the JSON form of 'lm stats'.
+type statsOutput struct {
+	Total      int64            `json:"total"`
+	ByStatus   map[string]int64 `json:"by_status"`
+	TopDomains []domainCount    `json:"top_domains"`
+
+	// Expired counts links past their expiry date; ExpiringSoon lists those
+	// expiring within services.ExpiringSoonWindow, soonest first.
+	Expired      int64        `json:"expired"`
+	ExpiringSoon []linkOutput `json:"expiring_soon"`
+}
+
+type domainCount struct {
+	Domain string `json:"domain"`
+	Count  int64  `json:"count"`
+}
+
+// runLLMStats prints the LLM spend recorded in llm_usage.
+func runLLMStats(ctx context.Context, db *database.Database) error {
+	today, err := db.Queries.SumLLMUsageSince(ctx, "start of day")
+	if err != nil {
+		return fmt.Errorf("LLM usage failed: %w", err)
+	}
+	month, err := db.Queries.SumLLMUsageSince(ctx, "start of month")
+	if err != nil {
+		return fmt.Errorf("LLM usage failed: %w", err)
+	}
+	byMonth, err := db.Queries.ListLLMUsageByMonth(ctx)
+	if err != nil {
+		return fmt.Errorf("LLM usage failed: %w", err)
+	}
+
+	out := llmStatsOutput{
+		Today:     llmUsage(today),
+		ThisMonth: llmUsage(month),
+		ByMonth:   []llmMonthUsage{},
+	}
+	for _, m := range byMonth {
+		u := llmUsage{Calls: m.Calls, InputTokens: m.InputTokens, OutputTokens: m.OutputTokens, CostUsd: m.CostUsd}
+		out.AllTime.Calls += u.Calls
+		out.AllTime.InputTokens += u.InputTokens
+		out.AllTime.OutputTokens += u.OutputTokens
+		out.AllTime.CostUsd += u.CostUsd
+		out.ByMonth = append(out.ByMonth, llmMonthUsage{Month: m.Month, llmUsage: u})
+	}
+
+	return emit(out, func() {
+		if out.AllTime.Calls == 0 {
+			fmt.Println("No LLM usage recorded yet.")
+			return
+		}
+		printUsage := func(label string, u llmUsage) {
+			fmt.Printf("  %-10s %6d calls %10d tokens  $%.4f\n", label, u.Calls, u.InputTokens+u.OutputTokens, u.CostUsd)
+		}
+		fmt.Println("LLM usage:")
+		printUsage("Today", out.Today)
+		printUsage("This month", out.ThisMonth)
+		printUsage("All time", out.AllTime)
+		fmt.Printf("\nBy month:\n")
+		for _, m := range out.ByMonth {
+			printUsage(m.Month, m.llmUsage)
+		}
+	})
+}
+
+// llmStatsOutput is the JSON form of 'lm stats --llm'.
+type llmStatsOutput struct {
+	Today     llmUsage        `json:"today"`
+	ThisMonth llmUsage        `json:"this_month"`
+	AllTime   llmUsage        `json:"all_time"`
+	ByMonth   []llmMonthUsage `json:"by_month"`
+}
+
+type llmUsage struct {
+	Calls        int64   `json:"calls"`
+	InputTokens  int64   `json:"input_tokens"`
+	OutputTokens int64   `json:"output_tokens"`
+	CostUsd      float64 `json:"cost_usd"`
+}
+
+type llmMonthUsage struct {
+	Month string `json:"month"` // YYYY-MM, local time
+	llmUsage
+}
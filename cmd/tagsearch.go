@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"mccwk.com/lm/internal/database"
+)
+
+var (
+	tagSearchAdd    []string
+	tagSearchDryRun bool
+	tagSearchYes    bool
+	tagSearchOpts   searchOptions
+)
+
+var tagSearchCmd = &cobra.Command{
+	Use:   "tag-search <text> --add <tag>...",
+	Short: "Tag every link matching a search",
+	Long: `Run the same search as 'lm search' and add tags to every matching link.
+
+  --add <t1,t2>       Tags to add (repeatable; created if they do not exist).
+  --dry-run           List the matching links without changing anything.
+  --yes               Skip the confirmation prompt.
+
+The --category, --tags, --domain, --type, and --include-archived filters work
+as in 'lm search'. Unlike 'lm search', every match is tagged, not just the
+first 100.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTagSearch,
+}
+
+func init() {
+	tagSearchCmd.Flags().StringSliceVar(&tagSearchAdd, "add", nil, "Tags to add to every matching link")
+	tagSearchCmd.Flags().BoolVar(&tagSearchDryRun, "dry-run", false, "Show the matching links without tagging them")
+	tagSearchCmd.Flags().BoolVarP(&tagSearchYes, "yes", "y", false, "Tag without asking for confirmation")
+	tagSearchCmd.Flags().StringVarP(&tagSearchOpts.category, "category", "c", "", "Filter by category name")
+	tagSearchCmd.Flags().StringVarP(&tagSearchOpts.tags, "tags", "t", "", "Filter by comma-separated tags (link must have all)")
+	tagSearchCmd.Flags().StringVar(&tagSearchOpts.linkType, "type", "", "Filter by type: link, task, or activity")
+	tagSearchCmd.Flags().StringVar(&tagSearchOpts.domain, "domain", "", "Filter by site domain, e.g. github.com")
+	tagSearchCmd.Flags().BoolVar(&tagSearchOpts.includeArchived, "include-archived", false, "Include archived links")
+	rootCmd.AddCommand(tagSearchCmd)
+}
+
+func runTagSearch(cmd *cobra.Command, args []string) error {
+	query := strings.TrimSpace(args[0])
+	ctx := context.Background()
+
+	tags := parseTags(strings.Join(tagSearchAdd, ","))
+	if len(tags) == 0 {
+		return fmt.Errorf("no tags given: pass --add <tag>")
+	}
+	if err := tagSearchOpts.validate(); err != nil {
+		return err
+	}
+
+	if dir, err := configDir(); err == nil {
+		_ = loadEnvFile(dir)
+	}
+
+	db := database.New(dbPathFromEnv())
+	defer db.Close()
+
+	links, err := findLinks(ctx, db, query, tagSearchOpts, -1)
+	if err != nil {
+		return err
+	}
+	out := tagSearchOutput{Tags: tags, Links: toLinkOutputs(links)}
+	if len(links) == 0 {
+		return emit(out, func() { fmt.Println("No results found.") })
+	}
+
+	if tagSearchDryRun {
+		return emit(out, func() {
+			fmt.Printf("Would tag %d link(s) with %s:\n\n", len(links), strings.Join(tags, ", "))
+			for _, l := range links {
+				title := l.Title.String
+				if title == "" {
+					title = l.Url
+				}
+				fmt.Printf("%d. %s\n", l.ID, title)
+			}
+		})
+	}
+
+	if !tagSearchYes {
+		if jsonOutput {
+			return fmt.Errorf("--json needs --yes or --dry-run")
+		}
+		in := bufio.NewReader(os.Stdin)
+		question := fmt.Sprintf("Tag %d link(s) with %s?", len(links), strings.Join(tags, ", "))
+		if !promptYesNo(in, question, false) {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+	}
+
+	for _, l := range links {
+		assignTags(ctx, db, l.ID, tags)
+	}
+	out.Tagged = true
+	return emit(out, func() {
+		fmt.Printf("Tagged %d link(s) with %s.\n", len(links), strings.Join(tags, ", "))
+	})
+}
+
+// tagSearchOutput is the JSON form of 'lm tag-search'.
+type tagSearchOutput struct {
+	Tags   []string     `json:"tags"`
+	Tagged bool         `json:"tagged"`
+	Links  []linkOutput `json:"links"`
+}
@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"mccwk.com/lm/internal/database"
+)
+
+var trashCmd = &cobra.Command{
+	Use:   "trash",
+	Short: "List links in the trash",
+	Long: `List links that have been deleted.
+
+Deleted links are kept in the trash until purged, so they can be
+restored if removed by mistake.
+
+  lm trash                 List trashed links.
+  lm trash restore <id>    Move a link out of the trash.
+  lm trash purge [id]      Permanently delete one trashed link, or all of them.`,
+	Args: cobra.NoArgs,
+	RunE: runTrashList,
+}
+
+var trashRestoreCmd = &cobra.Command{
+	Use:   "restore <url|id>",
+	Short: "Restore a link from the trash",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runTrashRestore,
+}
+
+var trashPurgeCmd = &cobra.Command{
+	Use:   "purge [url|id]",
+	Short: "Permanently delete trashed links",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runTrashPurge,
+}
+
+func init() {
+	trashCmd.AddCommand(trashRestoreCmd)
+	trashCmd.AddCommand(trashPurgeCmd)
+	rootCmd.AddCommand(trashCmd)
+}
+
+func openTrashDB() *database.Database {
+	if dir, err := configDir(); err == nil {
+		_ = loadEnvFile(dir)
+	}
+	return database.New(dbPathFromEnv())
+}
+
+func runTrashList(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	db := openTrashDB()
+	defer db.Close()
+
+	links, err := db.Queries.ListDeletedLinks(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list trash: %w", err)
+	}
+	return emit(toLinkOutputs(links), func() {
+		if len(links) == 0 {
+			fmt.Println("Trash is empty.")
+			return
+		}
+
+		fmt.Printf("%d link(s) in trash:\n\n", len(links))
+		for _, l := range links {
+			title := l.Title.String
+			if title == "" {
+				title = l.Url
+			}
+			fmt.Printf("%d. %s\n", l.ID, title)
+			fmt.Printf("   %s\n", l.Url)
+			fmt.Printf("   deleted %s\n\n", l.DeletedAt.Time.Local().Format("2006-01-02 15:04"))
+		}
+	})
+}
+
+func runTrashRestore(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	db := openTrashDB()
+	defer db.Close()
+
+	link, err := lookupLink(ctx, db, args[0])
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && !link.DeletedAt.Valid) {
+		return emit([]linkOutput{}, func() { fmt.Println("No matching link in trash.") })
+	}
+	if err != nil {
+		return fmt.Errorf("lookup failed: %w", err)
+	}
+	if err := db.Queries.RestoreLink(ctx, link.ID); err != nil {
+		return fmt.Errorf("restore failed: %w", err)
+	}
+	link.DeletedAt.Valid = false
+	return emit([]linkOutput{toLinkOutput(link)}, func() { fmt.Printf("Restored: %s\n", link.Url) })
+}
+
+func runTrashPurge(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	db := openTrashDB()
+	defer db.Close()
+
+	if len(args) == 0 {
+		n, err := db.Queries.PurgeDeletedLinks(ctx)
+		if err != nil {
+			return fmt.Errorf("purge failed: %w", err)
+		}
+		return emit(purgeOutput{Purged: n}, func() { fmt.Printf("Purged %d link(s).\n", n) })
+	}
+
+	link, err := lookupLink(ctx, db, args[0])
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && !link.DeletedAt.Valid) {
+		return emit(purgeOutput{}, func() { fmt.Println("No matching link in trash.") })
+	}
+	if err != nil {
+		return fmt.Errorf("lookup failed: %w", err)
+	}
+	if err := db.Queries.PurgeLink(ctx, link.ID); err != nil {
+		return fmt.Errorf("purge failed: %w", err)
+	}
+	return emit(purgeOutput{Purged: 1, URL: link.Url}, func() { fmt.Printf("Purged: %s\n", link.Url) })
+}
+
+// purgeOutput is the JSON form of 'lm trash purge'.
+type purgeOutput struct {
+	Purged int64  `json:"purged"`
+	URL    string `json:"url,omitempty"`
+}
@@ -0,0 +1,205 @@
+// Package api serves a read-only JSON view of the link library over HTTP.
+package api
+
+import (
+	"crypto/subtle"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"mccwk.com/lm/internal/database"
+	"mccwk.com/lm/internal/models"
+)
+
+// Server handles the API routes. A non-empty token requires every request
+// to carry "Authorization: Bearer <token>".
+type Server struct {
+	db    *database.Database
+	token string
+	mux   *http.ServeMux
+}
+
+func NewServer(db *database.Database, token string) *Server {
+	s := &Server{db: db, token: token, mux: http.NewServeMux()}
+	s.mux.HandleFunc("GET /api/links", s.handleListLinks)
+	s.mux.HandleFunc("GET /api/links/{id}", s.handleGetLink)
+	s.mux.HandleFunc("GET /api/tags", s.handleListTags)
+	s.mux.HandleFunc("GET /api/categories", s.handleListCategories)
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if s.token != "" {
+		got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(got), []byte(s.token)) != 1 {
+			writeError(w, http.StatusUnauthorized, "missing or invalid bearer token")
+			return
+		}
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+// link is the JSON shape of a link. Content is only filled in for single-link
+// responses.
+type link struct {
+	ID            int64      `json:"id"`
+	URL           string     `json:"url"`
+	Title         string     `json:"title"`
+	Summary       string     `json:"summary,omitempty"`
+	Content       string     `json:"content,omitempty"`
+	Status        string     `json:"status"`
+	Domain        string     `json:"domain"`
+	ContentLength int64      `json:"content_length"`
+	CreatedAt     time.Time  `json:"created_at"`
+	FetchedAt     *time.Time `json:"fetched_at,omitempty"`
+	Tags          []string   `json:"tags,omitempty"`
+	Categories    []string   `json:"categories,omitempty"`
+}
+
+func toLink(l models.Link) link {
+	out := link{
+		ID:            l.ID,
+		URL:           l.Url,
+		Title:         l.Title.String,
+		Summary:       l.Summary.String,
+		Status:        l.Status,
+		Domain:        l.Domain,
+		ContentLength: l.ContentLength,
+		CreatedAt:     l.CreatedAt,
+	}
+	if l.FetchedAt.Valid {
+		out.FetchedAt = &l.FetchedAt.Time
+	}
+	return out
+}
+
+// handleListLinks lists links newest first. Query parameters: q (search
+// text), status, domain, limit (default 50, max 500), and offset.
+func (s *Server) handleListLinks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	q := r.URL.Query()
+	query := strings.TrimSpace(q.Get("q"))
+	status := q.Get("status")
+	domain := strings.ToLower(q.Get("domain"))
+	limit := queryInt(q.Get("limit"), 50)
+	if limit <= 0 || limit > 500 {
+		limit = 50
+	}
+	offset := queryInt(q.Get("offset"), 0)
+
+	// Search text and filters are all applied in the query, so the limit
+	// and offset count matching links.
+	var pattern string
+	if query != "" {
+		pattern = "%" + query + "%"
+	}
+	links, err := s.db.Queries.ListLinksFiltered(ctx, models.ListLinksFilteredParams{
+		Domain:          domain,
+		Status:          status,
+		IncludeArchived: true,
+		Pattern:         pattern,
+		Limit:           limit,
+		Offset:          offset,
+	})
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+
+	out := []link{}
+	for _, l := range links {
+		out = append(out, toLink(l))
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+// handleGetLink returns one link with its content, tags, and categories.
+func (s *Server) handleGetLink(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid link id")
+		return
+	}
+
+	l, err := s.db.Queries.GetLink(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && l.DeletedAt.Valid) {
+		writeError(w, http.StatusNotFound, "link not found")
+		return
+	}
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+
+	out := toLink(l)
+	out.Content = l.Content.String
+	tags, err := s.db.Queries.GetTagsForLink(ctx, id)
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+	for _, t := range tags {
+		out.Tags = append(out.Tags, t.Name)
+	}
+	categories, err := s.db.Queries.GetCategoriesForLink(ctx, id)
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+	for _, c := range categories {
+		out.Categories = append(out.Categories, c.Name)
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+// handleListTags lists every tag with the number of links carrying it.
+func (s *Server) handleListTags(w http.ResponseWriter, r *http.Request) {
+	tags, err := s.db.Queries.CountLinksByTag(r.Context())
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, tags)
+}
+
+// handleListCategories lists every category with the number of links in it.
+func (s *Server) handleListCategories(w http.ResponseWriter, r *http.Request) {
+	categories, err := s.db.Queries.CountLinksByCategory(r.Context())
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, categories)
+}
+
+func (s *Server) serverError(w http.ResponseWriter, err error) {
+	slog.Error("api request failed", "error", err)
+	writeError(w, http.StatusInternalServerError, "internal error")
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		slog.Warn("failed to write response", "error", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
+
+// queryInt parses a query parameter, returning def if it is missing or invalid.
+func queryInt(v string, def int64) int64 {
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return def
+	}
+	return n
+}
@@ -0,0 +1,43 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"mccwk.com/lm/internal/models"
+)
+
+// ArchiveHTML stores html as the page linkID was last extracted from, for
+// 'lm reextract'. Empty html, as from fetchers that do not archive pages, is
+// ignored. Failures are logged rather than returned, since a missing archive
+// should never stop a link from being saved.
+func (db *Database) ArchiveHTML(ctx context.Context, linkID int64, html string) {
+	if html == "" {
+		return
+	}
+	err := db.Queries.UpsertLinkArchive(ctx, models.UpsertLinkArchiveParams{LinkID: linkID, Html: html})
+	if err != nil {
+		slog.Warn("failed to archive HTML", "link_id", linkID, "error", err)
+	}
+}
+
+// LinkLabels returns the names of the categories and tags linkID is filed
+// under.
+func (db *Database) LinkLabels(ctx context.Context, linkID int64) (categories, tags []string, err error) {
+	cats, err := db.Queries.GetCategoriesForLink(ctx, linkID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load categories: %w", err)
+	}
+	for _, c := range cats {
+		categories = append(categories, c.Name)
+	}
+	tagRows, err := db.Queries.GetTagsForLink(ctx, linkID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load tags: %w", err)
+	}
+	for _, t := range tagRows {
+		tags = append(tags, t.Name)
+	}
+	return categories, tags, nil
+}
@@ -0,0 +1,42 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"mccwk.com/lm/internal/models"
+)
+
+// ParseDefaultTags splits a category's comma-separated default_tags value
+// into lowercased, trimmed tag names.
+func ParseDefaultTags(raw string) []string {
+	var tags []string
+	for _, t := range strings.Split(raw, ",") {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if t != "" {
+			tags = append(tags, t)
+		}
+	}
+	return tags
+}
+
+// ApplyCategoryDefaultTags tags the link with each of the category's default
+// tags, creating tags that do not exist yet. Tags the link already has are
+// left alone.
+func (db *Database) ApplyCategoryDefaultTags(ctx context.Context, linkID int64, cat models.Category) error {
+	for _, name := range ParseDefaultTags(cat.DefaultTags) {
+		tag, err := db.Queries.GetTagByName(ctx, name)
+		if err != nil {
+			tag, err = db.Queries.CreateTag(ctx, name)
+			if err != nil {
+				return fmt.Errorf("failed to create tag %q: %w", name, err)
+			}
+		}
+		err = db.Queries.LinkTag(ctx, models.LinkTagParams{LinkID: linkID, TagID: tag.ID})
+		if err != nil && !strings.Contains(err.Error(), "UNIQUE constraint failed") {
+			return fmt.Errorf("failed to link tag %q: %w", name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"mccwk.com/lm/internal/models"
+)
+
+// DuplicateGroup is a set of links saved under different forms of the same
+// URL. Keep is the one that stays; Dupes are merged into it.
+type DuplicateGroup struct {
+	Keep  models.Link
+	Dupes []models.Link
+}
+
+// SummarySource returns the link in the group whose summary the kept link
+// ends up with: the longest one, preferring Keep's own on a tie.
+func (g DuplicateGroup) SummarySource() models.Link {
+	best := g.Keep
+	for _, l := range g.Dupes {
+		if len(l.Summary.String) > len(best.Summary.String) {
+			best = l
+		}
+	}
+	return best
+}
+
+// MergeDuplicates merges each group's duplicates into the link it keeps and
+// moves them to the trash. The kept link gains their tags, categories, tasks,
+// activities, and open history, and the best summary of the group. Either
+// every group is merged or, on error, none is.
+func (db *Database) MergeDuplicates(ctx context.Context, groups []DuplicateGroup) error {
+	tx, err := db.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	q := db.Queries.WithTx(tx)
+	for _, g := range groups {
+		if err := mergeGroup(ctx, q, g); err != nil {
+			return fmt.Errorf("failed to merge duplicates of link %d: %w", g.Keep.ID, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit merge: %w", err)
+	}
+	return nil
+}
+
+func mergeGroup(ctx context.Context, q *models.Queries, g DuplicateGroup) error {
+	keepID := g.Keep.ID
+	for _, dupe := range g.Dupes {
+		from := dupe.ID
+		if err := q.MergeLinkTags(ctx, models.MergeLinkTagsParams{ToID: keepID, FromID: from}); err != nil {
+			return err
+		}
+		if err := q.MergeLinkCategories(ctx, models.MergeLinkCategoriesParams{ToID: keepID, FromID: from}); err != nil {
+			return err
+		}
+		if err := q.MergeLinkTasks(ctx, models.MergeLinkTasksParams{ToID: keepID, FromID: from}); err != nil {
+			return err
+		}
+		if err := q.MergeLinkActivities(ctx, models.MergeLinkActivitiesParams{ToID: keepID, FromID: from}); err != nil {
+			return err
+		}
+		if err := q.MoveLinkOpens(ctx, models.MoveLinkOpensParams{ToID: keepID, FromID: from}); err != nil {
+			return err
+		}
+		if err := q.DeleteLink(ctx, from); err != nil {
+			return err
+		}
+	}
+
+	if best := g.SummarySource(); best.ID != keepID {
+		err := q.SetLinkSummary(ctx, models.SetLinkSummaryParams{Summary: best.Summary, ID: keepID})
+		if err != nil {
+			return err
+		}
+		err = q.SetLinkSummaryShort(ctx, models.SetLinkSummaryShortParams{SummaryShort: best.SummaryShort, ID: keepID})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
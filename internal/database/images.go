@@ -0,0 +1,40 @@
+package database
+
+import (
+	"context"
+	"log/slog"
+
+	"mccwk.com/lm/internal/models"
+)
+
+// Image is an image in a link's content, as stored in link_images.
+type Image struct {
+	URL string
+	Alt string
+}
+
+// SaveImages replaces the images recorded for linkID with images. A nil
+// slice, as from extractors that do not keep images, leaves them alone, so
+// turning KEEP_IMAGES off never loses images already kept. Failures are
+// logged rather than returned, like ArchiveHTML's.
+func (db *Database) SaveImages(ctx context.Context, linkID int64, images []Image) {
+	if images == nil {
+		return
+	}
+	if err := db.Queries.DeleteLinkImages(ctx, linkID); err != nil {
+		slog.Warn("failed to save images", "link_id", linkID, "error", err)
+		return
+	}
+	for i, img := range images {
+		err := db.Queries.AddLinkImage(ctx, models.AddLinkImageParams{
+			LinkID:   linkID,
+			Position: int64(i),
+			Url:      img.URL,
+			Alt:      img.Alt,
+		})
+		if err != nil {
+			slog.Warn("failed to save images", "link_id", linkID, "error", err)
+			return
+		}
+	}
+}
@@ -0,0 +1,9 @@
+-- +goose Up
+-- Soft delete: links moved to the trash keep their row until purged
+ALTER TABLE links ADD COLUMN deleted_at DATETIME;
+
+CREATE INDEX idx_links_deleted_at ON links(deleted_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_links_deleted_at;
+ALTER TABLE links DROP COLUMN deleted_at;
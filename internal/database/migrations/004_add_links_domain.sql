@@ -0,0 +1,19 @@
+-- +goose Up
+-- Host of each link's URL, for filtering and grouping by site
+ALTER TABLE links ADD COLUMN domain TEXT NOT NULL DEFAULT '';
+
+-- Backfill existing rows: strip the scheme, path, query, port, and "www."
+UPDATE links SET domain = substr(url, instr(url, '://') + 3) WHERE instr(url, '://') > 0;
+UPDATE links SET domain = substr(domain, 1, instr(domain, '/') - 1) WHERE instr(domain, '/') > 0;
+UPDATE links SET domain = substr(domain, 1, instr(domain, '?') - 1) WHERE instr(domain, '?') > 0;
+UPDATE links SET domain = substr(domain, 1, instr(domain, '#') - 1) WHERE instr(domain, '#') > 0;
+UPDATE links SET domain = substr(domain, instr(domain, '@') + 1) WHERE instr(domain, '@') > 0;
+UPDATE links SET domain = substr(domain, 1, instr(domain, ':') - 1) WHERE instr(domain, ':') > 0;
+UPDATE links SET domain = lower(domain);
+UPDATE links SET domain = substr(domain, 5) WHERE domain LIKE 'www.%';
+
+CREATE INDEX idx_links_domain ON links(domain);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_links_domain;
+ALTER TABLE links DROP COLUMN domain;
@@ -0,0 +1,6 @@
+-- +goose Up
+-- Comma-separated tags applied to a link whenever it is assigned the category
+ALTER TABLE categories ADD COLUMN default_tags TEXT NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE categories DROP COLUMN default_tags;
@@ -0,0 +1,21 @@
+-- +goose Up
+-- Word count of each link's extracted text, so the UI can show and sort by
+-- length without recounting on every render
+ALTER TABLE links ADD COLUMN content_length INTEGER NOT NULL DEFAULT 0;
+
+-- Backfill existing rows from the stored content. SQLite has no word
+-- splitting, so approximate it: fold newlines and tabs to spaces, collapse
+-- runs of spaces, and count the gaps. New saves store an exact count.
+UPDATE links
+SET content_length = (
+    SELECT CASE WHEN t = '' THEN 0 ELSE length(t) - length(replace(t, ' ', '')) + 1 END
+    FROM (
+        SELECT trim(replace(replace(replace(replace(replace(
+            replace(replace(content, char(10), ' '), char(9), ' '),
+            '        ', ' '), '    ', ' '), '  ', ' '), '  ', ' '), '  ', ' ')) AS t
+    )
+)
+WHERE content IS NOT NULL;
+
+-- +goose Down
+ALTER TABLE links DROP COLUMN content_length;
@@ -0,0 +1,7 @@
+-- +goose Up
+-- Whether a task's link has been opened from the task, so tasks can show
+-- reading progress
+ALTER TABLE link_tasks ADD COLUMN opened BOOLEAN NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE link_tasks DROP COLUMN opened;
@@ -0,0 +1,7 @@
+-- +goose Up
+-- Links to pages that change often (dashboards, changelogs) can be marked to
+-- refetch in the background whenever they are opened or viewed
+ALTER TABLE links ADD COLUMN auto_refresh BOOLEAN NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE links DROP COLUMN auto_refresh;
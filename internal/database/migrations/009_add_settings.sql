@@ -0,0 +1,10 @@
+-- +goose Up
+-- Small key/value store for preferences set from the TUI, such as the dense
+-- list view
+CREATE TABLE settings (
+    key TEXT PRIMARY KEY,
+    value TEXT NOT NULL
+);
+
+-- +goose Down
+DROP TABLE settings;
@@ -0,0 +1,17 @@
+-- +goose Up
+-- One row per LLM call, so spend can be reported across sessions
+CREATE TABLE llm_usage (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    model TEXT NOT NULL,
+    operation TEXT NOT NULL, -- summarize, short_summary, or suggest_metadata (database.Op*)
+    input_tokens INTEGER NOT NULL,
+    output_tokens INTEGER NOT NULL,
+    cost_usd REAL NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_llm_usage_created_at ON llm_usage(created_at);
+
+-- +goose Down
+DROP INDEX idx_llm_usage_created_at;
+DROP TABLE llm_usage;
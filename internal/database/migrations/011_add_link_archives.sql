@@ -0,0 +1,12 @@
+-- +goose Up
+-- The HTML each link was last extracted from, kept when ARCHIVE_HTML is set
+-- so 'lm reextract' can rerun extraction without fetching again
+CREATE TABLE link_archives (
+    link_id INTEGER PRIMARY KEY,
+    html TEXT NOT NULL,
+    archived_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (link_id) REFERENCES links(id) ON DELETE CASCADE
+);
+
+-- +goose Down
+DROP TABLE link_archives;
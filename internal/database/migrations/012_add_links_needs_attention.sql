@@ -0,0 +1,7 @@
+-- +goose Up
+-- Links can be flagged by hand as broken or in need of a re-save (a bad
+-- extraction, say), giving a triage queue separate from their status
+ALTER TABLE links ADD COLUMN needs_attention BOOLEAN NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE links DROP COLUMN needs_attention;
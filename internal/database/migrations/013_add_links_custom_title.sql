@@ -0,0 +1,7 @@
+-- +goose Up
+-- Set when a link's title was edited by hand, so refetches keep it instead
+-- of the page's own <title>
+ALTER TABLE links ADD COLUMN custom_title BOOLEAN NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE links DROP COLUMN custom_title;
@@ -0,0 +1,14 @@
+-- +goose Up
+-- Position of a link within its task, so links can be put in reading order.
+-- Existing links keep their newest-first order.
+ALTER TABLE link_tasks ADD COLUMN sort_order INTEGER NOT NULL DEFAULT 0;
+UPDATE link_tasks
+SET sort_order = (
+    SELECT COUNT(*) FROM link_tasks other
+    JOIN links ol ON ol.id = other.link_id
+    WHERE other.task_id = link_tasks.task_id
+      AND ol.created_at > (SELECT created_at FROM links WHERE id = link_tasks.link_id)
+);
+
+-- +goose Down
+ALTER TABLE link_tasks DROP COLUMN sort_order;
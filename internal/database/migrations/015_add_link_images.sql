@@ -0,0 +1,14 @@
+-- +goose Up
+-- Images found in each link's content, kept when KEEP_IMAGES is set so
+-- diagrams and infographics can be opened or downloaded later
+CREATE TABLE link_images (
+    link_id INTEGER NOT NULL,
+    position INTEGER NOT NULL,
+    url TEXT NOT NULL,
+    alt TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY (link_id, position),
+    FOREIGN KEY (link_id) REFERENCES links(id) ON DELETE CASCADE
+);
+
+-- +goose Down
+DROP TABLE link_images;
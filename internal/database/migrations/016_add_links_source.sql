@@ -0,0 +1,7 @@
+-- +goose Up
+-- Where a link came from (cli, manual, or the import format), so links from
+-- one source can be reviewed together. Links saved before this are ''.
+ALTER TABLE links ADD COLUMN source TEXT NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE links DROP COLUMN source;
@@ -0,0 +1,15 @@
+-- +goose Up
+-- One row each time a link is opened in the browser, for the recently
+-- opened history
+CREATE TABLE link_opens (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    link_id INTEGER NOT NULL,
+    opened_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (link_id) REFERENCES links(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_link_opens_link_id ON link_opens(link_id);
+
+-- +goose Down
+DROP INDEX idx_link_opens_link_id;
+DROP TABLE link_opens;
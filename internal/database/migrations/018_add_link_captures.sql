@@ -0,0 +1,11 @@
+-- +goose Up
+-- Links saved with 'lm add --capture' that 'lm process' has yet to fetch
+-- and summarise
+CREATE TABLE link_captures (
+    link_id INTEGER PRIMARY KEY,
+    captured_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (link_id) REFERENCES links(id) ON DELETE CASCADE
+);
+
+-- +goose Down
+DROP TABLE link_captures;
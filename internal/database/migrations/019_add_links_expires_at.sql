@@ -0,0 +1,7 @@
+-- +goose Up
+-- When a time-sensitive link (an event, a sale) stops being useful. Expired
+-- links are flagged and hidden, not deleted. NULL for links that never expire.
+ALTER TABLE links ADD COLUMN expires_at DATETIME;
+
+-- +goose Down
+ALTER TABLE links DROP COLUMN expires_at;
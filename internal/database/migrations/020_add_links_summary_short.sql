@@ -0,0 +1,7 @@
+-- +goose Up
+-- A one-line summary for scanning lists; summary stays the longer one shown
+-- in detail views. NULL until one is generated or written.
+ALTER TABLE links ADD COLUMN summary_short TEXT;
+
+-- +goose Down
+ALTER TABLE links DROP COLUMN summary_short;
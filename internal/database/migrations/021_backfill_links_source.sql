@@ -0,0 +1,8 @@
+-- +goose Up
+-- Links saved before 016 recorded no source. Count them as manual, like links
+-- from the TUI's Add Link form, so that every link has a source to filter on.
+UPDATE links SET source = 'manual' WHERE source = '';
+
+-- +goose Down
+-- Backfilled links cannot be told apart from ones saved as manual, so they
+-- keep their source.
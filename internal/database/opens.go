@@ -0,0 +1,16 @@
+package database
+
+import (
+	"context"
+	"log/slog"
+)
+
+// RecordOpen notes that linkID was just opened in the browser, for the
+// recently opened history. Failures are logged rather than returned: the
+// page is already open, and a gap in the history is no reason to report an
+// error.
+func (db *Database) RecordOpen(ctx context.Context, linkID int64) {
+	if err := db.Queries.RecordLinkOpen(ctx, linkID); err != nil {
+		slog.Warn("failed to record link open", "link_id", linkID, "error", err)
+	}
+}
@@ -1,6 +1,6 @@
 -- name: CreateLink :one
-INSERT INTO links (url, title, content, summary, status)
-VALUES (?, ?, ?, ?, ?)
+INSERT INTO links (url, title, content, summary, status, domain, content_length, source)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)
 RETURNING *;
 
 -- name: GetLink :one
@@ -11,27 +11,164 @@ WHERE id = ?;
 SELECT * FROM links
 WHERE url = ?;
 
+-- name: GetRandomLink :one
+SELECT * FROM links
+WHERE deleted_at IS NULL
+ORDER BY RANDOM()
+LIMIT 1;
+
+-- name: GetRandomLinkByStatus :one
+SELECT * FROM links
+WHERE status = ? AND deleted_at IS NULL
+ORDER BY RANDOM()
+LIMIT 1;
+
 -- name: ListLinks :many
 SELECT * FROM links
+WHERE deleted_at IS NULL
 ORDER BY created_at DESC
 LIMIT ? OFFSET ?;
 
+-- name: ListLinksAddedSince :many
+SELECT * FROM links
+WHERE deleted_at IS NULL
+  AND created_at >= datetime('now', sqlc.arg(age))
+ORDER BY created_at DESC;
+
+-- name: ListLinksAfterID :many
+SELECT * FROM links
+WHERE id > ? AND deleted_at IS NULL
+ORDER BY id;
+
 -- name: ListLinksByStatus :many
 SELECT * FROM links
-WHERE status = ?
+WHERE status = ? AND deleted_at IS NULL
+ORDER BY created_at DESC
+LIMIT ? OFFSET ?;
+
+-- name: ListLinksFiltered :many
+SELECT * FROM links
+WHERE deleted_at IS NULL
+  AND (sqlc.arg(domain) = '' OR domain = sqlc.arg(domain))
+  AND (sqlc.arg(source) = '' OR source = sqlc.arg(source) OR source LIKE sqlc.arg(source) || ':%')
+  AND (sqlc.arg(status) = '' OR status = sqlc.arg(status))
+  AND (sqlc.arg(include_archived) OR sqlc.arg(status) != '' OR status != 'archived')
+  AND content_length >= sqlc.arg(min_words)
+  AND (sqlc.arg(max_words) = 0 OR content_length <= sqlc.arg(max_words))
+  AND (NOT sqlc.arg(needs_attention) OR needs_attention)
+  AND (sqlc.arg(pattern) = '' OR url LIKE sqlc.arg(pattern) OR title LIKE sqlc.arg(pattern)
+       OR content LIKE sqlc.arg(pattern) OR summary LIKE sqlc.arg(pattern))
+ORDER BY created_at DESC
+LIMIT sqlc.arg(limit) OFFSET sqlc.arg(offset);
+
+-- name: ListUnarchivedLinks :many
+SELECT * FROM links
+WHERE deleted_at IS NULL AND status != 'archived'
 ORDER BY created_at DESC
 LIMIT ? OFFSET ?;
 
+-- name: ListStaleLinks :many
+SELECT * FROM links
+WHERE deleted_at IS NULL
+  AND (fetched_at IS NULL OR fetched_at < datetime('now', sqlc.arg(age)))
+ORDER BY fetched_at
+LIMIT ?;
+
+-- name: CountLinksByStatus :many
+SELECT status, COUNT(*) AS count FROM links
+WHERE deleted_at IS NULL
+GROUP BY status
+ORDER BY status;
+
+-- name: CountLinksByDomain :many
+SELECT domain, COUNT(*) AS count FROM links
+WHERE deleted_at IS NULL AND domain != ''
+GROUP BY domain
+ORDER BY count DESC, domain
+LIMIT ?;
+
+-- name: CountLinksByTag :many
+SELECT t.id, t.name, COUNT(l.id) AS count FROM tags t
+LEFT JOIN link_tags lt ON t.id = lt.tag_id
+LEFT JOIN links l ON l.id = lt.link_id AND l.deleted_at IS NULL
+GROUP BY t.id, t.name
+ORDER BY t.name;
+
+-- name: CountLinksByCategory :many
+SELECT c.id, c.name, COUNT(l.id) AS count FROM categories c
+LEFT JOIN link_categories lc ON c.id = lc.category_id
+LEFT JOIN links l ON l.id = lc.link_id AND l.deleted_at IS NULL
+GROUP BY c.id, c.name
+ORDER BY c.name;
+
 -- name: UpdateLink :one
 UPDATE links
 SET title = ?,
     content = ?,
     summary = ?,
     status = ?,
+    content_length = ?,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = ?
 RETURNING *;
 
+-- name: UpdateLinkStatus :exec
+UPDATE links
+SET status = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = ?;
+
+-- name: SetLinkAutoRefresh :exec
+UPDATE links
+SET auto_refresh = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = ?;
+
+-- name: SetLinkContent :exec
+UPDATE links
+SET content = ?,
+    content_length = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = ?;
+
+-- name: SetLinkNeedsAttention :exec
+UPDATE links
+SET needs_attention = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = ?;
+
+-- name: SetLinkExpiresAt :exec
+UPDATE links
+SET expires_at = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = ?;
+
+-- name: SetLinkSummary :exec
+UPDATE links
+SET summary = ?,
+    summarized_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = ?;
+
+-- name: SetLinkSummaryShort :exec
+UPDATE links
+SET summary_short = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = ?;
+
+-- name: SetLinkTitle :exec
+UPDATE links
+SET title = ?,
+    custom_title = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = ?;
+
+-- name: UpdateLinkCreatedAt :exec
+UPDATE links
+SET created_at = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = ?;
+
 -- name: UpdateLinkFetchedAt :exec
 UPDATE links
 SET fetched_at = CURRENT_TIMESTAMP,
@@ -45,16 +182,47 @@ SET summarized_at = CURRENT_TIMESTAMP,
 WHERE id = ?;
 
 -- name: DeleteLink :exec
-DELETE FROM links
+UPDATE links
+SET deleted_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = ?;
+
+-- name: RestoreLink :exec
+UPDATE links
+SET deleted_at = NULL,
+    updated_at = CURRENT_TIMESTAMP
 WHERE id = ?;
 
+-- name: ListDeletedLinks :many
+SELECT * FROM links
+WHERE deleted_at IS NOT NULL
+ORDER BY deleted_at DESC;
+
+-- name: ListExpiringLinks :many
+SELECT * FROM links
+WHERE expires_at IS NOT NULL AND deleted_at IS NULL
+ORDER BY expires_at;
+
+-- name: ListLinkContents :many
+SELECT content FROM links
+WHERE deleted_at IS NULL AND content IS NOT NULL;
+
+-- name: PurgeLink :exec
+DELETE FROM links
+WHERE id = ? AND deleted_at IS NOT NULL;
+
+-- name: PurgeDeletedLinks :execrows
+DELETE FROM links
+WHERE deleted_at IS NOT NULL;
+
 -- name: SearchLinks :many
 SELECT * FROM links
-WHERE 
+WHERE deleted_at IS NULL AND (
     url LIKE ? OR
     title LIKE ? OR
     content LIKE ? OR
     summary LIKE ?
+)
 ORDER BY created_at DESC
 LIMIT ? OFFSET ?;
 
@@ -96,8 +264,8 @@ DELETE FROM tasks
 WHERE id = ?;
 
 -- name: CreateCategory :one
-INSERT INTO categories (name, description)
-VALUES (?, ?)
+INSERT INTO categories (name, description, default_tags)
+VALUES (?, ?, ?)
 RETURNING *;
 
 -- name: GetCategory :one
@@ -112,6 +280,14 @@ WHERE name = ?;
 SELECT * FROM categories
 ORDER BY name;
 
+-- name: UpdateCategory :one
+UPDATE categories
+SET name = ?,
+    description = ?,
+    default_tags = ?
+WHERE id = ?
+RETURNING *;
+
 -- name: DeleteCategory :exec
 DELETE FROM categories
 WHERE id = ?;
@@ -138,18 +314,38 @@ DELETE FROM tags
 WHERE id = ?;
 
 -- name: LinkTask :exec
-INSERT INTO link_tasks (link_id, task_id)
-VALUES (?, ?);
+INSERT INTO link_tasks (link_id, task_id, sort_order)
+VALUES (sqlc.arg(link_id), sqlc.arg(task_id), (
+    SELECT COALESCE(MIN(sort_order), 1) - 1 FROM link_tasks WHERE task_id = sqlc.arg(task_id)
+));
 
 -- name: UnlinkTask :exec
 DELETE FROM link_tasks
 WHERE link_id = ? AND task_id = ?;
 
+-- name: UpdateTaskLinkOrder :exec
+UPDATE link_tasks
+SET sort_order = ?
+WHERE link_id = ? AND task_id = ?;
+
+-- name: MarkTaskLinksOpened :exec
+UPDATE link_tasks
+SET opened = 1
+WHERE task_id = ?;
+
+-- name: CountTaskLinksOpened :many
+SELECT lt.task_id,
+       COUNT(*) AS total,
+       COUNT(CASE WHEN lt.opened THEN 1 END) AS opened
+FROM link_tasks lt
+JOIN links l ON l.id = lt.link_id AND l.deleted_at IS NULL
+GROUP BY lt.task_id;
+
 -- name: GetLinksForTask :many
 SELECT l.* FROM links l
 JOIN link_tasks lt ON l.id = lt.link_id
-WHERE lt.task_id = ?
-ORDER BY l.created_at DESC;
+WHERE lt.task_id = ? AND l.deleted_at IS NULL
+ORDER BY lt.sort_order, l.created_at DESC;
 
 -- name: GetTasksForLink :many
 SELECT t.* FROM tasks t
@@ -168,7 +364,7 @@ WHERE link_id = ? AND category_id = ?;
 -- name: GetLinksForCategory :many
 SELECT l.* FROM links l
 JOIN link_categories lc ON l.id = lc.link_id
-WHERE lc.category_id = ?
+WHERE lc.category_id = ? AND l.deleted_at IS NULL
 ORDER BY l.created_at DESC;
 
 -- name: GetCategoriesForLink :many
@@ -188,15 +384,51 @@ WHERE link_id = ? AND tag_id = ?;
 -- name: GetLinksForTag :many
 SELECT l.* FROM links l
 JOIN link_tags lt ON l.id = lt.link_id
-WHERE lt.tag_id = ?
+WHERE lt.tag_id = ? AND l.deleted_at IS NULL
+ORDER BY l.created_at DESC;
+
+-- name: GetLinksForTagPair :many
+SELECT l.* FROM links l
+JOIN link_tags a ON l.id = a.link_id AND a.tag_id = sqlc.arg(tag_id)
+JOIN link_tags b ON l.id = b.link_id AND b.tag_id = sqlc.arg(other_tag_id)
+WHERE l.deleted_at IS NULL
 ORDER BY l.created_at DESC;
 
+-- name: ListRelatedTags :many
+SELECT t.id, t.name, COUNT(*) AS count FROM link_tags a
+JOIN link_tags b ON b.link_id = a.link_id AND b.tag_id != a.tag_id
+JOIN tags t ON t.id = b.tag_id
+JOIN links l ON l.id = a.link_id AND l.deleted_at IS NULL
+WHERE a.tag_id = ?
+GROUP BY t.id, t.name
+ORDER BY count DESC, t.name
+LIMIT ?;
+
 -- name: GetTagsForLink :many
 SELECT t.* FROM tags t
 JOIN link_tags lt ON t.id = lt.tag_id
 WHERE lt.link_id = ?
 ORDER BY t.name;
 
+-- name: ListLinkTagNames :many
+SELECT lt.link_id, t.name FROM link_tags lt
+JOIN tags t ON t.id = lt.tag_id
+ORDER BY lt.link_id, t.name;
+
+-- name: UpdateLinksStatusForCategory :execrows
+UPDATE links
+SET status = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id IN (SELECT link_id FROM link_categories WHERE category_id = ?)
+  AND deleted_at IS NULL;
+
+-- name: UpdateLinksStatusForTag :execrows
+UPDATE links
+SET status = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id IN (SELECT link_id FROM link_tags WHERE tag_id = ?)
+  AND deleted_at IS NULL;
+
 -- Activities
 -- name: CreateActivity :one
 INSERT INTO activities (name, description)
@@ -230,7 +462,7 @@ DELETE FROM link_activities WHERE link_id = ? AND activity_id = ?;
 -- name: GetLinksForActivity :many
 SELECT l.* FROM links l
 JOIN link_activities la ON l.id = la.link_id
-WHERE la.activity_id = ?
+WHERE la.activity_id = ? AND l.deleted_at IS NULL
 ORDER BY l.created_at DESC;
 
 -- name: GetActivitiesForLink :many
@@ -238,3 +470,127 @@ SELECT a.* FROM activities a
 JOIN link_activities la ON a.id = la.activity_id
 WHERE la.link_id = ?
 ORDER BY a.created_at DESC;
+
+-- Settings
+
+-- name: GetSetting :one
+SELECT value FROM settings
+WHERE key = ?;
+
+-- name: SetSetting :exec
+INSERT INTO settings (key, value)
+VALUES (?, ?)
+ON CONFLICT (key) DO UPDATE SET value = excluded.value;
+
+-- LLM usage
+
+-- name: CreateLLMUsage :exec
+INSERT INTO llm_usage (model, operation, input_tokens, output_tokens, cost_usd)
+VALUES (?, ?, ?, ?, ?);
+
+-- name: SumLLMUsageSince :one
+SELECT COUNT(*) AS calls,
+       CAST(COALESCE(SUM(input_tokens), 0) AS INTEGER) AS input_tokens,
+       CAST(COALESCE(SUM(output_tokens), 0) AS INTEGER) AS output_tokens,
+       CAST(COALESCE(SUM(cost_usd), 0) AS REAL) AS cost_usd
+FROM llm_usage
+WHERE created_at >= datetime('now', 'localtime', sqlc.arg(start), 'utc');
+
+-- name: ListLLMUsageByMonth :many
+SELECT CAST(strftime('%Y-%m', created_at, 'localtime') AS TEXT) AS month,
+       COUNT(*) AS calls,
+       CAST(COALESCE(SUM(input_tokens), 0) AS INTEGER) AS input_tokens,
+       CAST(COALESCE(SUM(output_tokens), 0) AS INTEGER) AS output_tokens,
+       CAST(COALESCE(SUM(cost_usd), 0) AS REAL) AS cost_usd
+FROM llm_usage
+GROUP BY month
+ORDER BY month DESC;
+
+-- Link archives
+
+-- name: UpsertLinkArchive :exec
+INSERT INTO link_archives (link_id, html)
+VALUES (?, ?)
+ON CONFLICT (link_id) DO UPDATE SET html = excluded.html, archived_at = CURRENT_TIMESTAMP;
+
+-- name: GetLinkArchive :one
+SELECT html FROM link_archives
+WHERE link_id = ?;
+
+-- name: AddLinkImage :exec
+INSERT INTO link_images (link_id, position, url, alt)
+VALUES (?, ?, ?, ?);
+
+-- name: DeleteLinkImages :exec
+DELETE FROM link_images
+WHERE link_id = ?;
+
+-- name: GetLinkImages :many
+SELECT * FROM link_images
+WHERE link_id = ?
+ORDER BY position;
+
+-- name: ListArchivedLinks :many
+SELECT l.* FROM links l
+JOIN link_archives la ON l.id = la.link_id
+WHERE l.deleted_at IS NULL
+ORDER BY l.id;
+
+-- Link opens
+
+-- name: RecordLinkOpen :exec
+INSERT INTO link_opens (link_id)
+VALUES (?);
+
+-- name: ListRecentlyOpenedLinks :many
+SELECT l.* FROM links l
+JOIN (
+    SELECT link_id, MAX(id) AS last_open FROM link_opens
+    GROUP BY link_id
+) o ON l.id = o.link_id
+WHERE l.deleted_at IS NULL
+ORDER BY o.last_open DESC
+LIMIT ?;
+
+-- Link captures
+
+-- name: AddLinkCapture :exec
+INSERT OR IGNORE INTO link_captures (link_id)
+VALUES (?);
+
+-- name: DeleteLinkCapture :exec
+DELETE FROM link_captures
+WHERE link_id = ?;
+
+-- name: ListCapturedLinks :many
+SELECT l.* FROM links l
+JOIN link_captures lc ON l.id = lc.link_id
+WHERE l.deleted_at IS NULL
+ORDER BY lc.captured_at, l.id;
+
+-- Merging duplicate links
+
+-- name: MergeLinkTags :exec
+INSERT OR IGNORE INTO link_tags (link_id, tag_id, created_at)
+SELECT sqlc.arg(to_id), tag_id, created_at FROM link_tags
+WHERE link_id = sqlc.arg(from_id);
+
+-- name: MergeLinkCategories :exec
+INSERT OR IGNORE INTO link_categories (link_id, category_id, created_at)
+SELECT sqlc.arg(to_id), category_id, created_at FROM link_categories
+WHERE link_id = sqlc.arg(from_id);
+
+-- name: MergeLinkTasks :exec
+INSERT OR IGNORE INTO link_tasks (link_id, task_id, created_at, opened, sort_order)
+SELECT sqlc.arg(to_id), task_id, created_at, opened, sort_order FROM link_tasks
+WHERE link_id = sqlc.arg(from_id);
+
+-- name: MergeLinkActivities :exec
+INSERT OR IGNORE INTO link_activities (link_id, activity_id, created_at)
+SELECT sqlc.arg(to_id), activity_id, created_at FROM link_activities
+WHERE link_id = sqlc.arg(from_id);
+
+-- name: MoveLinkOpens :exec
+UPDATE link_opens
+SET link_id = sqlc.arg(to_id)
+WHERE link_id = sqlc.arg(from_id);
@@ -0,0 +1,35 @@
+package database
+
+import (
+	"context"
+	"log/slog"
+
+	"mccwk.com/lm/internal/models"
+)
+
+// LLM operations recorded in llm_usage.
+const (
+	OpSummarize       = "summarize"
+	OpShortSummary    = "short_summary"
+	OpSuggestMetadata = "suggest_metadata"
+)
+
+// RecordLLMUsage stores one LLM call for 'lm stats --llm'. Calls that used no
+// tokens, such as ones that failed before reaching the API, are not recorded.
+// Failures are logged rather than returned, since losing a usage row should
+// never stop a link from being saved.
+func (db *Database) RecordLLMUsage(ctx context.Context, model, operation string, inputTokens, outputTokens int, costUSD float64) {
+	if inputTokens+outputTokens == 0 {
+		return
+	}
+	err := db.Queries.CreateLLMUsage(ctx, models.CreateLLMUsageParams{
+		Model:        model,
+		Operation:    operation,
+		InputTokens:  int64(inputTokens),
+		OutputTokens: int64(outputTokens),
+		CostUsd:      costUSD,
+	})
+	if err != nil {
+		slog.Warn("failed to record LLM usage", "operation", operation, "error", err)
+	}
+}
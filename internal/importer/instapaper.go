@@ -0,0 +1,84 @@
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Instapaper parses an Instapaper export, either the HTML or the CSV one,
+// detected from the content.
+func Instapaper(r io.Reader) ([]Item, error) {
+	br := bufio.NewReader(r)
+	head, err := br.Peek(512)
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		return nil, fmt.Errorf("failed to read export: %w", err)
+	}
+	if bytes.HasPrefix(bytes.TrimSpace(head), []byte("<")) {
+		return instapaperHTML(br)
+	}
+	return instapaperCSV(br)
+}
+
+// instapaperHTML parses the HTML export: one <ol> of links per folder,
+// headed by the folder's name in an <h1>.
+func instapaperHTML(r io.Reader) ([]Item, error) {
+	doc, err := goquery.NewDocumentFromReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse HTML export: %w", err)
+	}
+
+	var items []Item
+	doc.Find("ol").Each(func(_ int, ol *goquery.Selection) {
+		folder := strings.TrimSpace(ol.PrevAllFiltered("h1").First().Text())
+		ol.Find("a[href]").Each(func(_ int, a *goquery.Selection) {
+			href, _ := a.Attr("href")
+			item := Item{
+				URL:   strings.TrimSpace(href),
+				Title: strings.TrimSpace(a.Text()),
+			}
+			instapaperFolder(&item, folder)
+			items = append(items, item)
+		})
+	})
+	return items, nil
+}
+
+// instapaperCSV parses the CSV export, whose header is URL, Title,
+// Selection, Folder, and Timestamp (Unix seconds).
+func instapaperCSV(r io.Reader) ([]Item, error) {
+	return readCSV(r, func(field func(name string) string) Item {
+		item := Item{
+			URL:       field("url"),
+			Title:     field("title"),
+			Selection: field("selection"),
+		}
+		if secs, err := strconv.ParseInt(field("timestamp"), 10, 64); err == nil && secs > 0 {
+			item.Added = time.Unix(secs, 0)
+		}
+		instapaperFolder(&item, field("folder"))
+		return item
+	})
+}
+
+// instapaperFolder files item by its Instapaper folder. The built-in
+// Unread, Archive, and Starred folders set its read state; any other folder
+// becomes its category. Nested folders are exported as "Parent/Child", which
+// is kept as the category name.
+func instapaperFolder(item *Item, folder string) {
+	switch strings.ToLower(folder) {
+	case "", "unread":
+	case "archive":
+		item.Read = true
+	case "starred":
+		item.Starred = true
+	default:
+		item.Category = folder
+	}
+}
@@ -0,0 +1,17 @@
+package importer
+
+import "io"
+
+// LM parses the CSV written by 'lm export', so one library can be synced
+// into another. Tags are separated by ";", and links exported as archived
+// are marked read. Summaries and categories are not imported.
+func LM(r io.Reader) ([]Item, error) {
+	return readCSV(r, func(field func(name string) string) Item {
+		return Item{
+			URL:   field("url"),
+			Title: field("title"),
+			Tags:  splitTags(field("tags"), ";"),
+			Read:  field("status") == "archived",
+		}
+	})
+}
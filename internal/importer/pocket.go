@@ -0,0 +1,131 @@
+// Package importer parses link lists exported from other tools.
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Item is one link read from an import file.
+type Item struct {
+	URL      string
+	Title    string
+	Tags     []string
+	Read     bool   // marked read/archived in the source
+	Starred  bool   // starred/favourited in the source
+	Category string // the folder it was filed in, if the source has them
+	// Selection is text the user highlighted when saving the link, kept as
+	// its content.
+	Selection string
+	Added     time.Time // when it was saved in the source, if known
+}
+
+// Pocket parses a Pocket export, either the HTML ril_export.html or the CSV
+// export, detected from the content.
+func Pocket(r io.Reader) ([]Item, error) {
+	br := bufio.NewReader(r)
+	head, err := br.Peek(512)
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		return nil, fmt.Errorf("failed to read export: %w", err)
+	}
+	if bytes.HasPrefix(bytes.TrimSpace(head), []byte("<")) {
+		return pocketHTML(br)
+	}
+	return pocketCSV(br)
+}
+
+// pocketHTML parses ril_export.html: one <ul> of <a href tags="a,b"> per
+// section, headed by <h1>Unread</h1> or <h1>Read Archive</h1>.
+func pocketHTML(r io.Reader) ([]Item, error) {
+	doc, err := goquery.NewDocumentFromReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse HTML export: %w", err)
+	}
+
+	var items []Item
+	doc.Find("ul").Each(func(_ int, ul *goquery.Selection) {
+		heading := strings.ToLower(ul.PrevAllFiltered("h1").First().Text())
+		read := strings.Contains(heading, "read archive")
+		ul.Find("a[href]").Each(func(_ int, a *goquery.Selection) {
+			href, _ := a.Attr("href")
+			tags, _ := a.Attr("tags")
+			items = append(items, Item{
+				URL:   strings.TrimSpace(href),
+				Title: strings.TrimSpace(a.Text()),
+				Tags:  splitTags(tags, ","),
+				Read:  read,
+			})
+		})
+	})
+	return items, nil
+}
+
+// pocketCSV parses the CSV export, whose header includes title, url, tags
+// (separated by "|"), and status ("unread" or "archive").
+func pocketCSV(r io.Reader) ([]Item, error) {
+	return readCSV(r, func(field func(name string) string) Item {
+		return Item{
+			URL:   field("url"),
+			Title: field("title"),
+			Tags:  splitTags(field("tags"), "|"),
+			Read:  field("status") == "archive",
+		}
+	})
+}
+
+// readCSV reads a CSV export with a header row naming its columns, which
+// must include url, building an item from each row. field returns a row's
+// value for a column, or "" if the export has no such column.
+func readCSV(r io.Reader, item func(field func(name string) string) Item) ([]Item, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+	col := map[string]int{}
+	for i, name := range header {
+		col[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := col["url"]; !ok {
+		return nil, fmt.Errorf("CSV export has no url column")
+	}
+
+	var items []Item
+	for {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CSV export: %w", err)
+		}
+		items = append(items, item(func(name string) string {
+			i, ok := col[name]
+			if !ok || i >= len(rec) {
+				return ""
+			}
+			return strings.TrimSpace(rec[i])
+		}))
+	}
+	return items, nil
+}
+
+// splitTags splits raw on sep, lowercasing and dropping empty tags.
+func splitTags(raw, sep string) []string {
+	var out []string
+	for _, t := range strings.Split(raw, sep) {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if t != "" {
+			out = append(out, t)
+		}
+	}
+	return out
+}
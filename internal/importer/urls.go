@@ -0,0 +1,34 @@
+package importer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// URLList parses a plain text list with one URL per line, optionally followed
+// by inline tags: "https://example.com #go #tools". Blank lines and lines
+// starting with "#" are skipped.
+func URLList(r io.Reader) ([]Item, error) {
+	var items []Item
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		item := Item{URL: fields[0]}
+		for _, f := range fields[1:] {
+			if tag, ok := strings.CutPrefix(f, "#"); ok {
+				item.Tags = append(item.Tags, splitTags(tag, ",")...)
+			}
+		}
+		items = append(items, item)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read URL list: %w", err)
+	}
+	return items, nil
+}
@@ -7,6 +7,9 @@ import (
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 )
 
 const DefaultMaxEntries = 500
@@ -83,28 +86,80 @@ func (s *MemorySink) Entries() []Entry {
 }
 
 // Render formats all entries as a newline-separated string suitable for display
-// in a TUI viewport. Lines longer than width-2 are truncated.
-func (s *MemorySink) Render(width int) string {
+// in a TUI viewport width columns wide. Entries are coloured by level, and
+// messages too long for a line wrap onto continuation lines indented under
+// the message, so nothing is cut off. With relative set, times are shown as
+// how long ago each entry was logged, e.g. "2s ago", instead of the clock
+// time.
+func (s *MemorySink) Render(width int, relative bool) string {
 	entries := s.Entries()
 	if len(entries) == 0 {
 		return "(no log entries yet)"
 	}
+	timeStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("243"))
+	now := time.Now()
 	var b strings.Builder
 	for _, e := range entries {
-		line := fmt.Sprintf("%s [%s] %s",
-			e.Timestamp.Format("15:04:05"),
-			levelLabel(e.Level),
-			e.Message,
-		)
-		if width > 10 && len(line) > width-2 {
-			line = line[:width-5] + "..."
+		stamp := e.Timestamp.Format("15:04:05")
+		if relative {
+			stamp = fmt.Sprintf("%8s", relativeTime(e.Timestamp, now))
+		}
+		label := "[" + levelLabel(e.Level) + "]"
+		indent := len(stamp) + len(label) + 2
+
+		// Too narrow to wrap usefully: let the viewport clip instead.
+		lines := []string{e.Message}
+		if limit := width - 2 - indent; limit >= 20 {
+			lines = strings.Split(ansi.Wrap(e.Message, limit, ""), "\n")
+		}
+
+		style := levelStyle(e.Level)
+		b.WriteString(timeStyle.Render(stamp) + " " + style.Bold(true).Render(label) + " ")
+		for i, line := range lines {
+			if i > 0 {
+				b.WriteString("\n" + strings.Repeat(" ", indent))
+			}
+			b.WriteString(style.Render(line))
 		}
-		b.WriteString(line)
 		b.WriteString("\n")
 	}
 	return b.String()
 }
 
+// relativeTime describes how long before now t was, in the largest whole
+// unit, e.g. "45s ago" or "3h ago".
+func relativeTime(t, now time.Time) string {
+	d := now.Sub(t)
+	switch {
+	case d < time.Second:
+		return "now"
+	case d < time.Minute:
+		return fmt.Sprintf("%ds ago", int(d/time.Second))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d/time.Minute))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d/time.Hour))
+	default:
+		return fmt.Sprintf("%dd ago", int(d/(24*time.Hour)))
+	}
+}
+
+// levelStyle colours an entry of level l: debug dim, warnings yellow, and
+// errors red.
+func levelStyle(l slog.Level) lipgloss.Style {
+	style := lipgloss.NewStyle()
+	switch {
+	case l < slog.LevelInfo:
+		return style.Foreground(lipgloss.Color("243"))
+	case l < slog.LevelWarn:
+		return style
+	case l < slog.LevelError:
+		return style.Foreground(lipgloss.Color("11"))
+	default:
+		return style.Foreground(lipgloss.Color("9"))
+	}
+}
+
 func levelLabel(l slog.Level) string {
 	switch {
 	case l < slog.LevelInfo:
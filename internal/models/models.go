@@ -22,19 +22,29 @@ type Category struct {
 	Name        string         `json:"name"`
 	Description sql.NullString `json:"description"`
 	CreatedAt   time.Time      `json:"created_at"`
+	DefaultTags string         `json:"default_tags"`
 }
 
 type Link struct {
-	ID           int64          `json:"id"`
-	Url          string         `json:"url"`
-	Title        sql.NullString `json:"title"`
-	Content      sql.NullString `json:"content"`
-	Summary      sql.NullString `json:"summary"`
-	Status       string         `json:"status"`
-	CreatedAt    time.Time      `json:"created_at"`
-	UpdatedAt    time.Time      `json:"updated_at"`
-	FetchedAt    sql.NullTime   `json:"fetched_at"`
-	SummarizedAt sql.NullTime   `json:"summarized_at"`
+	ID             int64          `json:"id"`
+	Url            string         `json:"url"`
+	Title          sql.NullString `json:"title"`
+	Content        sql.NullString `json:"content"`
+	Summary        sql.NullString `json:"summary"`
+	Status         string         `json:"status"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	FetchedAt      sql.NullTime   `json:"fetched_at"`
+	SummarizedAt   sql.NullTime   `json:"summarized_at"`
+	DeletedAt      sql.NullTime   `json:"deleted_at"`
+	Domain         string         `json:"domain"`
+	ContentLength  int64          `json:"content_length"`
+	AutoRefresh    bool           `json:"auto_refresh"`
+	NeedsAttention bool           `json:"needs_attention"`
+	CustomTitle    bool           `json:"custom_title"`
+	Source         string         `json:"source"`
+	ExpiresAt      sql.NullTime   `json:"expires_at"`
+	SummaryShort   sql.NullString `json:"summary_short"`
 }
 
 type LinkActivity struct {
@@ -43,12 +53,36 @@ type LinkActivity struct {
 	CreatedAt  time.Time `json:"created_at"`
 }
 
+type LinkArchive struct {
+	LinkID     int64     `json:"link_id"`
+	Html       string    `json:"html"`
+	ArchivedAt time.Time `json:"archived_at"`
+}
+
+type LinkCapture struct {
+	LinkID     int64     `json:"link_id"`
+	CapturedAt time.Time `json:"captured_at"`
+}
+
 type LinkCategory struct {
 	LinkID     int64     `json:"link_id"`
 	CategoryID int64     `json:"category_id"`
 	CreatedAt  time.Time `json:"created_at"`
 }
 
+type LinkImage struct {
+	LinkID   int64  `json:"link_id"`
+	Position int64  `json:"position"`
+	Url      string `json:"url"`
+	Alt      string `json:"alt"`
+}
+
+type LinkOpen struct {
+	ID       int64     `json:"id"`
+	LinkID   int64     `json:"link_id"`
+	OpenedAt time.Time `json:"opened_at"`
+}
+
 type LinkTag struct {
 	LinkID    int64     `json:"link_id"`
 	TagID     int64     `json:"tag_id"`
@@ -59,6 +93,8 @@ type LinkTask struct {
 	LinkID    int64     `json:"link_id"`
 	TaskID    int64     `json:"task_id"`
 	CreatedAt time.Time `json:"created_at"`
+	Opened    bool      `json:"opened"`
+	SortOrder int64     `json:"sort_order"`
 }
 
 type LinksFt struct {
@@ -68,6 +104,21 @@ type LinksFt struct {
 	Summary string `json:"summary"`
 }
 
+type LlmUsage struct {
+	ID           int64     `json:"id"`
+	Model        string    `json:"model"`
+	Operation    string    `json:"operation"`
+	InputTokens  int64     `json:"input_tokens"`
+	OutputTokens int64     `json:"output_tokens"`
+	CostUsd      float64   `json:"cost_usd"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type Setting struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
 type Tag struct {
 	ID        int64     `json:"id"`
 	Name      string    `json:"name"`
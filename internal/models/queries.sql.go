@@ -8,8 +8,41 @@ package models
 import (
 	"context"
 	"database/sql"
+	"time"
 )
 
+const addLinkCapture = `-- name: AddLinkCapture :exec
+INSERT OR IGNORE INTO link_captures (link_id)
+VALUES (?)
+`
+
+func (q *Queries) AddLinkCapture(ctx context.Context, linkID int64) error {
+	_, err := q.db.ExecContext(ctx, addLinkCapture, linkID)
+	return err
+}
+
+const addLinkImage = `-- name: AddLinkImage :exec
+INSERT INTO link_images (link_id, position, url, alt)
+VALUES (?, ?, ?, ?)
+`
+
+type AddLinkImageParams struct {
+	LinkID   int64  `json:"link_id"`
+	Position int64  `json:"position"`
+	Url      string `json:"url"`
+	Alt      string `json:"alt"`
+}
+
+func (q *Queries) AddLinkImage(ctx context.Context, arg AddLinkImageParams) error {
+	_, err := q.db.ExecContext(ctx, addLinkImage,
+		arg.LinkID,
+		arg.Position,
+		arg.Url,
+		arg.Alt,
+	)
+	return err
+}
+
 const completeTask = `-- name: CompleteTask :exec
 UPDATE tasks
 SET completed = 1,
@@ -22,6 +55,189 @@ func (q *Queries) CompleteTask(ctx context.Context, id int64) error {
 	return err
 }
 
+const countLinksByCategory = `-- name: CountLinksByCategory :many
+SELECT c.id, c.name, COUNT(l.id) AS count FROM categories c
+LEFT JOIN link_categories lc ON c.id = lc.category_id
+LEFT JOIN links l ON l.id = lc.link_id AND l.deleted_at IS NULL
+GROUP BY c.id, c.name
+ORDER BY c.name
+`
+
+type CountLinksByCategoryRow struct {
+	ID    int64  `json:"id"`
+	Name  string `json:"name"`
+	Count int64  `json:"count"`
+}
+
+func (q *Queries) CountLinksByCategory(ctx context.Context) ([]CountLinksByCategoryRow, error) {
+	rows, err := q.db.QueryContext(ctx, countLinksByCategory)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CountLinksByCategoryRow{}
+	for rows.Next() {
+		var i CountLinksByCategoryRow
+		if err := rows.Scan(&i.ID, &i.Name, &i.Count); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const countLinksByDomain = `-- name: CountLinksByDomain :many
+SELECT domain, COUNT(*) AS count FROM links
+WHERE deleted_at IS NULL AND domain != ''
+GROUP BY domain
+ORDER BY count DESC, domain
+LIMIT ?
+`
+
+type CountLinksByDomainRow struct {
+	Domain string `json:"domain"`
+	Count  int64  `json:"count"`
+}
+
+func (q *Queries) CountLinksByDomain(ctx context.Context, limit int64) ([]CountLinksByDomainRow, error) {
+	rows, err := q.db.QueryContext(ctx, countLinksByDomain, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CountLinksByDomainRow{}
+	for rows.Next() {
+		var i CountLinksByDomainRow
+		if err := rows.Scan(&i.Domain, &i.Count); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const countLinksByStatus = `-- name: CountLinksByStatus :many
+SELECT status, COUNT(*) AS count FROM links
+WHERE deleted_at IS NULL
+GROUP BY status
+ORDER BY status
+`
+
+type CountLinksByStatusRow struct {
+	Status string `json:"status"`
+	Count  int64  `json:"count"`
+}
+
+func (q *Queries) CountLinksByStatus(ctx context.Context) ([]CountLinksByStatusRow, error) {
+	rows, err := q.db.QueryContext(ctx, countLinksByStatus)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CountLinksByStatusRow{}
+	for rows.Next() {
+		var i CountLinksByStatusRow
+		if err := rows.Scan(&i.Status, &i.Count); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const countLinksByTag = `-- name: CountLinksByTag :many
+SELECT t.id, t.name, COUNT(l.id) AS count FROM tags t
+LEFT JOIN link_tags lt ON t.id = lt.tag_id
+LEFT JOIN links l ON l.id = lt.link_id AND l.deleted_at IS NULL
+GROUP BY t.id, t.name
+ORDER BY t.name
+`
+
+type CountLinksByTagRow struct {
+	ID    int64  `json:"id"`
+	Name  string `json:"name"`
+	Count int64  `json:"count"`
+}
+
+func (q *Queries) CountLinksByTag(ctx context.Context) ([]CountLinksByTagRow, error) {
+	rows, err := q.db.QueryContext(ctx, countLinksByTag)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CountLinksByTagRow{}
+	for rows.Next() {
+		var i CountLinksByTagRow
+		if err := rows.Scan(&i.ID, &i.Name, &i.Count); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const countTaskLinksOpened = `-- name: CountTaskLinksOpened :many
+SELECT lt.task_id,
+       COUNT(*) AS total,
+       COUNT(CASE WHEN lt.opened THEN 1 END) AS opened
+FROM link_tasks lt
+JOIN links l ON l.id = lt.link_id AND l.deleted_at IS NULL
+GROUP BY lt.task_id
+`
+
+type CountTaskLinksOpenedRow struct {
+	TaskID int64 `json:"task_id"`
+	Total  int64 `json:"total"`
+	Opened int64 `json:"opened"`
+}
+
+func (q *Queries) CountTaskLinksOpened(ctx context.Context) ([]CountTaskLinksOpenedRow, error) {
+	rows, err := q.db.QueryContext(ctx, countTaskLinksOpened)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CountTaskLinksOpenedRow{}
+	for rows.Next() {
+		var i CountTaskLinksOpenedRow
+		if err := rows.Scan(&i.TaskID, &i.Total, &i.Opened); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const createActivity = `-- name: CreateActivity :one
 INSERT INTO activities (name, description)
 VALUES (?, ?)
@@ -48,40 +264,69 @@ func (q *Queries) CreateActivity(ctx context.Context, arg CreateActivityParams)
 }
 
 const createCategory = `-- name: CreateCategory :one
-INSERT INTO categories (name, description)
-VALUES (?, ?)
-RETURNING id, name, description, created_at
+INSERT INTO categories (name, description, default_tags)
+VALUES (?, ?, ?)
+RETURNING id, name, description, created_at, default_tags
 `
 
 type CreateCategoryParams struct {
 	Name        string         `json:"name"`
 	Description sql.NullString `json:"description"`
+	DefaultTags string         `json:"default_tags"`
 }
 
 func (q *Queries) CreateCategory(ctx context.Context, arg CreateCategoryParams) (Category, error) {
-	row := q.db.QueryRowContext(ctx, createCategory, arg.Name, arg.Description)
+	row := q.db.QueryRowContext(ctx, createCategory, arg.Name, arg.Description, arg.DefaultTags)
 	var i Category
 	err := row.Scan(
 		&i.ID,
 		&i.Name,
 		&i.Description,
 		&i.CreatedAt,
+		&i.DefaultTags,
 	)
 	return i, err
 }
 
-const createLink = `-- name: CreateLink :one
-INSERT INTO links (url, title, content, summary, status)
+const createLLMUsage = `-- name: CreateLLMUsage :exec
+INSERT INTO llm_usage (model, operation, input_tokens, output_tokens, cost_usd)
 VALUES (?, ?, ?, ?, ?)
-RETURNING id, url, title, content, summary, status, created_at, updated_at, fetched_at, summarized_at
+`
+
+type CreateLLMUsageParams struct {
+	Model        string  `json:"model"`
+	Operation    string  `json:"operation"`
+	InputTokens  int64   `json:"input_tokens"`
+	OutputTokens int64   `json:"output_tokens"`
+	CostUsd      float64 `json:"cost_usd"`
+}
+
+func (q *Queries) CreateLLMUsage(ctx context.Context, arg CreateLLMUsageParams) error {
+	_, err := q.db.ExecContext(ctx, createLLMUsage,
+		arg.Model,
+		arg.Operation,
+		arg.InputTokens,
+		arg.OutputTokens,
+		arg.CostUsd,
+	)
+	return err
+}
+
+const createLink = `-- name: CreateLink :one
+INSERT INTO links (url, title, content, summary, status, domain, content_length, source)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING id, url, title, content, summary, status, created_at, updated_at, fetched_at, summarized_at, deleted_at, domain, content_length, auto_refresh, needs_attention, custom_title, source, expires_at, summary_short
 `
 
 type CreateLinkParams struct {
-	Url     string         `json:"url"`
-	Title   sql.NullString `json:"title"`
-	Content sql.NullString `json:"content"`
-	Summary sql.NullString `json:"summary"`
-	Status  string         `json:"status"`
+	Url           string         `json:"url"`
+	Title         sql.NullString `json:"title"`
+	Content       sql.NullString `json:"content"`
+	Summary       sql.NullString `json:"summary"`
+	Status        string         `json:"status"`
+	Domain        string         `json:"domain"`
+	ContentLength int64          `json:"content_length"`
+	Source        string         `json:"source"`
 }
 
 func (q *Queries) CreateLink(ctx context.Context, arg CreateLinkParams) (Link, error) {
@@ -91,6 +336,9 @@ func (q *Queries) CreateLink(ctx context.Context, arg CreateLinkParams) (Link, e
 		arg.Content,
 		arg.Summary,
 		arg.Status,
+		arg.Domain,
+		arg.ContentLength,
+		arg.Source,
 	)
 	var i Link
 	err := row.Scan(
@@ -104,6 +352,15 @@ func (q *Queries) CreateLink(ctx context.Context, arg CreateLinkParams) (Link, e
 		&i.UpdatedAt,
 		&i.FetchedAt,
 		&i.SummarizedAt,
+		&i.DeletedAt,
+		&i.Domain,
+		&i.ContentLength,
+		&i.AutoRefresh,
+		&i.NeedsAttention,
+		&i.CustomTitle,
+		&i.Source,
+		&i.ExpiresAt,
+		&i.SummaryShort,
 	)
 	return i, err
 }
@@ -166,7 +423,9 @@ func (q *Queries) DeleteCategory(ctx context.Context, id int64) error {
 }
 
 const deleteLink = `-- name: DeleteLink :exec
-DELETE FROM links
+UPDATE links
+SET deleted_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
 WHERE id = ?
 `
 
@@ -175,6 +434,26 @@ func (q *Queries) DeleteLink(ctx context.Context, id int64) error {
 	return err
 }
 
+const deleteLinkCapture = `-- name: DeleteLinkCapture :exec
+DELETE FROM link_captures
+WHERE link_id = ?
+`
+
+func (q *Queries) DeleteLinkCapture(ctx context.Context, linkID int64) error {
+	_, err := q.db.ExecContext(ctx, deleteLinkCapture, linkID)
+	return err
+}
+
+const deleteLinkImages = `-- name: DeleteLinkImages :exec
+DELETE FROM link_images
+WHERE link_id = ?
+`
+
+func (q *Queries) DeleteLinkImages(ctx context.Context, linkID int64) error {
+	_, err := q.db.ExecContext(ctx, deleteLinkImages, linkID)
+	return err
+}
+
 const deleteTag = `-- name: DeleteTag :exec
 DELETE FROM tags
 WHERE id = ?
@@ -249,7 +528,7 @@ func (q *Queries) GetActivity(ctx context.Context, id int64) (Activity, error) {
 }
 
 const getCategoriesForLink = `-- name: GetCategoriesForLink :many
-SELECT c.id, c.name, c.description, c.created_at FROM categories c
+SELECT c.id, c.name, c.description, c.created_at, c.default_tags FROM categories c
 JOIN link_categories lc ON c.id = lc.category_id
 WHERE lc.link_id = ?
 ORDER BY c.name
@@ -269,6 +548,7 @@ func (q *Queries) GetCategoriesForLink(ctx context.Context, linkID int64) ([]Cat
 			&i.Name,
 			&i.Description,
 			&i.CreatedAt,
+			&i.DefaultTags,
 		); err != nil {
 			return nil, err
 		}
@@ -284,7 +564,7 @@ func (q *Queries) GetCategoriesForLink(ctx context.Context, linkID int64) ([]Cat
 }
 
 const getCategory = `-- name: GetCategory :one
-SELECT id, name, description, created_at FROM categories
+SELECT id, name, description, created_at, default_tags FROM categories
 WHERE id = ?
 `
 
@@ -296,12 +576,13 @@ func (q *Queries) GetCategory(ctx context.Context, id int64) (Category, error) {
 		&i.Name,
 		&i.Description,
 		&i.CreatedAt,
+		&i.DefaultTags,
 	)
 	return i, err
 }
 
 const getCategoryByName = `-- name: GetCategoryByName :one
-SELECT id, name, description, created_at FROM categories
+SELECT id, name, description, created_at, default_tags FROM categories
 WHERE name = ?
 `
 
@@ -313,12 +594,13 @@ func (q *Queries) GetCategoryByName(ctx context.Context, name string) (Category,
 		&i.Name,
 		&i.Description,
 		&i.CreatedAt,
+		&i.DefaultTags,
 	)
 	return i, err
 }
 
 const getLink = `-- name: GetLink :one
-SELECT id, url, title, content, summary, status, created_at, updated_at, fetched_at, summarized_at FROM links
+SELECT id, url, title, content, summary, status, created_at, updated_at, fetched_at, summarized_at, deleted_at, domain, content_length, auto_refresh, needs_attention, custom_title, source, expires_at, summary_short FROM links
 WHERE id = ?
 `
 
@@ -336,12 +618,33 @@ func (q *Queries) GetLink(ctx context.Context, id int64) (Link, error) {
 		&i.UpdatedAt,
 		&i.FetchedAt,
 		&i.SummarizedAt,
+		&i.DeletedAt,
+		&i.Domain,
+		&i.ContentLength,
+		&i.AutoRefresh,
+		&i.NeedsAttention,
+		&i.CustomTitle,
+		&i.Source,
+		&i.ExpiresAt,
+		&i.SummaryShort,
 	)
 	return i, err
 }
 
+const getLinkArchive = `-- name: GetLinkArchive :one
+SELECT html FROM link_archives
+WHERE link_id = ?
+`
+
+func (q *Queries) GetLinkArchive(ctx context.Context, linkID int64) (string, error) {
+	row := q.db.QueryRowContext(ctx, getLinkArchive, linkID)
+	var html string
+	err := row.Scan(&html)
+	return html, err
+}
+
 const getLinkByURL = `-- name: GetLinkByURL :one
-SELECT id, url, title, content, summary, status, created_at, updated_at, fetched_at, summarized_at FROM links
+SELECT id, url, title, content, summary, status, created_at, updated_at, fetched_at, summarized_at, deleted_at, domain, content_length, auto_refresh, needs_attention, custom_title, source, expires_at, summary_short FROM links
 WHERE url = ?
 `
 
@@ -359,14 +662,57 @@ func (q *Queries) GetLinkByURL(ctx context.Context, url string) (Link, error) {
 		&i.UpdatedAt,
 		&i.FetchedAt,
 		&i.SummarizedAt,
+		&i.DeletedAt,
+		&i.Domain,
+		&i.ContentLength,
+		&i.AutoRefresh,
+		&i.NeedsAttention,
+		&i.CustomTitle,
+		&i.Source,
+		&i.ExpiresAt,
+		&i.SummaryShort,
 	)
 	return i, err
 }
 
+const getLinkImages = `-- name: GetLinkImages :many
+SELECT link_id, position, url, alt FROM link_images
+WHERE link_id = ?
+ORDER BY position
+`
+
+func (q *Queries) GetLinkImages(ctx context.Context, linkID int64) ([]LinkImage, error) {
+	rows, err := q.db.QueryContext(ctx, getLinkImages, linkID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []LinkImage{}
+	for rows.Next() {
+		var i LinkImage
+		if err := rows.Scan(
+			&i.LinkID,
+			&i.Position,
+			&i.Url,
+			&i.Alt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getLinksForActivity = `-- name: GetLinksForActivity :many
-SELECT l.id, l.url, l.title, l.content, l.summary, l.status, l.created_at, l.updated_at, l.fetched_at, l.summarized_at FROM links l
+SELECT l.id, l.url, l.title, l.content, l.summary, l.status, l.created_at, l.updated_at, l.fetched_at, l.summarized_at, l.deleted_at, l.domain, l.content_length, l.auto_refresh, l.needs_attention, l.custom_title, l.source, l.expires_at, l.summary_short FROM links l
 JOIN link_activities la ON l.id = la.link_id
-WHERE la.activity_id = ?
+WHERE la.activity_id = ? AND l.deleted_at IS NULL
 ORDER BY l.created_at DESC
 `
 
@@ -390,6 +736,15 @@ func (q *Queries) GetLinksForActivity(ctx context.Context, activityID int64) ([]
 			&i.UpdatedAt,
 			&i.FetchedAt,
 			&i.SummarizedAt,
+			&i.DeletedAt,
+			&i.Domain,
+			&i.ContentLength,
+			&i.AutoRefresh,
+			&i.NeedsAttention,
+			&i.CustomTitle,
+			&i.Source,
+			&i.ExpiresAt,
+			&i.SummaryShort,
 		); err != nil {
 			return nil, err
 		}
@@ -405,9 +760,9 @@ func (q *Queries) GetLinksForActivity(ctx context.Context, activityID int64) ([]
 }
 
 const getLinksForCategory = `-- name: GetLinksForCategory :many
-SELECT l.id, l.url, l.title, l.content, l.summary, l.status, l.created_at, l.updated_at, l.fetched_at, l.summarized_at FROM links l
+SELECT l.id, l.url, l.title, l.content, l.summary, l.status, l.created_at, l.updated_at, l.fetched_at, l.summarized_at, l.deleted_at, l.domain, l.content_length, l.auto_refresh, l.needs_attention, l.custom_title, l.source, l.expires_at, l.summary_short FROM links l
 JOIN link_categories lc ON l.id = lc.link_id
-WHERE lc.category_id = ?
+WHERE lc.category_id = ? AND l.deleted_at IS NULL
 ORDER BY l.created_at DESC
 `
 
@@ -431,6 +786,15 @@ func (q *Queries) GetLinksForCategory(ctx context.Context, categoryID int64) ([]
 			&i.UpdatedAt,
 			&i.FetchedAt,
 			&i.SummarizedAt,
+			&i.DeletedAt,
+			&i.Domain,
+			&i.ContentLength,
+			&i.AutoRefresh,
+			&i.NeedsAttention,
+			&i.CustomTitle,
+			&i.Source,
+			&i.ExpiresAt,
+			&i.SummaryShort,
 		); err != nil {
 			return nil, err
 		}
@@ -446,9 +810,9 @@ func (q *Queries) GetLinksForCategory(ctx context.Context, categoryID int64) ([]
 }
 
 const getLinksForTag = `-- name: GetLinksForTag :many
-SELECT l.id, l.url, l.title, l.content, l.summary, l.status, l.created_at, l.updated_at, l.fetched_at, l.summarized_at FROM links l
+SELECT l.id, l.url, l.title, l.content, l.summary, l.status, l.created_at, l.updated_at, l.fetched_at, l.summarized_at, l.deleted_at, l.domain, l.content_length, l.auto_refresh, l.needs_attention, l.custom_title, l.source, l.expires_at, l.summary_short FROM links l
 JOIN link_tags lt ON l.id = lt.link_id
-WHERE lt.tag_id = ?
+WHERE lt.tag_id = ? AND l.deleted_at IS NULL
 ORDER BY l.created_at DESC
 `
 
@@ -472,6 +836,15 @@ func (q *Queries) GetLinksForTag(ctx context.Context, tagID int64) ([]Link, erro
 			&i.UpdatedAt,
 			&i.FetchedAt,
 			&i.SummarizedAt,
+			&i.DeletedAt,
+			&i.Domain,
+			&i.ContentLength,
+			&i.AutoRefresh,
+			&i.NeedsAttention,
+			&i.CustomTitle,
+			&i.Source,
+			&i.ExpiresAt,
+			&i.SummaryShort,
 		); err != nil {
 			return nil, err
 		}
@@ -486,15 +859,21 @@ func (q *Queries) GetLinksForTag(ctx context.Context, tagID int64) ([]Link, erro
 	return items, nil
 }
 
-const getLinksForTask = `-- name: GetLinksForTask :many
-SELECT l.id, l.url, l.title, l.content, l.summary, l.status, l.created_at, l.updated_at, l.fetched_at, l.summarized_at FROM links l
-JOIN link_tasks lt ON l.id = lt.link_id
-WHERE lt.task_id = ?
+const getLinksForTagPair = `-- name: GetLinksForTagPair :many
+SELECT l.id, l.url, l.title, l.content, l.summary, l.status, l.created_at, l.updated_at, l.fetched_at, l.summarized_at, l.deleted_at, l.domain, l.content_length, l.auto_refresh, l.needs_attention, l.custom_title, l.source, l.expires_at, l.summary_short FROM links l
+JOIN link_tags a ON l.id = a.link_id AND a.tag_id = ?1
+JOIN link_tags b ON l.id = b.link_id AND b.tag_id = ?2
+WHERE l.deleted_at IS NULL
 ORDER BY l.created_at DESC
 `
 
-func (q *Queries) GetLinksForTask(ctx context.Context, taskID int64) ([]Link, error) {
-	rows, err := q.db.QueryContext(ctx, getLinksForTask, taskID)
+type GetLinksForTagPairParams struct {
+	TagID      int64 `json:"tag_id"`
+	OtherTagID int64 `json:"other_tag_id"`
+}
+
+func (q *Queries) GetLinksForTagPair(ctx context.Context, arg GetLinksForTagPairParams) ([]Link, error) {
+	rows, err := q.db.QueryContext(ctx, getLinksForTagPair, arg.TagID, arg.OtherTagID)
 	if err != nil {
 		return nil, err
 	}
@@ -513,6 +892,15 @@ func (q *Queries) GetLinksForTask(ctx context.Context, taskID int64) ([]Link, er
 			&i.UpdatedAt,
 			&i.FetchedAt,
 			&i.SummarizedAt,
+			&i.DeletedAt,
+			&i.Domain,
+			&i.ContentLength,
+			&i.AutoRefresh,
+			&i.NeedsAttention,
+			&i.CustomTitle,
+			&i.Source,
+			&i.ExpiresAt,
+			&i.SummaryShort,
 		); err != nil {
 			return nil, err
 		}
@@ -527,47 +915,43 @@ func (q *Queries) GetLinksForTask(ctx context.Context, taskID int64) ([]Link, er
 	return items, nil
 }
 
-const getTag = `-- name: GetTag :one
-SELECT id, name, created_at FROM tags
-WHERE id = ?
-`
-
-func (q *Queries) GetTag(ctx context.Context, id int64) (Tag, error) {
-	row := q.db.QueryRowContext(ctx, getTag, id)
-	var i Tag
-	err := row.Scan(&i.ID, &i.Name, &i.CreatedAt)
-	return i, err
-}
-
-const getTagByName = `-- name: GetTagByName :one
-SELECT id, name, created_at FROM tags
-WHERE name = ?
-`
-
-func (q *Queries) GetTagByName(ctx context.Context, name string) (Tag, error) {
-	row := q.db.QueryRowContext(ctx, getTagByName, name)
-	var i Tag
-	err := row.Scan(&i.ID, &i.Name, &i.CreatedAt)
-	return i, err
-}
-
-const getTagsForLink = `-- name: GetTagsForLink :many
-SELECT t.id, t.name, t.created_at FROM tags t
-JOIN link_tags lt ON t.id = lt.tag_id
-WHERE lt.link_id = ?
-ORDER BY t.name
+const getLinksForTask = `-- name: GetLinksForTask :many
+SELECT l.id, l.url, l.title, l.content, l.summary, l.status, l.created_at, l.updated_at, l.fetched_at, l.summarized_at, l.deleted_at, l.domain, l.content_length, l.auto_refresh, l.needs_attention, l.custom_title, l.source, l.expires_at, l.summary_short FROM links l
+JOIN link_tasks lt ON l.id = lt.link_id
+WHERE lt.task_id = ? AND l.deleted_at IS NULL
+ORDER BY lt.sort_order, l.created_at DESC
 `
 
-func (q *Queries) GetTagsForLink(ctx context.Context, linkID int64) ([]Tag, error) {
-	rows, err := q.db.QueryContext(ctx, getTagsForLink, linkID)
+func (q *Queries) GetLinksForTask(ctx context.Context, taskID int64) ([]Link, error) {
+	rows, err := q.db.QueryContext(ctx, getLinksForTask, taskID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []Tag{}
+	items := []Link{}
 	for rows.Next() {
-		var i Tag
-		if err := rows.Scan(&i.ID, &i.Name, &i.CreatedAt); err != nil {
+		var i Link
+		if err := rows.Scan(
+			&i.ID,
+			&i.Url,
+			&i.Title,
+			&i.Content,
+			&i.Summary,
+			&i.Status,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.FetchedAt,
+			&i.SummarizedAt,
+			&i.DeletedAt,
+			&i.Domain,
+			&i.ContentLength,
+			&i.AutoRefresh,
+			&i.NeedsAttention,
+			&i.CustomTitle,
+			&i.Source,
+			&i.ExpiresAt,
+			&i.SummaryShort,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -581,22 +965,156 @@ func (q *Queries) GetTagsForLink(ctx context.Context, linkID int64) ([]Tag, erro
 	return items, nil
 }
 
-const getTask = `-- name: GetTask :one
-SELECT id, name, description, completed, created_at, updated_at FROM tasks
-WHERE id = ?
+const getRandomLink = `-- name: GetRandomLink :one
+SELECT id, url, title, content, summary, status, created_at, updated_at, fetched_at, summarized_at, deleted_at, domain, content_length, auto_refresh, needs_attention, custom_title, source, expires_at, summary_short FROM links
+WHERE deleted_at IS NULL
+ORDER BY RANDOM()
+LIMIT 1
 `
 
-func (q *Queries) GetTask(ctx context.Context, id int64) (Task, error) {
-	row := q.db.QueryRowContext(ctx, getTask, id)
-	var i Task
+func (q *Queries) GetRandomLink(ctx context.Context) (Link, error) {
+	row := q.db.QueryRowContext(ctx, getRandomLink)
+	var i Link
 	err := row.Scan(
 		&i.ID,
-		&i.Name,
-		&i.Description,
-		&i.Completed,
+		&i.Url,
+		&i.Title,
+		&i.Content,
+		&i.Summary,
+		&i.Status,
 		&i.CreatedAt,
 		&i.UpdatedAt,
-	)
+		&i.FetchedAt,
+		&i.SummarizedAt,
+		&i.DeletedAt,
+		&i.Domain,
+		&i.ContentLength,
+		&i.AutoRefresh,
+		&i.NeedsAttention,
+		&i.CustomTitle,
+		&i.Source,
+		&i.ExpiresAt,
+		&i.SummaryShort,
+	)
+	return i, err
+}
+
+const getRandomLinkByStatus = `-- name: GetRandomLinkByStatus :one
+SELECT id, url, title, content, summary, status, created_at, updated_at, fetched_at, summarized_at, deleted_at, domain, content_length, auto_refresh, needs_attention, custom_title, source, expires_at, summary_short FROM links
+WHERE status = ? AND deleted_at IS NULL
+ORDER BY RANDOM()
+LIMIT 1
+`
+
+func (q *Queries) GetRandomLinkByStatus(ctx context.Context, status string) (Link, error) {
+	row := q.db.QueryRowContext(ctx, getRandomLinkByStatus, status)
+	var i Link
+	err := row.Scan(
+		&i.ID,
+		&i.Url,
+		&i.Title,
+		&i.Content,
+		&i.Summary,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.FetchedAt,
+		&i.SummarizedAt,
+		&i.DeletedAt,
+		&i.Domain,
+		&i.ContentLength,
+		&i.AutoRefresh,
+		&i.NeedsAttention,
+		&i.CustomTitle,
+		&i.Source,
+		&i.ExpiresAt,
+		&i.SummaryShort,
+	)
+	return i, err
+}
+
+const getSetting = `-- name: GetSetting :one
+SELECT value FROM settings
+WHERE key = ?
+`
+
+func (q *Queries) GetSetting(ctx context.Context, key string) (string, error) {
+	row := q.db.QueryRowContext(ctx, getSetting, key)
+	var value string
+	err := row.Scan(&value)
+	return value, err
+}
+
+const getTag = `-- name: GetTag :one
+SELECT id, name, created_at FROM tags
+WHERE id = ?
+`
+
+func (q *Queries) GetTag(ctx context.Context, id int64) (Tag, error) {
+	row := q.db.QueryRowContext(ctx, getTag, id)
+	var i Tag
+	err := row.Scan(&i.ID, &i.Name, &i.CreatedAt)
+	return i, err
+}
+
+const getTagByName = `-- name: GetTagByName :one
+SELECT id, name, created_at FROM tags
+WHERE name = ?
+`
+
+func (q *Queries) GetTagByName(ctx context.Context, name string) (Tag, error) {
+	row := q.db.QueryRowContext(ctx, getTagByName, name)
+	var i Tag
+	err := row.Scan(&i.ID, &i.Name, &i.CreatedAt)
+	return i, err
+}
+
+const getTagsForLink = `-- name: GetTagsForLink :many
+SELECT t.id, t.name, t.created_at FROM tags t
+JOIN link_tags lt ON t.id = lt.tag_id
+WHERE lt.link_id = ?
+ORDER BY t.name
+`
+
+func (q *Queries) GetTagsForLink(ctx context.Context, linkID int64) ([]Tag, error) {
+	rows, err := q.db.QueryContext(ctx, getTagsForLink, linkID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Tag{}
+	for rows.Next() {
+		var i Tag
+		if err := rows.Scan(&i.ID, &i.Name, &i.CreatedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTask = `-- name: GetTask :one
+SELECT id, name, description, completed, created_at, updated_at FROM tasks
+WHERE id = ?
+`
+
+func (q *Queries) GetTask(ctx context.Context, id int64) (Task, error) {
+	row := q.db.QueryRowContext(ctx, getTask, id)
+	var i Task
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.Completed,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
 	return i, err
 }
 
@@ -682,8 +1200,10 @@ func (q *Queries) LinkTag(ctx context.Context, arg LinkTagParams) error {
 }
 
 const linkTask = `-- name: LinkTask :exec
-INSERT INTO link_tasks (link_id, task_id)
-VALUES (?, ?)
+INSERT INTO link_tasks (link_id, task_id, sort_order)
+VALUES (?1, ?2, (
+    SELECT COALESCE(MIN(sort_order), 1) - 1 FROM link_tasks WHERE task_id = ?2
+))
 `
 
 type LinkTaskParams struct {
@@ -730,8 +1250,108 @@ func (q *Queries) ListActivities(ctx context.Context) ([]Activity, error) {
 	return items, nil
 }
 
+const listArchivedLinks = `-- name: ListArchivedLinks :many
+SELECT l.id, l.url, l.title, l.content, l.summary, l.status, l.created_at, l.updated_at, l.fetched_at, l.summarized_at, l.deleted_at, l.domain, l.content_length, l.auto_refresh, l.needs_attention, l.custom_title, l.source, l.expires_at, l.summary_short FROM links l
+JOIN link_archives la ON l.id = la.link_id
+WHERE l.deleted_at IS NULL
+ORDER BY l.id
+`
+
+func (q *Queries) ListArchivedLinks(ctx context.Context) ([]Link, error) {
+	rows, err := q.db.QueryContext(ctx, listArchivedLinks)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Link{}
+	for rows.Next() {
+		var i Link
+		if err := rows.Scan(
+			&i.ID,
+			&i.Url,
+			&i.Title,
+			&i.Content,
+			&i.Summary,
+			&i.Status,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.FetchedAt,
+			&i.SummarizedAt,
+			&i.DeletedAt,
+			&i.Domain,
+			&i.ContentLength,
+			&i.AutoRefresh,
+			&i.NeedsAttention,
+			&i.CustomTitle,
+			&i.Source,
+			&i.ExpiresAt,
+			&i.SummaryShort,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCapturedLinks = `-- name: ListCapturedLinks :many
+SELECT l.id, l.url, l.title, l.content, l.summary, l.status, l.created_at, l.updated_at, l.fetched_at, l.summarized_at, l.deleted_at, l.domain, l.content_length, l.auto_refresh, l.needs_attention, l.custom_title, l.source, l.expires_at, l.summary_short FROM links l
+JOIN link_captures lc ON l.id = lc.link_id
+WHERE l.deleted_at IS NULL
+ORDER BY lc.captured_at, l.id
+`
+
+func (q *Queries) ListCapturedLinks(ctx context.Context) ([]Link, error) {
+	rows, err := q.db.QueryContext(ctx, listCapturedLinks)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Link{}
+	for rows.Next() {
+		var i Link
+		if err := rows.Scan(
+			&i.ID,
+			&i.Url,
+			&i.Title,
+			&i.Content,
+			&i.Summary,
+			&i.Status,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.FetchedAt,
+			&i.SummarizedAt,
+			&i.DeletedAt,
+			&i.Domain,
+			&i.ContentLength,
+			&i.AutoRefresh,
+			&i.NeedsAttention,
+			&i.CustomTitle,
+			&i.Source,
+			&i.ExpiresAt,
+			&i.SummaryShort,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listCategories = `-- name: ListCategories :many
-SELECT id, name, description, created_at FROM categories
+SELECT id, name, description, created_at, default_tags FROM categories
 ORDER BY name
 `
 
@@ -749,6 +1369,7 @@ func (q *Queries) ListCategories(ctx context.Context) ([]Category, error) {
 			&i.Name,
 			&i.Description,
 			&i.CreatedAt,
+			&i.DefaultTags,
 		); err != nil {
 			return nil, err
 		}
@@ -799,19 +1420,14 @@ func (q *Queries) ListIncompleteTasks(ctx context.Context) ([]Task, error) {
 	return items, nil
 }
 
-const listLinks = `-- name: ListLinks :many
-SELECT id, url, title, content, summary, status, created_at, updated_at, fetched_at, summarized_at FROM links
-ORDER BY created_at DESC
-LIMIT ? OFFSET ?
+const listDeletedLinks = `-- name: ListDeletedLinks :many
+SELECT id, url, title, content, summary, status, created_at, updated_at, fetched_at, summarized_at, deleted_at, domain, content_length, auto_refresh, needs_attention, custom_title, source, expires_at, summary_short FROM links
+WHERE deleted_at IS NOT NULL
+ORDER BY deleted_at DESC
 `
 
-type ListLinksParams struct {
-	Limit  int64 `json:"limit"`
-	Offset int64 `json:"offset"`
-}
-
-func (q *Queries) ListLinks(ctx context.Context, arg ListLinksParams) ([]Link, error) {
-	rows, err := q.db.QueryContext(ctx, listLinks, arg.Limit, arg.Offset)
+func (q *Queries) ListDeletedLinks(ctx context.Context) ([]Link, error) {
+	rows, err := q.db.QueryContext(ctx, listDeletedLinks)
 	if err != nil {
 		return nil, err
 	}
@@ -830,6 +1446,15 @@ func (q *Queries) ListLinks(ctx context.Context, arg ListLinksParams) ([]Link, e
 			&i.UpdatedAt,
 			&i.FetchedAt,
 			&i.SummarizedAt,
+			&i.DeletedAt,
+			&i.Domain,
+			&i.ContentLength,
+			&i.AutoRefresh,
+			&i.NeedsAttention,
+			&i.CustomTitle,
+			&i.Source,
+			&i.ExpiresAt,
+			&i.SummaryShort,
 		); err != nil {
 			return nil, err
 		}
@@ -844,21 +1469,14 @@ func (q *Queries) ListLinks(ctx context.Context, arg ListLinksParams) ([]Link, e
 	return items, nil
 }
 
-const listLinksByStatus = `-- name: ListLinksByStatus :many
-SELECT id, url, title, content, summary, status, created_at, updated_at, fetched_at, summarized_at FROM links
-WHERE status = ?
-ORDER BY created_at DESC
-LIMIT ? OFFSET ?
+const listExpiringLinks = `-- name: ListExpiringLinks :many
+SELECT id, url, title, content, summary, status, created_at, updated_at, fetched_at, summarized_at, deleted_at, domain, content_length, auto_refresh, needs_attention, custom_title, source, expires_at, summary_short FROM links
+WHERE expires_at IS NOT NULL AND deleted_at IS NULL
+ORDER BY expires_at
 `
 
-type ListLinksByStatusParams struct {
-	Status string `json:"status"`
-	Limit  int64  `json:"limit"`
-	Offset int64  `json:"offset"`
-}
-
-func (q *Queries) ListLinksByStatus(ctx context.Context, arg ListLinksByStatusParams) ([]Link, error) {
-	rows, err := q.db.QueryContext(ctx, listLinksByStatus, arg.Status, arg.Limit, arg.Offset)
+func (q *Queries) ListExpiringLinks(ctx context.Context) ([]Link, error) {
+	rows, err := q.db.QueryContext(ctx, listExpiringLinks)
 	if err != nil {
 		return nil, err
 	}
@@ -877,6 +1495,15 @@ func (q *Queries) ListLinksByStatus(ctx context.Context, arg ListLinksByStatusPa
 			&i.UpdatedAt,
 			&i.FetchedAt,
 			&i.SummarizedAt,
+			&i.DeletedAt,
+			&i.Domain,
+			&i.ContentLength,
+			&i.AutoRefresh,
+			&i.NeedsAttention,
+			&i.CustomTitle,
+			&i.Source,
+			&i.ExpiresAt,
+			&i.SummaryShort,
 		); err != nil {
 			return nil, err
 		}
@@ -891,21 +1518,41 @@ func (q *Queries) ListLinksByStatus(ctx context.Context, arg ListLinksByStatusPa
 	return items, nil
 }
 
-const listTags = `-- name: ListTags :many
-SELECT id, name, created_at FROM tags
-ORDER BY name
+const listLLMUsageByMonth = `-- name: ListLLMUsageByMonth :many
+SELECT CAST(strftime('%Y-%m', created_at, 'localtime') AS TEXT) AS month,
+       COUNT(*) AS calls,
+       CAST(COALESCE(SUM(input_tokens), 0) AS INTEGER) AS input_tokens,
+       CAST(COALESCE(SUM(output_tokens), 0) AS INTEGER) AS output_tokens,
+       CAST(COALESCE(SUM(cost_usd), 0) AS REAL) AS cost_usd
+FROM llm_usage
+GROUP BY month
+ORDER BY month DESC
 `
 
-func (q *Queries) ListTags(ctx context.Context) ([]Tag, error) {
-	rows, err := q.db.QueryContext(ctx, listTags)
+type ListLLMUsageByMonthRow struct {
+	Month        string  `json:"month"`
+	Calls        int64   `json:"calls"`
+	InputTokens  int64   `json:"input_tokens"`
+	OutputTokens int64   `json:"output_tokens"`
+	CostUsd      float64 `json:"cost_usd"`
+}
+
+func (q *Queries) ListLLMUsageByMonth(ctx context.Context) ([]ListLLMUsageByMonthRow, error) {
+	rows, err := q.db.QueryContext(ctx, listLLMUsageByMonth)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []Tag{}
+	items := []ListLLMUsageByMonthRow{}
 	for rows.Next() {
-		var i Tag
-		if err := rows.Scan(&i.ID, &i.Name, &i.CreatedAt); err != nil {
+		var i ListLLMUsageByMonthRow
+		if err := rows.Scan(
+			&i.Month,
+			&i.Calls,
+			&i.InputTokens,
+			&i.OutputTokens,
+			&i.CostUsd,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -919,31 +1566,24 @@ func (q *Queries) ListTags(ctx context.Context) ([]Tag, error) {
 	return items, nil
 }
 
-const listTasks = `-- name: ListTasks :many
-SELECT id, name, description, completed, created_at, updated_at FROM tasks
-ORDER BY created_at DESC
+const listLinkContents = `-- name: ListLinkContents :many
+SELECT content FROM links
+WHERE deleted_at IS NULL AND content IS NOT NULL
 `
 
-func (q *Queries) ListTasks(ctx context.Context) ([]Task, error) {
-	rows, err := q.db.QueryContext(ctx, listTasks)
+func (q *Queries) ListLinkContents(ctx context.Context) ([]sql.NullString, error) {
+	rows, err := q.db.QueryContext(ctx, listLinkContents)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []Task{}
+	items := []sql.NullString{}
 	for rows.Next() {
-		var i Task
-		if err := rows.Scan(
-			&i.ID,
-			&i.Name,
-			&i.Description,
-			&i.Completed,
-			&i.CreatedAt,
-			&i.UpdatedAt,
-		); err != nil {
+		var content sql.NullString
+		if err := rows.Scan(&content); err != nil {
 			return nil, err
 		}
-		items = append(items, i)
+		items = append(items, content)
 	}
 	if err := rows.Close(); err != nil {
 		return nil, err
@@ -954,54 +1594,27 @@ func (q *Queries) ListTasks(ctx context.Context) ([]Task, error) {
 	return items, nil
 }
 
-const searchLinks = `-- name: SearchLinks :many
-SELECT id, url, title, content, summary, status, created_at, updated_at, fetched_at, summarized_at FROM links
-WHERE 
-    url LIKE ? OR
-    title LIKE ? OR
-    content LIKE ? OR
-    summary LIKE ?
-ORDER BY created_at DESC
-LIMIT ? OFFSET ?
+const listLinkTagNames = `-- name: ListLinkTagNames :many
+SELECT lt.link_id, t.name FROM link_tags lt
+JOIN tags t ON t.id = lt.tag_id
+ORDER BY lt.link_id, t.name
 `
 
-type SearchLinksParams struct {
-	Url     string         `json:"url"`
-	Title   sql.NullString `json:"title"`
-	Content sql.NullString `json:"content"`
-	Summary sql.NullString `json:"summary"`
-	Limit   int64          `json:"limit"`
-	Offset  int64          `json:"offset"`
+type ListLinkTagNamesRow struct {
+	LinkID int64  `json:"link_id"`
+	Name   string `json:"name"`
 }
 
-func (q *Queries) SearchLinks(ctx context.Context, arg SearchLinksParams) ([]Link, error) {
-	rows, err := q.db.QueryContext(ctx, searchLinks,
-		arg.Url,
-		arg.Title,
-		arg.Content,
-		arg.Summary,
-		arg.Limit,
-		arg.Offset,
-	)
+func (q *Queries) ListLinkTagNames(ctx context.Context) ([]ListLinkTagNamesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listLinkTagNames)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []Link{}
+	items := []ListLinkTagNamesRow{}
 	for rows.Next() {
-		var i Link
-		if err := rows.Scan(
-			&i.ID,
-			&i.Url,
-			&i.Title,
-			&i.Content,
-			&i.Summary,
-			&i.Status,
-			&i.CreatedAt,
-			&i.UpdatedAt,
-			&i.FetchedAt,
-			&i.SummarizedAt,
-		); err != nil {
+		var i ListLinkTagNamesRow
+		if err := rows.Scan(&i.LinkID, &i.Name); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -1015,9 +1628,950 @@ func (q *Queries) SearchLinks(ctx context.Context, arg SearchLinksParams) ([]Lin
 	return items, nil
 }
 
-const unlinkActivity = `-- name: UnlinkActivity :exec
-DELETE FROM link_activities WHERE link_id = ? AND activity_id = ?
-`
+const listLinks = `-- name: ListLinks :many
+SELECT id, url, title, content, summary, status, created_at, updated_at, fetched_at, summarized_at, deleted_at, domain, content_length, auto_refresh, needs_attention, custom_title, source, expires_at, summary_short FROM links
+WHERE deleted_at IS NULL
+ORDER BY created_at DESC
+LIMIT ? OFFSET ?
+`
+
+type ListLinksParams struct {
+	Limit  int64 `json:"limit"`
+	Offset int64 `json:"offset"`
+}
+
+func (q *Queries) ListLinks(ctx context.Context, arg ListLinksParams) ([]Link, error) {
+	rows, err := q.db.QueryContext(ctx, listLinks, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Link{}
+	for rows.Next() {
+		var i Link
+		if err := rows.Scan(
+			&i.ID,
+			&i.Url,
+			&i.Title,
+			&i.Content,
+			&i.Summary,
+			&i.Status,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.FetchedAt,
+			&i.SummarizedAt,
+			&i.DeletedAt,
+			&i.Domain,
+			&i.ContentLength,
+			&i.AutoRefresh,
+			&i.NeedsAttention,
+			&i.CustomTitle,
+			&i.Source,
+			&i.ExpiresAt,
+			&i.SummaryShort,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listLinksAddedSince = `-- name: ListLinksAddedSince :many
+SELECT id, url, title, content, summary, status, created_at, updated_at, fetched_at, summarized_at, deleted_at, domain, content_length, auto_refresh, needs_attention, custom_title, source, expires_at, summary_short FROM links
+WHERE deleted_at IS NULL
+  AND created_at >= datetime('now', ?1)
+ORDER BY created_at DESC
+`
+
+func (q *Queries) ListLinksAddedSince(ctx context.Context, age interface{}) ([]Link, error) {
+	rows, err := q.db.QueryContext(ctx, listLinksAddedSince, age)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Link{}
+	for rows.Next() {
+		var i Link
+		if err := rows.Scan(
+			&i.ID,
+			&i.Url,
+			&i.Title,
+			&i.Content,
+			&i.Summary,
+			&i.Status,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.FetchedAt,
+			&i.SummarizedAt,
+			&i.DeletedAt,
+			&i.Domain,
+			&i.ContentLength,
+			&i.AutoRefresh,
+			&i.NeedsAttention,
+			&i.CustomTitle,
+			&i.Source,
+			&i.ExpiresAt,
+			&i.SummaryShort,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listLinksAfterID = `-- name: ListLinksAfterID :many
+SELECT id, url, title, content, summary, status, created_at, updated_at, fetched_at, summarized_at, deleted_at, domain, content_length, auto_refresh, needs_attention, custom_title, source, expires_at, summary_short FROM links
+WHERE id > ? AND deleted_at IS NULL
+ORDER BY id
+`
+
+func (q *Queries) ListLinksAfterID(ctx context.Context, id int64) ([]Link, error) {
+	rows, err := q.db.QueryContext(ctx, listLinksAfterID, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Link{}
+	for rows.Next() {
+		var i Link
+		if err := rows.Scan(
+			&i.ID,
+			&i.Url,
+			&i.Title,
+			&i.Content,
+			&i.Summary,
+			&i.Status,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.FetchedAt,
+			&i.SummarizedAt,
+			&i.DeletedAt,
+			&i.Domain,
+			&i.ContentLength,
+			&i.AutoRefresh,
+			&i.NeedsAttention,
+			&i.CustomTitle,
+			&i.Source,
+			&i.ExpiresAt,
+			&i.SummaryShort,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listLinksByStatus = `-- name: ListLinksByStatus :many
+SELECT id, url, title, content, summary, status, created_at, updated_at, fetched_at, summarized_at, deleted_at, domain, content_length, auto_refresh, needs_attention, custom_title, source, expires_at, summary_short FROM links
+WHERE status = ? AND deleted_at IS NULL
+ORDER BY created_at DESC
+LIMIT ? OFFSET ?
+`
+
+type ListLinksByStatusParams struct {
+	Status string `json:"status"`
+	Limit  int64  `json:"limit"`
+	Offset int64  `json:"offset"`
+}
+
+func (q *Queries) ListLinksByStatus(ctx context.Context, arg ListLinksByStatusParams) ([]Link, error) {
+	rows, err := q.db.QueryContext(ctx, listLinksByStatus, arg.Status, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Link{}
+	for rows.Next() {
+		var i Link
+		if err := rows.Scan(
+			&i.ID,
+			&i.Url,
+			&i.Title,
+			&i.Content,
+			&i.Summary,
+			&i.Status,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.FetchedAt,
+			&i.SummarizedAt,
+			&i.DeletedAt,
+			&i.Domain,
+			&i.ContentLength,
+			&i.AutoRefresh,
+			&i.NeedsAttention,
+			&i.CustomTitle,
+			&i.Source,
+			&i.ExpiresAt,
+			&i.SummaryShort,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listLinksFiltered = `-- name: ListLinksFiltered :many
+SELECT id, url, title, content, summary, status, created_at, updated_at, fetched_at, summarized_at, deleted_at, domain, content_length, auto_refresh, needs_attention, custom_title, source, expires_at, summary_short FROM links
+WHERE deleted_at IS NULL
+  AND (?1 = '' OR domain = ?1)
+  AND (?2 = '' OR source = ?2 OR source LIKE ?2 || ':%')
+  AND (?3 = '' OR status = ?3)
+  AND (?4 OR ?3 != '' OR status != 'archived')
+  AND content_length >= ?5
+  AND (?6 = 0 OR content_length <= ?6)
+  AND (NOT ?7 OR needs_attention)
+  AND (?8 = '' OR url LIKE ?8 OR title LIKE ?8
+       OR content LIKE ?8 OR summary LIKE ?8)
+ORDER BY created_at DESC
+LIMIT ?9 OFFSET ?10
+`
+
+type ListLinksFilteredParams struct {
+	Domain          string `json:"domain"`
+	Source          string `json:"source"`
+	Status          string `json:"status"`
+	IncludeArchived bool   `json:"include_archived"`
+	MinWords        int64  `json:"min_words"`
+	MaxWords        int64  `json:"max_words"`
+	NeedsAttention  bool   `json:"needs_attention"`
+	Pattern         string `json:"pattern"`
+	Limit           int64  `json:"limit"`
+	Offset          int64  `json:"offset"`
+}
+
+func (q *Queries) ListLinksFiltered(ctx context.Context, arg ListLinksFilteredParams) ([]Link, error) {
+	rows, err := q.db.QueryContext(ctx, listLinksFiltered,
+		arg.Domain,
+		arg.Source,
+		arg.Status,
+		arg.IncludeArchived,
+		arg.MinWords,
+		arg.MaxWords,
+		arg.NeedsAttention,
+		arg.Pattern,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Link{}
+	for rows.Next() {
+		var i Link
+		if err := rows.Scan(
+			&i.ID,
+			&i.Url,
+			&i.Title,
+			&i.Content,
+			&i.Summary,
+			&i.Status,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.FetchedAt,
+			&i.SummarizedAt,
+			&i.DeletedAt,
+			&i.Domain,
+			&i.ContentLength,
+			&i.AutoRefresh,
+			&i.NeedsAttention,
+			&i.CustomTitle,
+			&i.Source,
+			&i.ExpiresAt,
+			&i.SummaryShort,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRecentlyOpenedLinks = `-- name: ListRecentlyOpenedLinks :many
+SELECT l.id, l.url, l.title, l.content, l.summary, l.status, l.created_at, l.updated_at, l.fetched_at, l.summarized_at, l.deleted_at, l.domain, l.content_length, l.auto_refresh, l.needs_attention, l.custom_title, l.source, l.expires_at, l.summary_short FROM links l
+JOIN (
+    SELECT link_id, MAX(id) AS last_open FROM link_opens
+    GROUP BY link_id
+) o ON l.id = o.link_id
+WHERE l.deleted_at IS NULL
+ORDER BY o.last_open DESC
+LIMIT ?
+`
+
+func (q *Queries) ListRecentlyOpenedLinks(ctx context.Context, limit int64) ([]Link, error) {
+	rows, err := q.db.QueryContext(ctx, listRecentlyOpenedLinks, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Link{}
+	for rows.Next() {
+		var i Link
+		if err := rows.Scan(
+			&i.ID,
+			&i.Url,
+			&i.Title,
+			&i.Content,
+			&i.Summary,
+			&i.Status,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.FetchedAt,
+			&i.SummarizedAt,
+			&i.DeletedAt,
+			&i.Domain,
+			&i.ContentLength,
+			&i.AutoRefresh,
+			&i.NeedsAttention,
+			&i.CustomTitle,
+			&i.Source,
+			&i.ExpiresAt,
+			&i.SummaryShort,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRelatedTags = `-- name: ListRelatedTags :many
+SELECT t.id, t.name, COUNT(*) AS count FROM link_tags a
+JOIN link_tags b ON b.link_id = a.link_id AND b.tag_id != a.tag_id
+JOIN tags t ON t.id = b.tag_id
+JOIN links l ON l.id = a.link_id AND l.deleted_at IS NULL
+WHERE a.tag_id = ?
+GROUP BY t.id, t.name
+ORDER BY count DESC, t.name
+LIMIT ?
+`
+
+type ListRelatedTagsParams struct {
+	TagID int64 `json:"tag_id"`
+	Limit int64 `json:"limit"`
+}
+
+type ListRelatedTagsRow struct {
+	ID    int64  `json:"id"`
+	Name  string `json:"name"`
+	Count int64  `json:"count"`
+}
+
+func (q *Queries) ListRelatedTags(ctx context.Context, arg ListRelatedTagsParams) ([]ListRelatedTagsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listRelatedTags, arg.TagID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListRelatedTagsRow{}
+	for rows.Next() {
+		var i ListRelatedTagsRow
+		if err := rows.Scan(&i.ID, &i.Name, &i.Count); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listStaleLinks = `-- name: ListStaleLinks :many
+SELECT id, url, title, content, summary, status, created_at, updated_at, fetched_at, summarized_at, deleted_at, domain, content_length, auto_refresh, needs_attention, custom_title, source, expires_at, summary_short FROM links
+WHERE deleted_at IS NULL
+  AND (fetched_at IS NULL OR fetched_at < datetime('now', ?1))
+ORDER BY fetched_at
+LIMIT ?2
+`
+
+type ListStaleLinksParams struct {
+	Age   interface{} `json:"age"`
+	Limit int64       `json:"limit"`
+}
+
+func (q *Queries) ListStaleLinks(ctx context.Context, arg ListStaleLinksParams) ([]Link, error) {
+	rows, err := q.db.QueryContext(ctx, listStaleLinks, arg.Age, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Link{}
+	for rows.Next() {
+		var i Link
+		if err := rows.Scan(
+			&i.ID,
+			&i.Url,
+			&i.Title,
+			&i.Content,
+			&i.Summary,
+			&i.Status,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.FetchedAt,
+			&i.SummarizedAt,
+			&i.DeletedAt,
+			&i.Domain,
+			&i.ContentLength,
+			&i.AutoRefresh,
+			&i.NeedsAttention,
+			&i.CustomTitle,
+			&i.Source,
+			&i.ExpiresAt,
+			&i.SummaryShort,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTags = `-- name: ListTags :many
+SELECT id, name, created_at FROM tags
+ORDER BY name
+`
+
+func (q *Queries) ListTags(ctx context.Context) ([]Tag, error) {
+	rows, err := q.db.QueryContext(ctx, listTags)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Tag{}
+	for rows.Next() {
+		var i Tag
+		if err := rows.Scan(&i.ID, &i.Name, &i.CreatedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTasks = `-- name: ListTasks :many
+SELECT id, name, description, completed, created_at, updated_at FROM tasks
+ORDER BY created_at DESC
+`
+
+func (q *Queries) ListTasks(ctx context.Context) ([]Task, error) {
+	rows, err := q.db.QueryContext(ctx, listTasks)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Task{}
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.Completed,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUnarchivedLinks = `-- name: ListUnarchivedLinks :many
+SELECT id, url, title, content, summary, status, created_at, updated_at, fetched_at, summarized_at, deleted_at, domain, content_length, auto_refresh, needs_attention, custom_title, source, expires_at, summary_short FROM links
+WHERE deleted_at IS NULL AND status != 'archived'
+ORDER BY created_at DESC
+LIMIT ? OFFSET ?
+`
+
+type ListUnarchivedLinksParams struct {
+	Limit  int64 `json:"limit"`
+	Offset int64 `json:"offset"`
+}
+
+func (q *Queries) ListUnarchivedLinks(ctx context.Context, arg ListUnarchivedLinksParams) ([]Link, error) {
+	rows, err := q.db.QueryContext(ctx, listUnarchivedLinks, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Link{}
+	for rows.Next() {
+		var i Link
+		if err := rows.Scan(
+			&i.ID,
+			&i.Url,
+			&i.Title,
+			&i.Content,
+			&i.Summary,
+			&i.Status,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.FetchedAt,
+			&i.SummarizedAt,
+			&i.DeletedAt,
+			&i.Domain,
+			&i.ContentLength,
+			&i.AutoRefresh,
+			&i.NeedsAttention,
+			&i.CustomTitle,
+			&i.Source,
+			&i.ExpiresAt,
+			&i.SummaryShort,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markTaskLinksOpened = `-- name: MarkTaskLinksOpened :exec
+UPDATE link_tasks
+SET opened = 1
+WHERE task_id = ?
+`
+
+func (q *Queries) MarkTaskLinksOpened(ctx context.Context, taskID int64) error {
+	_, err := q.db.ExecContext(ctx, markTaskLinksOpened, taskID)
+	return err
+}
+
+const mergeLinkActivities = `-- name: MergeLinkActivities :exec
+INSERT OR IGNORE INTO link_activities (link_id, activity_id, created_at)
+SELECT ?1, activity_id, created_at FROM link_activities
+WHERE link_id = ?2
+`
+
+type MergeLinkActivitiesParams struct {
+	ToID   int64 `json:"to_id"`
+	FromID int64 `json:"from_id"`
+}
+
+func (q *Queries) MergeLinkActivities(ctx context.Context, arg MergeLinkActivitiesParams) error {
+	_, err := q.db.ExecContext(ctx, mergeLinkActivities, arg.ToID, arg.FromID)
+	return err
+}
+
+const mergeLinkCategories = `-- name: MergeLinkCategories :exec
+INSERT OR IGNORE INTO link_categories (link_id, category_id, created_at)
+SELECT ?1, category_id, created_at FROM link_categories
+WHERE link_id = ?2
+`
+
+type MergeLinkCategoriesParams struct {
+	ToID   int64 `json:"to_id"`
+	FromID int64 `json:"from_id"`
+}
+
+func (q *Queries) MergeLinkCategories(ctx context.Context, arg MergeLinkCategoriesParams) error {
+	_, err := q.db.ExecContext(ctx, mergeLinkCategories, arg.ToID, arg.FromID)
+	return err
+}
+
+const mergeLinkTags = `-- name: MergeLinkTags :exec
+INSERT OR IGNORE INTO link_tags (link_id, tag_id, created_at)
+SELECT ?1, tag_id, created_at FROM link_tags
+WHERE link_id = ?2
+`
+
+type MergeLinkTagsParams struct {
+	ToID   int64 `json:"to_id"`
+	FromID int64 `json:"from_id"`
+}
+
+func (q *Queries) MergeLinkTags(ctx context.Context, arg MergeLinkTagsParams) error {
+	_, err := q.db.ExecContext(ctx, mergeLinkTags, arg.ToID, arg.FromID)
+	return err
+}
+
+const mergeLinkTasks = `-- name: MergeLinkTasks :exec
+INSERT OR IGNORE INTO link_tasks (link_id, task_id, created_at, opened, sort_order)
+SELECT ?1, task_id, created_at, opened, sort_order FROM link_tasks
+WHERE link_id = ?2
+`
+
+type MergeLinkTasksParams struct {
+	ToID   int64 `json:"to_id"`
+	FromID int64 `json:"from_id"`
+}
+
+func (q *Queries) MergeLinkTasks(ctx context.Context, arg MergeLinkTasksParams) error {
+	_, err := q.db.ExecContext(ctx, mergeLinkTasks, arg.ToID, arg.FromID)
+	return err
+}
+
+const moveLinkOpens = `-- name: MoveLinkOpens :exec
+UPDATE link_opens
+SET link_id = ?1
+WHERE link_id = ?2
+`
+
+type MoveLinkOpensParams struct {
+	ToID   int64 `json:"to_id"`
+	FromID int64 `json:"from_id"`
+}
+
+func (q *Queries) MoveLinkOpens(ctx context.Context, arg MoveLinkOpensParams) error {
+	_, err := q.db.ExecContext(ctx, moveLinkOpens, arg.ToID, arg.FromID)
+	return err
+}
+
+const purgeDeletedLinks = `-- name: PurgeDeletedLinks :execrows
+DELETE FROM links
+WHERE deleted_at IS NOT NULL
+`
+
+func (q *Queries) PurgeDeletedLinks(ctx context.Context) (int64, error) {
+	result, err := q.db.ExecContext(ctx, purgeDeletedLinks)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const purgeLink = `-- name: PurgeLink :exec
+DELETE FROM links
+WHERE id = ? AND deleted_at IS NOT NULL
+`
+
+func (q *Queries) PurgeLink(ctx context.Context, id int64) error {
+	_, err := q.db.ExecContext(ctx, purgeLink, id)
+	return err
+}
+
+const recordLinkOpen = `-- name: RecordLinkOpen :exec
+INSERT INTO link_opens (link_id)
+VALUES (?)
+`
+
+func (q *Queries) RecordLinkOpen(ctx context.Context, linkID int64) error {
+	_, err := q.db.ExecContext(ctx, recordLinkOpen, linkID)
+	return err
+}
+
+const restoreLink = `-- name: RestoreLink :exec
+UPDATE links
+SET deleted_at = NULL,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = ?
+`
+
+func (q *Queries) RestoreLink(ctx context.Context, id int64) error {
+	_, err := q.db.ExecContext(ctx, restoreLink, id)
+	return err
+}
+
+const searchLinks = `-- name: SearchLinks :many
+SELECT id, url, title, content, summary, status, created_at, updated_at, fetched_at, summarized_at, deleted_at, domain, content_length, auto_refresh, needs_attention, custom_title, source, expires_at, summary_short FROM links
+WHERE deleted_at IS NULL AND (
+    url LIKE ? OR
+    title LIKE ? OR
+    content LIKE ? OR
+    summary LIKE ?
+)
+ORDER BY created_at DESC
+LIMIT ? OFFSET ?
+`
+
+type SearchLinksParams struct {
+	Url     string         `json:"url"`
+	Title   sql.NullString `json:"title"`
+	Content sql.NullString `json:"content"`
+	Summary sql.NullString `json:"summary"`
+	Limit   int64          `json:"limit"`
+	Offset  int64          `json:"offset"`
+}
+
+func (q *Queries) SearchLinks(ctx context.Context, arg SearchLinksParams) ([]Link, error) {
+	rows, err := q.db.QueryContext(ctx, searchLinks,
+		arg.Url,
+		arg.Title,
+		arg.Content,
+		arg.Summary,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Link{}
+	for rows.Next() {
+		var i Link
+		if err := rows.Scan(
+			&i.ID,
+			&i.Url,
+			&i.Title,
+			&i.Content,
+			&i.Summary,
+			&i.Status,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.FetchedAt,
+			&i.SummarizedAt,
+			&i.DeletedAt,
+			&i.Domain,
+			&i.ContentLength,
+			&i.AutoRefresh,
+			&i.NeedsAttention,
+			&i.CustomTitle,
+			&i.Source,
+			&i.ExpiresAt,
+			&i.SummaryShort,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setLinkAutoRefresh = `-- name: SetLinkAutoRefresh :exec
+UPDATE links
+SET auto_refresh = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = ?
+`
+
+type SetLinkAutoRefreshParams struct {
+	AutoRefresh bool  `json:"auto_refresh"`
+	ID          int64 `json:"id"`
+}
+
+func (q *Queries) SetLinkAutoRefresh(ctx context.Context, arg SetLinkAutoRefreshParams) error {
+	_, err := q.db.ExecContext(ctx, setLinkAutoRefresh, arg.AutoRefresh, arg.ID)
+	return err
+}
+
+const setLinkContent = `-- name: SetLinkContent :exec
+UPDATE links
+SET content = ?,
+    content_length = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = ?
+`
+
+type SetLinkContentParams struct {
+	Content       sql.NullString `json:"content"`
+	ContentLength int64          `json:"content_length"`
+	ID            int64          `json:"id"`
+}
+
+func (q *Queries) SetLinkContent(ctx context.Context, arg SetLinkContentParams) error {
+	_, err := q.db.ExecContext(ctx, setLinkContent, arg.Content, arg.ContentLength, arg.ID)
+	return err
+}
+
+const setLinkNeedsAttention = `-- name: SetLinkNeedsAttention :exec
+UPDATE links
+SET needs_attention = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = ?
+`
+
+type SetLinkNeedsAttentionParams struct {
+	NeedsAttention bool  `json:"needs_attention"`
+	ID             int64 `json:"id"`
+}
+
+func (q *Queries) SetLinkNeedsAttention(ctx context.Context, arg SetLinkNeedsAttentionParams) error {
+	_, err := q.db.ExecContext(ctx, setLinkNeedsAttention, arg.NeedsAttention, arg.ID)
+	return err
+}
+
+const setLinkExpiresAt = `-- name: SetLinkExpiresAt :exec
+UPDATE links
+SET expires_at = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = ?
+`
+
+type SetLinkExpiresAtParams struct {
+	ExpiresAt sql.NullTime `json:"expires_at"`
+	ID        int64        `json:"id"`
+}
+
+func (q *Queries) SetLinkExpiresAt(ctx context.Context, arg SetLinkExpiresAtParams) error {
+	_, err := q.db.ExecContext(ctx, setLinkExpiresAt, arg.ExpiresAt, arg.ID)
+	return err
+}
+
+const setLinkSummary = `-- name: SetLinkSummary :exec
+UPDATE links
+SET summary = ?,
+    summarized_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = ?
+`
+
+type SetLinkSummaryParams struct {
+	Summary sql.NullString `json:"summary"`
+	ID      int64          `json:"id"`
+}
+
+func (q *Queries) SetLinkSummary(ctx context.Context, arg SetLinkSummaryParams) error {
+	_, err := q.db.ExecContext(ctx, setLinkSummary, arg.Summary, arg.ID)
+	return err
+}
+
+const setLinkSummaryShort = `-- name: SetLinkSummaryShort :exec
+UPDATE links
+SET summary_short = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = ?
+`
+
+type SetLinkSummaryShortParams struct {
+	SummaryShort sql.NullString `json:"summary_short"`
+	ID           int64          `json:"id"`
+}
+
+func (q *Queries) SetLinkSummaryShort(ctx context.Context, arg SetLinkSummaryShortParams) error {
+	_, err := q.db.ExecContext(ctx, setLinkSummaryShort, arg.SummaryShort, arg.ID)
+	return err
+}
+
+const setLinkTitle = `-- name: SetLinkTitle :exec
+UPDATE links
+SET title = ?,
+    custom_title = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = ?
+`
+
+type SetLinkTitleParams struct {
+	Title       sql.NullString `json:"title"`
+	CustomTitle bool           `json:"custom_title"`
+	ID          int64          `json:"id"`
+}
+
+func (q *Queries) SetLinkTitle(ctx context.Context, arg SetLinkTitleParams) error {
+	_, err := q.db.ExecContext(ctx, setLinkTitle, arg.Title, arg.CustomTitle, arg.ID)
+	return err
+}
+
+const setSetting = `-- name: SetSetting :exec
+INSERT INTO settings (key, value)
+VALUES (?, ?)
+ON CONFLICT (key) DO UPDATE SET value = excluded.value
+`
+
+type SetSettingParams struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+func (q *Queries) SetSetting(ctx context.Context, arg SetSettingParams) error {
+	_, err := q.db.ExecContext(ctx, setSetting, arg.Key, arg.Value)
+	return err
+}
+
+const sumLLMUsageSince = `-- name: SumLLMUsageSince :one
+SELECT COUNT(*) AS calls,
+       CAST(COALESCE(SUM(input_tokens), 0) AS INTEGER) AS input_tokens,
+       CAST(COALESCE(SUM(output_tokens), 0) AS INTEGER) AS output_tokens,
+       CAST(COALESCE(SUM(cost_usd), 0) AS REAL) AS cost_usd
+FROM llm_usage
+WHERE created_at >= datetime('now', 'localtime', ?1, 'utc')
+`
+
+type SumLLMUsageSinceRow struct {
+	Calls        int64   `json:"calls"`
+	InputTokens  int64   `json:"input_tokens"`
+	OutputTokens int64   `json:"output_tokens"`
+	CostUsd      float64 `json:"cost_usd"`
+}
+
+func (q *Queries) SumLLMUsageSince(ctx context.Context, start interface{}) (SumLLMUsageSinceRow, error) {
+	row := q.db.QueryRowContext(ctx, sumLLMUsageSince, start)
+	var i SumLLMUsageSinceRow
+	err := row.Scan(
+		&i.Calls,
+		&i.InputTokens,
+		&i.OutputTokens,
+		&i.CostUsd,
+	)
+	return i, err
+}
+
+const unlinkActivity = `-- name: UnlinkActivity :exec
+DELETE FROM link_activities WHERE link_id = ? AND activity_id = ?
+`
 
 type UnlinkActivityParams struct {
 	LinkID     int64 `json:"link_id"`
@@ -1102,23 +2656,59 @@ func (q *Queries) UpdateActivity(ctx context.Context, arg UpdateActivityParams)
 	return i, err
 }
 
+const updateCategory = `-- name: UpdateCategory :one
+UPDATE categories
+SET name = ?,
+    description = ?,
+    default_tags = ?
+WHERE id = ?
+RETURNING id, name, description, created_at, default_tags
+`
+
+type UpdateCategoryParams struct {
+	Name        string         `json:"name"`
+	Description sql.NullString `json:"description"`
+	DefaultTags string         `json:"default_tags"`
+	ID          int64          `json:"id"`
+}
+
+func (q *Queries) UpdateCategory(ctx context.Context, arg UpdateCategoryParams) (Category, error) {
+	row := q.db.QueryRowContext(ctx, updateCategory,
+		arg.Name,
+		arg.Description,
+		arg.DefaultTags,
+		arg.ID,
+	)
+	var i Category
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.CreatedAt,
+		&i.DefaultTags,
+	)
+	return i, err
+}
+
 const updateLink = `-- name: UpdateLink :one
 UPDATE links
 SET title = ?,
     content = ?,
     summary = ?,
     status = ?,
+    content_length = ?,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = ?
-RETURNING id, url, title, content, summary, status, created_at, updated_at, fetched_at, summarized_at
+RETURNING id, url, title, content, summary, status, created_at, updated_at, fetched_at, summarized_at, deleted_at, domain, content_length, auto_refresh, needs_attention, custom_title, source, expires_at, summary_short
 `
 
 type UpdateLinkParams struct {
-	Title   sql.NullString `json:"title"`
-	Content sql.NullString `json:"content"`
-	Summary sql.NullString `json:"summary"`
-	Status  string         `json:"status"`
-	ID      int64          `json:"id"`
+	Title         sql.NullString `json:"title"`
+	Content       sql.NullString `json:"content"`
+	Summary       sql.NullString `json:"summary"`
+	Status        string         `json:"status"`
+	ContentLength int64          `json:"content_length"`
+	ID            int64          `json:"id"`
 }
 
 func (q *Queries) UpdateLink(ctx context.Context, arg UpdateLinkParams) (Link, error) {
@@ -1127,6 +2717,7 @@ func (q *Queries) UpdateLink(ctx context.Context, arg UpdateLinkParams) (Link, e
 		arg.Content,
 		arg.Summary,
 		arg.Status,
+		arg.ContentLength,
 		arg.ID,
 	)
 	var i Link
@@ -1141,10 +2732,36 @@ func (q *Queries) UpdateLink(ctx context.Context, arg UpdateLinkParams) (Link, e
 		&i.UpdatedAt,
 		&i.FetchedAt,
 		&i.SummarizedAt,
+		&i.DeletedAt,
+		&i.Domain,
+		&i.ContentLength,
+		&i.AutoRefresh,
+		&i.NeedsAttention,
+		&i.CustomTitle,
+		&i.Source,
+		&i.ExpiresAt,
+		&i.SummaryShort,
 	)
 	return i, err
 }
 
+const updateLinkCreatedAt = `-- name: UpdateLinkCreatedAt :exec
+UPDATE links
+SET created_at = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = ?
+`
+
+type UpdateLinkCreatedAtParams struct {
+	CreatedAt time.Time `json:"created_at"`
+	ID        int64     `json:"id"`
+}
+
+func (q *Queries) UpdateLinkCreatedAt(ctx context.Context, arg UpdateLinkCreatedAtParams) error {
+	_, err := q.db.ExecContext(ctx, updateLinkCreatedAt, arg.CreatedAt, arg.ID)
+	return err
+}
+
 const updateLinkFetchedAt = `-- name: UpdateLinkFetchedAt :exec
 UPDATE links
 SET fetched_at = CURRENT_TIMESTAMP,
@@ -1157,6 +2774,23 @@ func (q *Queries) UpdateLinkFetchedAt(ctx context.Context, id int64) error {
 	return err
 }
 
+const updateLinkStatus = `-- name: UpdateLinkStatus :exec
+UPDATE links
+SET status = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = ?
+`
+
+type UpdateLinkStatusParams struct {
+	Status string `json:"status"`
+	ID     int64  `json:"id"`
+}
+
+func (q *Queries) UpdateLinkStatus(ctx context.Context, arg UpdateLinkStatusParams) error {
+	_, err := q.db.ExecContext(ctx, updateLinkStatus, arg.Status, arg.ID)
+	return err
+}
+
 const updateLinkSummarizedAt = `-- name: UpdateLinkSummarizedAt :exec
 UPDATE links
 SET summarized_at = CURRENT_TIMESTAMP,
@@ -1169,6 +2803,48 @@ func (q *Queries) UpdateLinkSummarizedAt(ctx context.Context, id int64) error {
 	return err
 }
 
+const updateLinksStatusForCategory = `-- name: UpdateLinksStatusForCategory :execrows
+UPDATE links
+SET status = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id IN (SELECT link_id FROM link_categories WHERE category_id = ?)
+  AND deleted_at IS NULL
+`
+
+type UpdateLinksStatusForCategoryParams struct {
+	Status     string `json:"status"`
+	CategoryID int64  `json:"category_id"`
+}
+
+func (q *Queries) UpdateLinksStatusForCategory(ctx context.Context, arg UpdateLinksStatusForCategoryParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateLinksStatusForCategory, arg.Status, arg.CategoryID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const updateLinksStatusForTag = `-- name: UpdateLinksStatusForTag :execrows
+UPDATE links
+SET status = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id IN (SELECT link_id FROM link_tags WHERE tag_id = ?)
+  AND deleted_at IS NULL
+`
+
+type UpdateLinksStatusForTagParams struct {
+	Status string `json:"status"`
+	TagID  int64  `json:"tag_id"`
+}
+
+func (q *Queries) UpdateLinksStatusForTag(ctx context.Context, arg UpdateLinksStatusForTagParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateLinksStatusForTag, arg.Status, arg.TagID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const updateTask = `-- name: UpdateTask :one
 UPDATE tasks
 SET name = ?,
@@ -1204,3 +2880,36 @@ func (q *Queries) UpdateTask(ctx context.Context, arg UpdateTaskParams) (Task, e
 	)
 	return i, err
 }
+
+const updateTaskLinkOrder = `-- name: UpdateTaskLinkOrder :exec
+UPDATE link_tasks
+SET sort_order = ?
+WHERE link_id = ? AND task_id = ?
+`
+
+type UpdateTaskLinkOrderParams struct {
+	SortOrder int64 `json:"sort_order"`
+	LinkID    int64 `json:"link_id"`
+	TaskID    int64 `json:"task_id"`
+}
+
+func (q *Queries) UpdateTaskLinkOrder(ctx context.Context, arg UpdateTaskLinkOrderParams) error {
+	_, err := q.db.ExecContext(ctx, updateTaskLinkOrder, arg.SortOrder, arg.LinkID, arg.TaskID)
+	return err
+}
+
+const upsertLinkArchive = `-- name: UpsertLinkArchive :exec
+INSERT INTO link_archives (link_id, html)
+VALUES (?, ?)
+ON CONFLICT (link_id) DO UPDATE SET html = excluded.html, archived_at = CURRENT_TIMESTAMP
+`
+
+type UpsertLinkArchiveParams struct {
+	LinkID int64  `json:"link_id"`
+	Html   string `json:"html"`
+}
+
+func (q *Queries) UpsertLinkArchive(ctx context.Context, arg UpsertLinkArchiveParams) error {
+	_, err := q.db.ExecContext(ctx, upsertLinkArchive, arg.LinkID, arg.Html)
+	return err
+}
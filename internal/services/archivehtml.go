@@ -0,0 +1,138 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+)
+
+// ArchivePage is a saved link as written by WriteArchiveHTML.
+type ArchivePage struct {
+	URL        string
+	Title      string
+	Summary    string
+	Content    string // the stored page content, as markdown
+	Status     string
+	Tags       []string
+	Categories []string
+	Added      time.Time
+	Fetched    time.Time // zero if the page was never fetched
+}
+
+// archiveMarkdown renders stored content. Its defaults omit raw HTML and
+// unsafe link targets, so an archived page never runs script.
+var archiveMarkdown = goldmark.New(goldmark.WithExtensions(extension.GFM))
+
+var archiveTemplate = template.Must(template.New("archive").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+body { max-width: 42rem; margin: 2rem auto; padding: 0 1rem; font: 17px/1.6 Georgia, serif; color: #222; background: #fdfdfb; }
+header { border-bottom: 1px solid #ddd; margin-bottom: 2rem; padding-bottom: 1rem; font-family: system-ui, sans-serif; font-size: 14px; color: #555; }
+header h1 { font-family: Georgia, serif; font-size: 28px; line-height: 1.25; color: #111; margin: 0 0 .5rem; }
+header p { margin: .25rem 0; }
+.summary { font-style: italic; color: #333; font-size: 16px; margin-top: 1rem; }
+a { color: #1a5fb4; overflow-wrap: anywhere; }
+img { max-width: 100%; height: auto; }
+pre { background: #f3f3f0; padding: .75rem; overflow-x: auto; font-size: 14px; }
+code { font-family: ui-monospace, monospace; }
+blockquote { border-left: 3px solid #ccc; margin-left: 0; padding-left: 1rem; color: #444; }
+table { border-collapse: collapse; }
+td, th { border: 1px solid #ccc; padding: .25rem .5rem; }
+.empty { color: #888; font-style: italic; }
+</style>
+</head>
+<body>
+<header>
+<h1>{{.Title}}</h1>
+<p><a href="{{.URL}}">{{.URL}}</a></p>
+<p>Added {{.Added}}{{if .Fetched}} · fetched {{.Fetched}}{{end}} · {{.Status}}</p>
+{{- if .Categories}}
+<p>Categories: {{.Categories}}</p>
+{{- end}}
+{{- if .Tags}}
+<p>Tags: {{.Tags}}</p>
+{{- end}}
+{{- if .Summary}}
+<p class="summary">{{.Summary}}</p>
+{{- end}}
+</header>
+<main>
+{{if .Body}}{{.Body}}{{else}}<p class="empty">No content was saved for this link.</p>{{end}}
+</main>
+</body>
+</html>
+`))
+
+// WriteArchiveHTML writes p as a single HTML file that any browser can open
+// offline: a header with the link's details, then its content rendered from
+// markdown, with the styles inline. Images in the content still load from
+// their original sites.
+func WriteArchiveHTML(w io.Writer, p ArchivePage) error {
+	var body bytes.Buffer
+	if err := archiveMarkdown.Convert([]byte(p.Content), &body); err != nil {
+		return fmt.Errorf("failed to render content: %w", err)
+	}
+
+	title := p.Title
+	if title == "" {
+		title = p.URL
+	}
+	var fetched string
+	if !p.Fetched.IsZero() {
+		fetched = p.Fetched.Local().Format("2006-01-02")
+	}
+	return archiveTemplate.Execute(w, struct {
+		Title, URL, Summary, Status string
+		Added, Fetched              string
+		Tags, Categories            string
+		Body                        template.HTML
+	}{
+		Title:      title,
+		URL:        p.URL,
+		Summary:    p.Summary,
+		Status:     p.Status,
+		Added:      p.Added.Local().Format("2006-01-02"),
+		Fetched:    fetched,
+		Tags:       strings.Join(p.Tags, ", "),
+		Categories: strings.Join(p.Categories, ", "),
+		Body:       template.HTML(body.String()),
+	})
+}
+
+// ArchiveFilename names the archive file for link id, e.g.
+// "42-why-go-has-no-exceptions.html". The ID keeps names unique when titles
+// repeat or have no usable characters.
+func ArchiveFilename(id int64, title string) string {
+	var slug strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(title) {
+		switch {
+		case r <= unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			if dash && slug.Len() > 0 {
+				slug.WriteByte('-')
+			}
+			slug.WriteRune(r)
+			dash = false
+		default:
+			dash = true
+		}
+		if slug.Len() >= 60 {
+			break
+		}
+	}
+	if slug.Len() == 0 {
+		return fmt.Sprintf("%d.html", id)
+	}
+	return fmt.Sprintf("%d-%s.html", id, slug.String())
+}
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// CategoryRule files links matching Pattern under Category without asking the
+// LLM. Field says what Pattern is matched against:
+//
+//   - "site": the link's domain, which must be Pattern or a subdomain of it;
+//     a pattern with * is a glob instead, so *.edu matches any .edu site
+//   - "url": the URL, which must contain Pattern
+//   - "title": the title, which must contain Pattern
+//
+// Matching ignores case.
+type CategoryRule struct {
+	Field    string
+	Pattern  string
+	Category string
+}
+
+// CategoryRules are tried in order; the first that matches wins.
+type CategoryRules []CategoryRule
+
+// ParseCategoryRules parses rules written pattern=Category and separated by
+// semicolons, e.g. "github.com=Code; *.edu=Research; url:recipe=Cooking".
+// A pattern may start with site:, url:, or title:; a bare pattern is a site.
+func ParseCategoryRules(s string) (CategoryRules, error) {
+	var rules CategoryRules
+	for _, raw := range strings.Split(s, ";") {
+		if strings.TrimSpace(raw) == "" {
+			continue
+		}
+		pattern, category, ok := strings.Cut(raw, "=")
+		pattern = strings.ToLower(strings.TrimSpace(pattern))
+		category = strings.TrimSpace(category)
+		if !ok || pattern == "" || category == "" {
+			return nil, fmt.Errorf("invalid category rule %q: must be pattern=category", strings.TrimSpace(raw))
+		}
+		rule := CategoryRule{Field: "site", Pattern: pattern, Category: category}
+		if field, value, ok := strings.Cut(pattern, ":"); ok {
+			switch field {
+			case "site", "url", "title":
+				rule.Field, rule.Pattern = field, strings.TrimSpace(value)
+			default:
+				return nil, fmt.Errorf("invalid category rule %q: %q must be site, url, or title", strings.TrimSpace(raw), field)
+			}
+		}
+		if rule.Pattern == "" {
+			return nil, fmt.Errorf("invalid category rule %q: empty pattern", strings.TrimSpace(raw))
+		}
+		if rule.Field == "site" {
+			rule.Pattern = strings.TrimPrefix(rule.Pattern, "www.")
+			if _, err := path.Match(rule.Pattern, ""); err != nil {
+				return nil, fmt.Errorf("invalid category rule %q: %w", strings.TrimSpace(raw), err)
+			}
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// Category returns the category of the first rule matching a link with url
+// and title, or "" if none does.
+func (rs CategoryRules) Category(url, title string) string {
+	domain := DomainFromURL(url)
+	for _, r := range rs {
+		if r.matches(domain, strings.ToLower(url), strings.ToLower(title)) {
+			return r.Category
+		}
+	}
+	return ""
+}
+
+func (r CategoryRule) matches(domain, url, title string) bool {
+	switch r.Field {
+	case "url":
+		return strings.Contains(url, r.Pattern)
+	case "title":
+		return strings.Contains(title, r.Pattern)
+	}
+	if strings.Contains(r.Pattern, "*") {
+		ok, _ := path.Match(r.Pattern, domain)
+		return ok
+	}
+	return domain == r.Pattern || strings.HasSuffix(domain, "."+r.Pattern)
+}
@@ -0,0 +1,51 @@
+package services
+
+import "github.com/sashabaranov/go-openai"
+
+// LLMModel is the OpenAI model used for summaries and suggestions.
+const LLMModel = openai.GPT4oMini
+
+// GPT-4o-mini pricing in US dollars per million tokens.
+const (
+	inputPricePerMillion  = 0.15
+	outputPricePerMillion = 0.60
+)
+
+// LLMCost returns the price in US dollars of the given token usage.
+func LLMCost(inputTokens, outputTokens int) float64 {
+	return float64(inputTokens)*inputPricePerMillion/1_000_000.0 +
+		float64(outputTokens)*outputPricePerMillion/1_000_000.0
+}
+
+// EstimateTokens approximates the number of tokens in chars characters of
+// English text, at about four characters per token.
+func EstimateTokens(chars int) int {
+	return (chars + 3) / 4
+}
+
+// EstimateSummarize predicts the tokens Summarize would use for a page whose
+// text is textLen characters long. Output is counted at the request's limit,
+// so the estimate errs high. Text beyond the prompt limit is never sent, so
+// callers that have not fetched a page can pass math.MaxInt for the most it
+// could cost.
+func EstimateSummarize(title string, textLen int) (inputTokens, outputTokens int) {
+	return estimateInput(summaryMessages(title, "", TruncateWords), textLen, summaryMaxChars), summaryMaxTokens
+}
+
+// EstimateSuggestMetadata is EstimateSummarize for SuggestMetadata.
+func EstimateSuggestMetadata(title string, textLen int) (inputTokens, outputTokens int) {
+	return estimateInput(metadataMessages(title, "", TruncateWords), textLen, metadataMaxChars), metadataMaxTokens
+}
+
+// estimateInput counts the tokens of a prompt built without its page text,
+// plus textLen characters of text truncated as the prompt builders do.
+func estimateInput(messages []openai.ChatCompletionMessage, textLen, maxChars int) int {
+	chars := 0
+	for _, m := range messages {
+		chars += len(m.Content)
+	}
+	if textLen > maxChars {
+		textLen = maxChars + len("...")
+	}
+	return EstimateTokens(chars + textLen)
+}
@@ -0,0 +1,50 @@
+package services
+
+import (
+	"net/url"
+	"strings"
+)
+
+// trackingParams are query parameters that only record how a visitor got to
+// a page, so URLs differing in them point at the same article.
+var trackingParams = map[string]bool{
+	"fbclid":  true,
+	"gclid":   true,
+	"dclid":   true,
+	"msclkid": true,
+	"igshid":  true,
+	"mc_cid":  true,
+	"mc_eid":  true,
+	"ref_src": true,
+}
+
+// DedupeKey returns the form of rawURL that 'lm dedupe' groups links by: the
+// same for http and https, with or without "www.", a default port, a
+// trailing slash, a #fragment, tracking parameters such as utm_source, or
+// the query parameters in another order. It is a key for comparing URLs,
+// not a URL to fetch. A URL that cannot be parsed is its own key.
+func DedupeKey(rawURL string) string {
+	rawURL = strings.TrimSpace(rawURL)
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return rawURL
+	}
+
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	if port := u.Port(); port != "" && port != "80" && port != "443" {
+		host += ":" + port
+	}
+
+	query := u.Query()
+	for name := range query {
+		if strings.HasPrefix(strings.ToLower(name), "utm_") || trackingParams[strings.ToLower(name)] {
+			query.Del(name)
+		}
+	}
+
+	key := host + strings.TrimRight(u.EscapedPath(), "/")
+	if len(query) > 0 {
+		key += "?" + query.Encode()
+	}
+	return key
+}
@@ -0,0 +1,35 @@
+package services
+
+// The category and tag new links get when nothing else picks one, unless
+// DEFAULT_CATEGORY or DEFAULT_TAG says otherwise.
+const (
+	DefaultCategory = "General"
+	DefaultTag      = "uncategorized"
+)
+
+// LinkDefaults are what a newly added link is filed under when neither the
+// user, a category rule, nor the LLM gives it a category or any tags.
+type LinkDefaults struct {
+	Category string // "" leaves such links uncategorized
+	Tag      string // "" leaves them untagged
+}
+
+// StandardLinkDefaults files otherwise unfiled links under DefaultCategory
+// and DefaultTag.
+var StandardLinkDefaults = LinkDefaults{Category: DefaultCategory, Tag: DefaultTag}
+
+// CategoryOr returns category, or the default category if it is "".
+func (d LinkDefaults) CategoryOr(category string) string {
+	if category != "" {
+		return category
+	}
+	return d.Category
+}
+
+// TagsOr returns tags, or the default tag alone if there are none.
+func (d LinkDefaults) TagsOr(tags []string) []string {
+	if len(tags) > 0 || d.Tag == "" {
+		return tags
+	}
+	return []string{d.Tag}
+}
@@ -0,0 +1,17 @@
+package services
+
+import (
+	"net/url"
+	"strings"
+)
+
+// DomainFromURL returns the lowercased host of rawURL without any port or
+// leading "www.", or "" if the URL cannot be parsed.
+func DomainFromURL(rawURL string) string {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return ""
+	}
+	host := strings.ToLower(u.Hostname())
+	return strings.TrimPrefix(host, "www.")
+}
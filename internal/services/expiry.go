@@ -0,0 +1,44 @@
+package services
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ExpiringSoonWindow is how close a link's expiry date must be for it to
+// count as expiring soon.
+const ExpiringSoonWindow = 7 * 24 * time.Hour
+
+// Where a link stands against its expiry date, as returned by ExpiryState.
+const (
+	ExpiryNone     = ""         // no expiry date, or one further off than ExpiringSoonWindow
+	ExpiryExpiring = "expiring" // expires within ExpiringSoonWindow
+	ExpiryExpired  = "expired"  // the expiry date has passed
+)
+
+// ParseExpiryDate reads an expiry date written YYYY-MM-DD in local time. The
+// link expires at the end of that day.
+func ParseExpiryDate(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	day, err := time.ParseInLocation(time.DateOnly, s, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid expiry date %q: use YYYY-MM-DD", s)
+	}
+	return day.AddDate(0, 0, 1).Add(-time.Second), nil
+}
+
+// ExpiryState returns ExpiryNone, ExpiryExpiring, or ExpiryExpired for a
+// link expiring at expiresAt, as of now.
+func ExpiryState(expiresAt sql.NullTime, now time.Time) string {
+	switch {
+	case !expiresAt.Valid:
+		return ExpiryNone
+	case !now.Before(expiresAt.Time):
+		return ExpiryExpired
+	case expiresAt.Time.Sub(now) <= ExpiringSoonWindow:
+		return ExpiryExpiring
+	}
+	return ExpiryNone
+}
@@ -2,6 +2,7 @@ package services
 
 import (
 	"fmt"
+	"net/url"
 	"regexp"
 	"strings"
 
@@ -12,49 +13,102 @@ import (
 
 var (
 	multipleBlankLines = regexp.MustCompile(`\n\p{Z}*(\n\p{Z}*)+\n`)
+	// whitespaceLines matches lines holding only spaces. The converter indents
+	// the blank lines inside loose list items; emptying them keeps the
+	// nesting carried by the indentation of the item lines themselves.
+	whitespaceLines = regexp.MustCompile(`(?m)^\p{Z}+$`)
 	// mdImage matches ![alt](url) — images must be replaced before links so
 	// the image-inside-link pattern [![alt](img)](link) is handled correctly.
 	mdImage = regexp.MustCompile(`!\[([^\]]*)\]\([^)]*\)`)
-	// mdLink matches [text](url)
-	mdLink = regexp.MustCompile(`\[([^\]]*)\]\([^)]*\)`)
+	// mdLink matches [text](url). The text may contain escaped characters and
+	// one level of brackets, such as the [image: alt] placeholder of a linked
+	// image.
+	mdLink = regexp.MustCompile(`\[((?:\\.|\[[^\[\]]*\]|[^\[\]\\])*)\]\(([^)]*)\)`)
 )
 
-type Extractor struct{}
+// LinkStyle controls what ExtractText does with the URLs of links in the
+// content. The visible link text is always kept.
+type LinkStyle string
+
+const (
+	LinkStyleStrip     LinkStyle = "strip"     // drop the URLs (the default)
+	LinkStyleInline    LinkStyle = "inline"    // text (url)
+	LinkStyleFootnotes LinkStyle = "footnotes" // text [n], with the URLs listed at the end
+)
+
+// TruncateStyle controls where TruncateText cuts text that is over its limit.
+type TruncateStyle string
+
+const (
+	TruncateWords     TruncateStyle = "word"     // at the last word boundary (the default)
+	TruncateSentences TruncateStyle = "sentence" // at the last sentence end, else the last word boundary
+)
+
+// ParseTruncateStyle parses a TruncateStyle name.
+func ParseTruncateStyle(s string) (TruncateStyle, error) {
+	switch style := TruncateStyle(strings.ToLower(strings.TrimSpace(s))); style {
+	case TruncateWords, TruncateSentences:
+		return style, nil
+	}
+	return "", fmt.Errorf("invalid truncation style %q: must be word or sentence", s)
+}
+
+// ParseLinkStyle parses a LinkStyle name.
+func ParseLinkStyle(s string) (LinkStyle, error) {
+	switch style := LinkStyle(strings.ToLower(strings.TrimSpace(s))); style {
+	case LinkStyleStrip, LinkStyleInline, LinkStyleFootnotes:
+		return style, nil
+	}
+	return "", fmt.Errorf("invalid link style %q: must be strip, inline, or footnotes", s)
+}
+
+type Extractor struct {
+	links    LinkStyle
+	truncate TruncateStyle
+	images   bool // ExtractImages lists images rather than returning nil
+}
 
 func NewExtractor() *Extractor {
-	return &Extractor{}
+	return &Extractor{links: LinkStyleStrip, truncate: TruncateWords}
+}
+
+// SetLinkStyle sets how link URLs are kept in extracted content.
+func (e *Extractor) SetLinkStyle(style LinkStyle) {
+	e.links = style
+}
+
+// SetTruncateStyle sets where TruncateText cuts long text.
+func (e *Extractor) SetTruncateStyle(style TruncateStyle) {
+	e.truncate = style
 }
 
-// ExtractText parses HTML content and returns the title and content as Markdown.
-// The pageURL is used to resolve relative links to absolute URLs.
-func (e *Extractor) ExtractText(html, pageURL string) (title string, text string, err error) {
+// ExtractText parses HTML content and returns the title and content as Markdown,
+// along with the page's canonical URL ("" if it declares none).
+// The pageURL is used to resolve relative links to absolute URLs; it may lack
+// a scheme, or be empty if the page names its own address.
+func (e *Extractor) ExtractText(html, pageURL string) (title string, text string, canonical string, err error) {
 	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
 	if err != nil {
-		return "", "", fmt.Errorf("failed to parse HTML: %w", err)
+		return "", "", "", fmt.Errorf("failed to parse HTML: %w", err)
 	}
+	base := baseURL(doc, pageURL)
 
 	// Extract title
 	title = strings.TrimSpace(doc.Find("title").First().Text())
 
-	// Remove noisy structural elements; script/style are also handled by the
-	// converter but removing them first keeps content selection cleaner.
-	doc.Find("script, style, nav, header, footer, aside").Remove()
-
-	// Prefer a focused content area; fall back to the whole body.
-	var contentHTML string
-	mainContent := doc.Find("article, main, [role=main], .content, #content, .post, .entry-content").First()
-	if mainContent.Length() > 0 {
-		contentHTML, err = mainContent.Html()
-	} else {
-		contentHTML, err = doc.Find("body").Html()
+	// Extract <link rel="canonical">
+	if href, ok := doc.Find(`link[rel="canonical"]`).First().Attr("href"); ok {
+		canonical = resolveCanonical(href, base)
 	}
+
+	contentHTML, err := contentArea(doc).Html()
 	if err != nil {
-		return "", "", fmt.Errorf("failed to extract content HTML: %w", err)
+		return "", "", "", fmt.Errorf("failed to extract content HTML: %w", err)
 	}
 
-	md, err := htmltomarkdown.ConvertString(contentHTML, converter.WithDomain(pageURL))
+	md, err := htmltomarkdown.ConvertString(contentHTML, converter.WithDomain(base))
 	if err != nil {
-		return "", "", fmt.Errorf("failed to convert HTML to markdown: %w", err)
+		return "", "", "", fmt.Errorf("failed to convert HTML to markdown: %w", err)
 	}
 
 	// fmt.Println(strings.ReplaceAll(strings.ReplaceAll(md, " ", "."), "\n", "\\n\n"))
@@ -66,20 +120,212 @@ func (e *Extractor) ExtractText(html, pageURL string) (title string, text string
 		}
 		return "[image]"
 	})
-	// Strip link URLs, keeping the visible link text.
-	md = mdLink.ReplaceAllString(md, "$1")
+	// Replace links with their visible text, keeping the URLs if configured.
+	md = e.rewriteLinks(md, base)
 
+	// Collapse runs of blank lines. Only line breaks are removed, so the
+	// indentation of nested list items survives.
+	md = whitespaceLines.ReplaceAllString(md, "")
 	text = strings.TrimSpace(multipleBlankLines.ReplaceAllString(md, "\n\n"))
-	return title, text, nil
+	return title, text, canonical, nil
+}
+
+// contentArea strips doc of navigation and other page furniture and returns
+// the element holding the page's main content: a focused content area if
+// there is one, else the whole body.
+func contentArea(doc *goquery.Document) *goquery.Selection {
+	// Remove noisy structural elements; script/style are also handled by the
+	// converter but removing them first keeps content selection cleaner.
+	doc.Find("script, style, nav, header, footer, aside").Remove()
+
+	mainContent := doc.Find("article, main, [role=main], .content, #content, .post, .entry-content").First()
+	if mainContent.Length() > 0 {
+		return mainContent
+	}
+	return doc.Find("body")
 }
 
-// TruncateText truncates text to a maximum length at a word boundary.
+// ExtractTitle returns just the page's title and canonical URL, for links
+// saved as bare references without their content.
+func (e *Extractor) ExtractTitle(html, pageURL string) (title string, canonical string, err error) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		return "", "", fmt.Errorf("failed to parse HTML: %w", err)
+	}
+	title = strings.TrimSpace(doc.Find("title").First().Text())
+	if href, ok := doc.Find(`link[rel="canonical"]`).First().Attr("href"); ok {
+		canonical = resolveCanonical(href, baseURL(doc, pageURL))
+	}
+	return title, canonical, nil
+}
+
+// baseURL returns the URL relative links in doc resolve against: the page
+// URL, adjusted by the page's <base href> if it has one. Without a usable
+// page URL, the page's canonical or og:url address stands in. It returns ""
+// when no absolute base is known.
+func baseURL(doc *goquery.Document, pageURL string) string {
+	base := normalizePageURL(pageURL)
+	if base == "" {
+		canonical, _ := doc.Find(`link[rel="canonical"]`).First().Attr("href")
+		ogURL, _ := doc.Find(`meta[property="og:url"]`).First().Attr("content")
+		for _, candidate := range []string{canonical, ogURL} {
+			// Only an absolute address can stand in for the page URL.
+			if strings.Contains(candidate, "://") {
+				if base = normalizePageURL(candidate); base != "" {
+					break
+				}
+			}
+		}
+	}
+
+	href, ok := doc.Find("base[href]").First().Attr("href")
+	if !ok {
+		return base
+	}
+	if resolved := resolveCanonical(href, base); resolved != "" {
+		return resolved
+	}
+	return base
+}
+
+// normalizePageURL returns rawURL as an absolute http(s) URL, assuming https
+// when the scheme is missing, or "" if it has no host.
+func normalizePageURL(rawURL string) string {
+	rawURL = strings.TrimSpace(rawURL)
+	switch {
+	case rawURL == "":
+		return ""
+	case strings.HasPrefix(rawURL, "//"):
+		rawURL = "https:" + rawURL
+	case !strings.Contains(rawURL, "://"):
+		rawURL = "https://" + rawURL
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ""
+	}
+	return u.String()
+}
+
+// rewriteLinks replaces Markdown links with their text, followed by the URL
+// inline or as a numbered reference per the link style. URLs that add nothing
+// — bare links whose text is the URL, and anchors into the page itself — are
+// dropped in every style.
+func (e *Extractor) rewriteLinks(md, pageURL string) string {
+	var refs []string
+	refIndex := map[string]int{}
+
+	md = mdLink.ReplaceAllStringFunc(md, func(match string) string {
+		sub := mdLink.FindStringSubmatch(match)
+		text, target := sub[1], linkTarget(sub[2])
+		if e.links == LinkStyleStrip || target == "" || sameDocument(target, pageURL) ||
+			text == target || "mailto:"+text == target {
+			return text
+		}
+		if strings.TrimSpace(text) == "" {
+			return target
+		}
+		if e.links == LinkStyleInline {
+			return text + " (" + target + ")"
+		}
+		n, ok := refIndex[target]
+		if !ok {
+			refs = append(refs, target)
+			n = len(refs)
+			refIndex[target] = n
+		}
+		return fmt.Sprintf("%s [%d]", text, n)
+	})
+
+	if len(refs) == 0 {
+		return md
+	}
+	var b strings.Builder
+	b.WriteString(strings.TrimRight(md, "\n"))
+	b.WriteString("\n\n## Links\n\n")
+	for i, ref := range refs {
+		fmt.Fprintf(&b, "%d. %s\n", i+1, ref)
+	}
+	return b.String()
+}
+
+// linkTarget returns the URL of a Markdown link destination, dropping any
+// title.
+func linkTarget(dest string) string {
+	fields := strings.Fields(dest)
+	if len(fields) == 0 {
+		return ""
+	}
+	return strings.Trim(fields[0], "<>")
+}
+
+// sameDocument reports whether target points into the page at pageURL.
+func sameDocument(target, pageURL string) bool {
+	t, err := url.Parse(target)
+	if err != nil {
+		return false
+	}
+	p, err := url.Parse(pageURL)
+	if err != nil {
+		return false
+	}
+	t.Fragment, p.Fragment = "", ""
+	return t.String() == p.String()
+}
+
+// resolveCanonical resolves a canonical href against the page URL. It returns
+// "" unless the result is an absolute http(s) URL.
+func resolveCanonical(href, pageURL string) string {
+	ref, err := url.Parse(strings.TrimSpace(href))
+	if err != nil {
+		return ""
+	}
+	if base, err := url.Parse(pageURL); err == nil {
+		ref = base.ResolveReference(ref)
+	}
+	if (ref.Scheme != "http" && ref.Scheme != "https") || ref.Host == "" {
+		return ""
+	}
+	ref.Fragment = ""
+	return ref.String()
+}
+
+// WordCount returns the number of whitespace-separated words in text.
+func WordCount(text string) int64 {
+	return int64(len(strings.Fields(text)))
+}
+
+// TruncateText truncates text to a maximum length at a word boundary, or at
+// a sentence boundary when the extractor's truncation style says so.
 func (e *Extractor) TruncateText(text string, maxLength int) string {
+	return truncateText(text, maxLength, e.truncate)
+}
+
+// sentenceEnd matches the end of a sentence: ., !, or ?, optionally closed
+// by a quote or bracket (group 1), then a line break or the capital or digit
+// starting the next sentence, so "(really!) goes on" is not a sentence end.
+var sentenceEnd = regexp.MustCompile(`([.!?]["')\]]?)(?:\n|\s+["'(\[]?[\p{Lu}\d])`)
+
+// truncateText cuts text longer than maxLength. With TruncateSentences it
+// ends at the last sentence end within the limit; otherwise, or when no
+// sentence ends in the second half of the limit, at the last space. A cut
+// that would lose more than half the limit is made mid-word instead.
+func truncateText(text string, maxLength int, style TruncateStyle) string {
 	if len(text) <= maxLength {
 		return text
 	}
 
 	truncated := text[:maxLength]
+	if style == TruncateSentences {
+		ends := sentenceEnd.FindAllStringSubmatchIndex(truncated, -1)
+		if len(ends) > 0 {
+			// Keep the punctuation, drop what follows it.
+			if end := ends[len(ends)-1][3]; end > maxLength/2 {
+				return truncated[:end] + "..."
+			}
+		}
+	}
+
 	lastSpace := strings.LastIndex(truncated, " ")
 	if lastSpace > maxLength/2 {
 		truncated = truncated[:lastSpace]
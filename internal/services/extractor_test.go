@@ -0,0 +1,115 @@
+package services
+
+import "testing"
+
+func TestExtractTextNestedLists(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{
+			name: "nested unordered",
+			html: `<ul><li>One<ul><li>Two<ul><li>Three</li></ul></li></ul></li><li>Four</li></ul>`,
+			want: "- One\n\n  - Two\n\n    - Three\n- Four",
+		},
+		{
+			name: "nested ordered",
+			html: `<ol><li>First<ol><li>Inner</li></ol></li><li>Second</li></ol>`,
+			want: "1. First\n\n   1. Inner\n2. Second",
+		},
+		{
+			name: "ordered inside unordered",
+			html: `<ul><li>Mix<ol><li>A</li><li>B</li></ol></li></ul>`,
+			want: "- Mix\n\n  1. A\n  2. B",
+		},
+		{
+			name: "loose items",
+			html: `<ul><li><p>Loose</p><ul><li><p>Child</p></li></ul></li></ul>`,
+			want: "- Loose\n\n  - Child",
+		},
+	}
+
+	e := NewExtractor()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := "<html><body><article>" + tt.html + "</article></body></html>"
+			_, got, _, err := e.ExtractText(page, "https://example.com/")
+			if err != nil {
+				t.Fatalf("ExtractText: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ExtractText =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractResolvesRelativeURLs(t *testing.T) {
+	tests := []struct {
+		name    string
+		pageURL string
+		head    string
+		ref     string
+		want    string
+	}{
+		{"root-relative", "https://example.com/blog/post/", "", "/about", "https://example.com/about"},
+		{"path-relative", "https://example.com/blog/post/", "", "notes/1", "https://example.com/blog/post/notes/1"},
+		{"parent-relative", "https://example.com/blog/post/", "", "../archive", "https://example.com/blog/archive"},
+		{"protocol-relative", "https://example.com/blog/post/", "", "//cdn.example.net/x", "https://cdn.example.net/x"},
+		{"page URL without scheme", "example.com/blog/post/", "", "/about", "https://example.com/about"},
+		{"page URL empty, canonical stands in", "", `<link rel="canonical" href="https://example.com/blog/post/">`, "notes/1", "https://example.com/blog/post/notes/1"},
+		{"base href", "https://example.com/blog/post/", `<base href="https://static.example.org/docs/">`, "guide", "https://static.example.org/docs/guide"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := "<html><head>" + tt.head + "</head><body><article>" +
+				`<p><a href="` + tt.ref + `">link</a></p><img src="` + tt.ref + `" alt="pic">` +
+				"</article></body></html>"
+
+			e := NewExtractor()
+			e.SetLinkStyle(LinkStyleInline)
+			_, text, _, err := e.ExtractText(page, tt.pageURL)
+			if err != nil {
+				t.Fatalf("ExtractText: %v", err)
+			}
+			if want := "link (" + tt.want + ")\n\n[image: pic]"; text != want {
+				t.Errorf("ExtractText href = %q, want %q", text, want)
+			}
+
+			e.SetKeepImages(true)
+			images := e.ExtractImages(page, tt.pageURL)
+			if len(images) != 1 || images[0].URL != tt.want {
+				t.Errorf("ExtractImages src = %v, want %q", images, tt.want)
+			}
+		})
+	}
+}
+
+func TestTruncateText(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		max   int
+		style TruncateStyle
+		want  string
+	}{
+		{"sentence end within limit, word style", "First sentence here. Second sentence goes on and on.", 30, TruncateWords, "First sentence here. Second..."},
+		{"sentence end within limit, sentence style", "First sentence here. Second sentence goes on and on.", 30, TruncateSentences, "First sentence here...."},
+		{"no sentence end, word style", "alpha beta gamma delta epsilon zeta", 20, TruncateWords, "alpha beta gamma..."},
+		{"no sentence end, sentence style", "alpha beta gamma delta epsilon zeta", 20, TruncateSentences, "alpha beta gamma..."},
+		{"sentence end too early, sentence style", "Hi. This is a long run of words with no end", 30, TruncateSentences, "Hi. This is a long run of..."},
+		{"no space, cut mid-word", "Supercalifragilisticexpialidocious", 10, TruncateWords, "Supercalif..."},
+		{"shorter than limit, word style", "Short. Text.", 50, TruncateWords, "Short. Text."},
+		{"shorter than limit, sentence style", "Short. Text.", 50, TruncateSentences, "Short. Text."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := truncateText(tt.text, tt.max, tt.style); got != tt.want {
+				t.Errorf("truncateText(%q, %d, %q) = %q, want %q", tt.text, tt.max, tt.style, got, tt.want)
+			}
+		})
+	}
+}
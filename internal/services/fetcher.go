@@ -5,11 +5,19 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
+	"unicode/utf8"
+
+	"golang.org/x/net/html/charset"
 )
 
 type Fetcher struct {
-	client *http.Client
+	client    *http.Client
+	renderURL string // rendering service endpoint with a {url} placeholder; "" for none
+	render    bool   // fetch every page through the rendering service
+	archive   bool   // keep fetched HTML in Page.HTML for archiving
 }
 
 func NewFetcher() *Fetcher {
@@ -33,8 +41,74 @@ func (f *Fetcher) newRequest(ctx context.Context, url string) (*http.Request, er
 	return req, nil
 }
 
-// FetchURL retrieves the content from a URL
+// SetRenderService configures a headless-browser rendering service for pages
+// that build their content with JavaScript. endpoint is fetched with {url}
+// replaced by the query-escaped page URL, and must return the rendered HTML,
+// e.g. "http://localhost:8050/render.html?url={url}&wait=2" for Splash.
+func (f *Fetcher) SetRenderService(endpoint string) {
+	f.renderURL = endpoint
+}
+
+// SetAlwaysRender makes FetchURL fetch every page through the rendering
+// service. It has no effect without one.
+func (f *Fetcher) SetAlwaysRender(render bool) {
+	f.render = render
+}
+
+// SetArchiveHTML makes FetchPage keep the HTML each page was extracted from,
+// so it can be archived for 'lm reextract'.
+func (f *Fetcher) SetArchiveHTML(archive bool) {
+	f.archive = archive
+}
+
+// ArchivesHTML reports whether fetched HTML is kept for archiving.
+func (f *Fetcher) ArchivesHTML() bool {
+	return f.archive
+}
+
+// CanRender reports whether a rendering service is configured.
+func (f *Fetcher) CanRender() bool {
+	return f.renderURL != ""
+}
+
+// AlwaysRenders reports whether FetchURL already renders every page.
+func (f *Fetcher) AlwaysRenders() bool {
+	return f.render && f.CanRender()
+}
+
+// FetchRendered retrieves pageURL's HTML after JavaScript has run, through
+// the rendering service.
+func (f *Fetcher) FetchRendered(ctx context.Context, pageURL string) (string, error) {
+	if !f.CanRender() {
+		return "", fmt.Errorf("no rendering service configured")
+	}
+	endpoint := strings.ReplaceAll(f.renderURL, "{url}", url.QueryEscape(pageURL))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create render request: %w", err)
+	}
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to render URL: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("rendering service returned status %d", resp.StatusCode)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read rendered page: %w", err)
+	}
+	return decodeHTML(body, resp.Header.Get("Content-Type")), nil
+}
+
+// FetchURL retrieves the content from a URL, through the rendering service
+// if SetAlwaysRender is on.
 func (f *Fetcher) FetchURL(ctx context.Context, url string) (string, error) {
+	if f.AlwaysRenders() {
+		return f.FetchRendered(ctx, url)
+	}
+
 	// Try once, and if 202, retry once after a short delay
 	for attempt := 0; attempt < 2; attempt++ {
 		req, err := f.newRequest(ctx, url)
@@ -53,7 +127,7 @@ func (f *Fetcher) FetchURL(ctx context.Context, url string) (string, error) {
 			if err != nil {
 				return "", fmt.Errorf("failed to read response body: %w", err)
 			}
-			return string(body), nil
+			return decodeHTML(body, resp.Header.Get("Content-Type")), nil
 		}
 
 		if resp.StatusCode == http.StatusAccepted && attempt == 0 {
@@ -72,3 +146,23 @@ func (f *Fetcher) FetchURL(ctx context.Context, url string) (string, error) {
 
 	return "", fmt.Errorf("failed to fetch URL after retries")
 }
+
+// decodeHTML converts a page to UTF-8 using the charset named by its BOM,
+// its Content-Type header, or a <meta charset> tag. Pages are often
+// mislabelled, so a body that is already valid UTF-8 is kept as it is, and
+// one that claims UTF-8 but is not is read as Windows-1252 (a superset of
+// Latin-1), the usual culprit.
+func decodeHTML(body []byte, contentType string) string {
+	enc, name, _ := charset.DetermineEncoding(body, contentType)
+	if !strings.HasPrefix(name, "utf-16") && utf8.Valid(body) {
+		return string(body)
+	}
+	if name == "utf-8" {
+		enc, _ = charset.Lookup("windows-1252")
+	}
+	decoded, err := enc.NewDecoder().Bytes(body)
+	if err != nil {
+		return strings.ToValidUTF8(string(body), "\uFFFD")
+	}
+	return string(decoded)
+}
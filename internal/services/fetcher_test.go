@@ -0,0 +1,51 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestFetchURLTranscodesLatin1(t *testing.T) {
+	// "Café naïve résumé" in ISO-8859-1: not valid UTF-8 as it stands.
+	latin1Text := "Caf\xe9 na\xefve r\xe9sum\xe9"
+	wantText := "Café naïve résumé"
+
+	tests := []struct {
+		name        string
+		contentType string
+		meta        string
+	}{
+		{"charset in Content-Type", "text/html; charset=ISO-8859-1", ""},
+		{"charset in meta only", "text/html", `<meta charset="iso-8859-1">`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := "<html><head>" + tt.meta + "<title>" + latin1Text + "</title></head><body><p>" + latin1Text + "</p></body></html>"
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", tt.contentType)
+				w.Write([]byte(body))
+			}))
+			defer srv.Close()
+
+			html, err := NewFetcher().FetchURL(context.Background(), srv.URL)
+			if err != nil {
+				t.Fatalf("FetchURL: %v", err)
+			}
+			if !strings.Contains(html, "<p>"+wantText+"</p>") {
+				t.Errorf("FetchURL body = %q, want it to contain %q", html, wantText)
+			}
+
+			title, _, _, err := NewExtractor().ExtractText(html, srv.URL)
+			if err != nil {
+				t.Fatalf("ExtractText: %v", err)
+			}
+			if title != wantText {
+				t.Errorf("title = %q, want %q", title, wantText)
+			}
+		})
+	}
+}
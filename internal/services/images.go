@@ -0,0 +1,163 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// maxImageBytes caps the size of a downloaded image.
+const maxImageBytes = 50 << 20
+
+// Image is an image in a page's content, kept as a reference to the original.
+type Image struct {
+	URL string // absolute http(s) URL
+	Alt string
+}
+
+// SetKeepImages sets whether ExtractImages lists the images in a page's
+// content. Extracted text always has [image: alt] placeholders either way.
+func (e *Extractor) SetKeepImages(keep bool) {
+	e.images = keep
+}
+
+// ExtractImages returns the images in the same part of html that
+// ExtractText takes its content from, with URLs resolved against pageURL
+// and duplicates dropped. It returns nil unless SetKeepImages is on, and an
+// empty slice if the page has no images.
+func (e *Extractor) ExtractImages(html, pageURL string) []Image {
+	if !e.images {
+		return nil
+	}
+	images := []Image{}
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		return images
+	}
+	base := baseURL(doc, pageURL)
+
+	seen := map[string]bool{}
+	contentArea(doc).Find("img").Each(func(_ int, img *goquery.Selection) {
+		src, _ := img.Attr("src")
+		// Lazy-loading pages keep the real source aside until scrolled to.
+		if lazy, ok := img.Attr("data-src"); ok && (src == "" || strings.HasPrefix(src, "data:")) {
+			src = lazy
+		}
+		// resolveCanonical drops data: URIs along with anything else that is
+		// not http(s).
+		u := resolveCanonical(src, base)
+		if u == "" || seen[u] {
+			return
+		}
+		seen[u] = true
+		alt, _ := img.Attr("alt")
+		images = append(images, Image{URL: u, Alt: strings.Join(strings.Fields(alt), " ")})
+	})
+	return images
+}
+
+// DefaultImageDir returns the folder images are downloaded to: IMAGE_DIR if
+// set (a leading ~/ is the home directory), else the Downloads folder in the
+// home directory.
+func DefaultImageDir() string {
+	dir := os.Getenv("IMAGE_DIR")
+	if dir != "" && !strings.HasPrefix(dir, "~/") {
+		return dir
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "."
+	}
+	if dir != "" {
+		return filepath.Join(home, dir[2:])
+	}
+	return filepath.Join(home, "Downloads")
+}
+
+// DownloadImage saves the image at imageURL into dir, named after the last
+// element of its path, and returns the file's path. An existing file is
+// never overwritten; a number is added to the name instead.
+func (f *Fetcher) DownloadImage(ctx context.Context, imageURL, dir string) (string, error) {
+	req, err := f.newRequest(ctx, imageURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "image/*,*/*;q=0.8")
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch image: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+	file, err := createUnique(dir, imageFileName(imageURL))
+	if err != nil {
+		return "", err
+	}
+	n, err := io.Copy(file, io.LimitReader(resp.Body, maxImageBytes+1))
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil && n > maxImageBytes {
+		err = fmt.Errorf("image is larger than %d MB", maxImageBytes>>20)
+	}
+	if err != nil {
+		_ = os.Remove(file.Name())
+		return "", fmt.Errorf("failed to save image: %w", err)
+	}
+	return file.Name(), nil
+}
+
+// imageFileName returns a safe file name for the image at imageURL.
+func imageFileName(imageURL string) string {
+	name := "image"
+	if u, err := url.Parse(imageURL); err == nil {
+		if base := path.Base(u.Path); base != "/" && base != "." {
+			name = base
+		}
+	}
+	name = strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || r < ' ' {
+			return '_'
+		}
+		return r
+	}, name)
+	return strings.TrimLeft(name, ".")
+}
+
+// createUnique creates name in dir, or name-1, name-2, and so on (before the
+// extension) if it is taken.
+func createUnique(dir, name string) (*os.File, error) {
+	if name == "" {
+		name = "image"
+	}
+	ext := filepath.Ext(name)
+	stem := strings.TrimSuffix(name, ext)
+	for i := 0; i < 1000; i++ {
+		candidate := name
+		if i > 0 {
+			candidate = fmt.Sprintf("%s-%d%s", stem, i, ext)
+		}
+		file, err := os.OpenFile(filepath.Join(dir, candidate), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if err == nil {
+			return file, nil
+		}
+		if !os.IsExist(err) {
+			return nil, fmt.Errorf("failed to create image file: %w", err)
+		}
+	}
+	return nil, fmt.Errorf("too many files named like %s in %s", name, dir)
+}
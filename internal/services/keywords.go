@@ -0,0 +1,125 @@
+package services
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// stopwords are common English words that never make useful tags, plus
+// leftovers of the Markdown conversion such as link and image placeholders.
+var stopwords = func() map[string]bool {
+	words := `a about above after again against all also am an and any are as at
+be because been before being below between both but by can could did do does
+doing done down during each even every few for from further get gets got had
+has have having he her here hers herself him himself his how however i if in
+into is it its itself just know like made make many may me might more most
+much must my myself new no nor not now of off often on once one only or other
+our ours ourselves out over own per really same see she should since so some
+still such than that the their theirs them themselves then there these they
+this those through to too under until up upon us use used uses using very via
+want was way we well were what when where whether which while who whom whose
+why will with within without would yet you your yours yourself yourselves
+image http https www com org net html`
+	m := map[string]bool{}
+	for _, w := range strings.Fields(words) {
+		m[w] = true
+	}
+	return m
+}()
+
+// TagSuggester proposes tags for a page from its most distinctive terms,
+// without an LLM. Terms are scored by TF-IDF: frequent in the page, rare
+// across the saved corpus.
+type TagSuggester struct {
+	docFreq map[string]int // number of corpus documents containing each term
+	docs    int
+}
+
+// NewTagSuggester builds a suggester from the texts of already-saved links.
+func NewTagSuggester(corpus []string) *TagSuggester {
+	s := &TagSuggester{docFreq: map[string]int{}}
+	for _, doc := range corpus {
+		s.Add(doc)
+	}
+	return s
+}
+
+// Add counts doc in the corpus, as for a link saved after the suggester was
+// built. It is not safe to call concurrently with Add or Suggest.
+func (s *TagSuggester) Add(doc string) {
+	s.docs++
+	seen := map[string]bool{}
+	for _, term := range tokenize(doc) {
+		if !seen[term] {
+			seen[term] = true
+			s.docFreq[term]++
+		}
+	}
+}
+
+// Suggest returns up to n tags for a page, best first. Title terms count
+// double since titles name the topic.
+func (s *TagSuggester) Suggest(title, text string, n int) []string {
+	counts := map[string]int{}
+	total := 0
+	for _, term := range tokenize(title) {
+		counts[term] += 2
+		total += 2
+	}
+	for _, term := range tokenize(text) {
+		counts[term]++
+		total++
+	}
+	if total == 0 {
+		return nil
+	}
+
+	type scored struct {
+		term  string
+		score float64
+	}
+	terms := make([]scored, 0, len(counts))
+	for term, count := range counts {
+		// A term used once says little about the page.
+		if count < 2 {
+			continue
+		}
+		tf := float64(count) / float64(total)
+		idf := math.Log(float64(s.docs+1)/float64(s.docFreq[term]+1)) + 1
+		terms = append(terms, scored{term, tf * idf})
+	}
+	sort.Slice(terms, func(i, j int) bool {
+		if terms[i].score != terms[j].score {
+			return terms[i].score > terms[j].score
+		}
+		return terms[i].term < terms[j].term
+	})
+
+	var tags []string
+	for _, t := range terms {
+		if len(tags) == n {
+			break
+		}
+		tags = append(tags, t.term)
+	}
+	return tags
+}
+
+// tokenize splits text into lowercase terms, dropping stopwords, numbers,
+// and words shorter than three letters.
+func tokenize(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-'
+	})
+	var terms []string
+	for _, f := range fields {
+		f = strings.Trim(f, "-")
+		if len([]rune(f)) < 3 || stopwords[f] || !strings.ContainsFunc(f, unicode.IsLetter) {
+			continue
+		}
+		terms = append(terms, f)
+	}
+	return terms
+}
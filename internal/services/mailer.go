@@ -0,0 +1,59 @@
+package services
+
+import (
+	"fmt"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// Mailer sends plain-text email through an SMTP server. STARTTLS is used when
+// the server offers it.
+type Mailer struct {
+	host     string
+	port     string
+	username string
+	password string
+	from     string
+}
+
+func NewMailer(host, port, username, password, from string) *Mailer {
+	if port == "" {
+		port = "587"
+	}
+	return &Mailer{host: host, port: port, username: username, password: password, from: from}
+}
+
+// Send emails body to the given recipients.
+func (m *Mailer) Send(to []string, subject, body string) error {
+	if m.from == "" {
+		return fmt.Errorf("no sender address configured")
+	}
+	// The From header may carry a display name; the envelope needs the bare
+	// address.
+	sender, err := mail.ParseAddress(m.from)
+	if err != nil {
+		return fmt.Errorf("invalid sender address: %w", err)
+	}
+
+	var msg strings.Builder
+	msg.WriteString("From: " + m.from + "\r\n")
+	msg.WriteString("To: " + strings.Join(to, ", ") + "\r\n")
+	msg.WriteString("Subject: " + subject + "\r\n")
+	msg.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	msg.WriteString("\r\n")
+	msg.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+
+	var auth smtp.Auth
+	if m.username != "" {
+		auth = smtp.PlainAuth("", m.username, m.password, m.host)
+	}
+	if err := smtp.SendMail(net.JoinHostPort(m.host, m.port), auth, sender.Address, to, []byte(msg.String())); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
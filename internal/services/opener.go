@@ -0,0 +1,128 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"unicode"
+
+	"github.com/pkg/browser"
+)
+
+// OpenURL opens url with browserCmd, the BROWSER setting or 'lm open
+// --browser'. An empty command uses the system default opener.
+//
+// The command is split into words as a POSIX shell would, honouring single
+// and double quotes and backslash escapes, but without expanding variables
+// or globs. Any %s in it is replaced by the URL; otherwise the URL is added
+// as the last argument. Following the BROWSER convention, several commands
+// may be given separated by ":" (";" on Windows), and each is tried in turn
+// until one starts.
+func OpenURL(browserCmd, url string) error {
+	cmds, err := browserCommands(browserCmd)
+	if err != nil {
+		return err
+	}
+	if len(cmds) == 0 {
+		return browser.OpenURL(url)
+	}
+
+	var errs []error
+	for _, args := range cmds {
+		args = withURL(args, url)
+		cmd := exec.Command(args[0], args[1:]...)
+		if err := cmd.Start(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to start browser %q: %w", args[0], err))
+			continue
+		}
+		// Reap the process in the background; the browser may outlive us.
+		go func() { _ = cmd.Wait() }()
+		return nil
+	}
+	return errors.Join(errs...)
+}
+
+// browserCommands splits a browser setting into the commands it lists, each
+// as its words, for OpenURL.
+func browserCommands(s string) ([][]string, error) {
+	var (
+		cmds    [][]string
+		words   []string
+		word    strings.Builder
+		inWord  bool
+		quote   rune
+		escaped bool
+	)
+	endWord := func() {
+		if inWord {
+			words = append(words, word.String())
+			word.Reset()
+			inWord = false
+		}
+	}
+	endCommand := func() {
+		endWord()
+		if len(words) > 0 {
+			cmds = append(cmds, words)
+			words = nil
+		}
+	}
+
+	for _, r := range s {
+		switch {
+		case escaped:
+			word.WriteRune(r)
+			escaped = false
+		case quote == '\'':
+			if r == '\'' {
+				quote = 0
+			} else {
+				word.WriteRune(r)
+			}
+		case r == '\\':
+			escaped = true
+			inWord = true
+		case quote == '"':
+			if r == '"' {
+				quote = 0
+			} else {
+				word.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inWord = true
+		case r == os.PathListSeparator:
+			endCommand()
+		case unicode.IsSpace(r):
+			endWord()
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+	if quote != 0 || escaped {
+		return nil, fmt.Errorf("unterminated quote or escape in browser command %q", s)
+	}
+	endCommand()
+	return cmds, nil
+}
+
+// withURL returns args with url in place of each %s, or after them if there
+// is none.
+func withURL(args []string, url string) []string {
+	out := make([]string, 0, len(args)+1)
+	placed := false
+	for _, arg := range args {
+		if strings.Contains(arg, "%s") {
+			arg = strings.ReplaceAll(arg, "%s", url)
+			placed = true
+		}
+		out = append(out, arg)
+	}
+	if !placed {
+		out = append(out, url)
+	}
+	return out
+}
@@ -0,0 +1,61 @@
+package services
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestBrowserCommands(t *testing.T) {
+	sep := string(os.PathListSeparator)
+	tests := []struct {
+		name string
+		in   string
+		want [][]string
+	}{
+		{"empty", "", nil},
+		{"plain", "firefox", [][]string{{"firefox"}}},
+		{"arguments", "firefox -P work", [][]string{{"firefox", "-P", "work"}}},
+		{"double quotes", `"/opt/My Browser/run" --new-tab`, [][]string{{"/opt/My Browser/run", "--new-tab"}}},
+		{"single quotes", `open -a 'Google Chrome'`, [][]string{{"open", "-a", "Google Chrome"}}},
+		{"backslash", `/opt/My\ Browser/run`, [][]string{{"/opt/My Browser/run"}}},
+		{"empty quoted word", `run ""`, [][]string{{"run", ""}}},
+		{"list", "firefox" + sep + "chromium --incognito", [][]string{{"firefox"}, {"chromium", "--incognito"}}},
+		{"quoted separator", `'a` + sep + `b'`, [][]string{{"a" + sep + "b"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := browserCommands(tt.in)
+			if err != nil {
+				t.Fatalf("browserCommands(%q): %v", tt.in, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("browserCommands(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+
+	if _, err := browserCommands(`firefox "unclosed`); err == nil {
+		t.Error("browserCommands with an unterminated quote returned no error")
+	}
+}
+
+func TestWithURL(t *testing.T) {
+	const url = "https://example.com/"
+	tests := []struct {
+		args []string
+		want []string
+	}{
+		{[]string{"firefox"}, []string{"firefox", url}},
+		{[]string{"firefox", "-P", "work"}, []string{"firefox", "-P", "work", url}},
+		{[]string{"w3m", "%s", "-dump"}, []string{"w3m", url, "-dump"}},
+		{[]string{"browser", "--url=%s"}, []string{"browser", "--url=" + url}},
+	}
+
+	for _, tt := range tests {
+		if got := withURL(tt.args, url); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("withURL(%q) = %q, want %q", tt.args, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+// Page is the content fetched for a link.
+type Page struct {
+	Title     string
+	Text      string  // Markdown
+	Canonical string  // the page's canonical URL, if it declares one
+	Tag       string  // tag to apply, e.g. a video's channel
+	Thin      bool    // a full page came back but almost no text was extracted
+	HTML      string  // the HTML Text was extracted from, if the fetcher archives pages
+	Images    []Image // images in the content, if the extractor keeps them; nil otherwise
+}
+
+// FetchPage fetches and extracts rawURL. Known video hosts are described from
+// oEmbed metadata instead, falling back to the page itself if that fails.
+func FetchPage(ctx context.Context, fetcher *Fetcher, extractor *Extractor, rawURL string) (Page, error) {
+	if IsVideoURL(rawURL) {
+		video, err := fetcher.FetchVideo(ctx, rawURL)
+		if err == nil {
+			return Page{Title: video.Title, Text: video.Text(), Tag: video.Tag()}, nil
+		}
+		slog.Warn("oEmbed lookup failed, extracting page instead", "url", rawURL, "error", err)
+	}
+
+	html, err := fetcher.FetchURL(ctx, rawURL)
+	if err != nil {
+		return Page{}, fmt.Errorf("fetch failed: %w", err)
+	}
+	title, text, canonical, err := extractor.ExtractText(html, rawURL)
+	if err != nil {
+		return Page{}, fmt.Errorf("extraction failed: %w", err)
+	}
+	page := RenderIfShort(ctx, fetcher, extractor, rawURL, Page{Title: title, Text: text, Canonical: canonical, HTML: html})
+	page.Thin = ThinExtraction(page.HTML, page.Text)
+	page.Images = extractor.ExtractImages(page.HTML, rawURL)
+	if !fetcher.ArchivesHTML() {
+		page.HTML = ""
+	}
+	return page, nil
+}
+
+// PageFromHTML extracts a page from html already fetched for rawURL, such as
+// one saved from a browser for a site behind a login. There is nothing to
+// render again, so thin text is only flagged. html is kept in the page when
+// archive is set.
+func PageFromHTML(extractor *Extractor, rawURL, html string, archive bool) (Page, error) {
+	title, text, canonical, err := extractor.ExtractText(html, rawURL)
+	if err != nil {
+		return Page{}, fmt.Errorf("extraction failed: %w", err)
+	}
+	page := Page{Title: title, Text: text, Canonical: canonical, Thin: ThinExtraction(html, text)}
+	page.Images = extractor.ExtractImages(html, rawURL)
+	if archive {
+		page.HTML = html
+	}
+	return page, nil
+}
+
+// FetchTitle fetches rawURL for its title and canonical URL only, leaving
+// Text empty, for links saved as references rather than for their content.
+// The HTML is still kept when the fetcher archives pages, so the content can
+// be extracted later.
+func FetchTitle(ctx context.Context, fetcher *Fetcher, extractor *Extractor, rawURL string) (Page, error) {
+	if IsVideoURL(rawURL) {
+		video, err := fetcher.FetchVideo(ctx, rawURL)
+		if err == nil {
+			return Page{Title: video.Title, Tag: video.Tag()}, nil
+		}
+		slog.Warn("oEmbed lookup failed, fetching page instead", "url", rawURL, "error", err)
+	}
+
+	html, err := fetcher.FetchURL(ctx, rawURL)
+	if err != nil {
+		return Page{}, fmt.Errorf("fetch failed: %w", err)
+	}
+	title, canonical, err := extractor.ExtractTitle(html, rawURL)
+	if err != nil {
+		return Page{}, fmt.Errorf("extraction failed: %w", err)
+	}
+	page := Page{Title: title, Canonical: canonical}
+	if fetcher.ArchivesHTML() {
+		page.HTML = html
+	}
+	return page, nil
+}
+
+// Text shorter than minExtractedChars, from a page of at least thinPageBytes
+// of HTML, is taken as a failed extraction rather than a short page.
+const (
+	minExtractedChars = 200
+	thinPageBytes     = 1024
+)
+
+// ThinExtraction reports whether text extracted from html is suspiciously
+// short: a real page came back, but almost none of it was recognised as
+// content, as with pages built by JavaScript or unusual layouts.
+func ThinExtraction(html, text string) bool {
+	return len(html) >= thinPageBytes && len(strings.TrimSpace(text)) < minExtractedChars
+}
+
+// shortPageWords is the word count below which extracted text is taken to be
+// the empty shell of a page that renders its content with JavaScript.
+const shortPageWords = 50
+
+// RenderIfShort fetches rawURL again through the fetcher's rendering service
+// when page, extracted from the plain fetch, has almost no text. The rendered
+// page is used only if it has more text; otherwise, or without a rendering
+// service, page is returned unchanged. page.HTML should hold the plain
+// fetch's HTML; it is replaced along with the text.
+func RenderIfShort(ctx context.Context, fetcher *Fetcher, extractor *Extractor, rawURL string, page Page) Page {
+	words := WordCount(page.Text)
+	if !fetcher.CanRender() || fetcher.AlwaysRenders() || words >= shortPageWords {
+		return page
+	}
+
+	slog.Info("page text is short, rendering with JavaScript", "url", rawURL, "words", words)
+	html, err := fetcher.FetchRendered(ctx, rawURL)
+	if err != nil {
+		slog.Warn("rendering failed, keeping plain fetch", "url", rawURL, "error", err)
+		return page
+	}
+	title, text, canonical, err := extractor.ExtractText(html, rawURL)
+	if err != nil || WordCount(text) <= words {
+		return page
+	}
+	if title == "" {
+		title = page.Title
+	}
+	if canonical == "" {
+		canonical = page.Canonical
+	}
+	return Page{Title: title, Text: text, Canonical: canonical, Tag: page.Tag, HTML: html}
+}
@@ -0,0 +1,58 @@
+package services
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DefaultStatus is the status new links get when no StatusRule applies.
+const DefaultStatus = "read_later"
+
+// StatusRule picks a new link's status from its length, so long reads land
+// in the reading queue and short reference pages do not. The zero rule
+// gives every link DefaultStatus.
+type StatusRule struct {
+	MinWords int64  // links with at least this many words are long
+	Long     string // status for long links
+	Short    string // status for shorter links
+}
+
+// ParseStatusRule parses a rule written words:long:short, e.g.
+// "3000:read_later:archived" files links of 3000 words or more under
+// read_later and shorter ones as archived.
+func ParseStatusRule(s string) (StatusRule, error) {
+	parts := strings.Split(strings.TrimSpace(s), ":")
+	if len(parts) != 3 {
+		return StatusRule{}, fmt.Errorf("invalid status rule %q: must be words:long-status:short-status", s)
+	}
+	words, err := strconv.ParseInt(strings.TrimSpace(parts[0]), 10, 64)
+	if err != nil || words <= 0 {
+		return StatusRule{}, fmt.Errorf("invalid status rule %q: word count must be a positive number", s)
+	}
+	rule := StatusRule{MinWords: words}
+	for i, dst := range []*string{&rule.Long, &rule.Short} {
+		status := strings.ToLower(strings.TrimSpace(parts[i+1]))
+		switch status {
+		case "read_later", "remember", "archived":
+			*dst = status
+		default:
+			return StatusRule{}, fmt.Errorf("invalid status rule %q: status %q must be read_later, remember, or archived", s, status)
+		}
+	}
+	return rule, nil
+}
+
+// Status returns the status for a new link of words words. A link with no
+// text, such as one saved by title only, has nothing to measure and gets
+// DefaultStatus.
+func (r StatusRule) Status(words int64) string {
+	switch {
+	case r.MinWords == 0 || words == 0:
+		return DefaultStatus
+	case words >= r.MinWords:
+		return r.Long
+	default:
+		return r.Short
+	}
+}
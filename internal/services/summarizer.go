@@ -2,19 +2,83 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/sashabaranov/go-openai"
 )
 
+// DefaultLLMTimeout bounds each Summarize or SuggestMetadata call, retries
+// included, so a hung request cannot stall an add indefinitely.
+const DefaultLLMTimeout = 60 * time.Second
+
 type Summarizer struct {
-	client *openai.Client
+	client   *openai.Client
+	timeout  time.Duration
+	truncate TruncateStyle // where over-long page text is cut
+	short    bool          // also write a one-line summary with each summary
+	disabled atomic.Bool
 }
 
 func NewSummarizer(apiKey string) *Summarizer {
 	return &Summarizer{
-		client: openai.NewClient(apiKey),
+		client:   openai.NewClient(apiKey),
+		timeout:  DefaultLLMTimeout,
+		truncate: TruncateWords,
+	}
+}
+
+// SetTimeout changes the per-call time limit. Zero or less means no limit
+// beyond the caller's context.
+func (s *Summarizer) SetTimeout(d time.Duration) {
+	s.timeout = d
+}
+
+// SetTruncateStyle sets where page text over the prompt's size limit is cut.
+func (s *Summarizer) SetTruncateStyle(style TruncateStyle) {
+	s.truncate = style
+}
+
+// SetShortSummaries sets whether each new summary also gets a one-line
+// ShortSummary, for scanning lists.
+func (s *Summarizer) SetShortSummaries(on bool) {
+	s.short = on
+}
+
+// ShortSummaries reports whether SetShortSummaries is on.
+func (s *Summarizer) ShortSummaries() bool {
+	return s.short
+}
+
+// Validate checks the API key with a cheap models-list call. If OpenAI
+// rejects the key the summarizer is disabled, so later Summarize and
+// SuggestMetadata calls fail fast instead of hitting the API once per link.
+// Other failures, such as a network error or an outage, are returned but
+// leave it enabled, since they may clear before the next call.
+func (s *Summarizer) Validate(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	if _, err := s.client.ListModels(ctx); err != nil {
+		if rejectedKey(err) {
+			s.disabled.Store(true)
+			return fmt.Errorf("OpenAI API key rejected: %w", err)
+		}
+		return fmt.Errorf("OpenAI API key check failed: %w", err)
 	}
+	return nil
+}
+
+// Enabled reports whether the summarizer is usable. It is false once
+// Validate has found the API key rejected.
+func (s *Summarizer) Enabled() bool {
+	return !s.disabled.Load()
 }
 
 // Summarize generates a summary of the given text using OpenAI.
@@ -23,30 +87,16 @@ func (s *Summarizer) Summarize(ctx context.Context, title, text string) (string,
 	if s.client == nil {
 		return "", 0, 0, fmt.Errorf("OpenAI client not configured")
 	}
-
-	// Truncate text if too long (GPT-4 has limits)
-	maxLength := 8000
-	if len(text) > maxLength {
-		text = text[:maxLength] + "..."
+	if !s.Enabled() {
+		return "", 0, 0, fmt.Errorf("summarization disabled: invalid OpenAI API key")
 	}
 
-	prompt := fmt.Sprintf("Please provide a concise summary (2-3 sentences) of the following web page:\n\nTitle: %s\n\nContent:\n%s", title, text)
-
-	resp, err := s.client.CreateChatCompletion(
+	resp, err := s.createChatCompletion(
 		ctx,
 		openai.ChatCompletionRequest{
-			Model: openai.GPT4oMini,
-			Messages: []openai.ChatCompletionMessage{
-				{
-					Role:    openai.ChatMessageRoleSystem,
-					Content: "You are a helpful assistant that summarizes web content concisely.",
-				},
-				{
-					Role:    openai.ChatMessageRoleUser,
-					Content: prompt,
-				},
-			},
-			MaxTokens:   200,
+			Model:       LLMModel,
+			Messages:    summaryMessages(title, text, s.truncate),
+			MaxTokens:   summaryMaxTokens,
 			Temperature: 0.7,
 		},
 	)
@@ -64,17 +114,131 @@ func (s *Summarizer) Summarize(ctx context.Context, title, text string) (string,
 
 // SuggestMetadata generates suggested category and tags for the given content.
 // Returns the category, tags, input token count, output token count, and any error.
+// The category and tags are empty if the model suggested none; LinkDefaults
+// fills them in.
 func (s *Summarizer) SuggestMetadata(ctx context.Context, title, text string) (category string, tags []string, inputTokens int, outputTokens int, err error) {
 	if s.client == nil {
 		return "", nil, 0, 0, fmt.Errorf("OpenAI client not configured")
 	}
+	if !s.Enabled() {
+		return "", nil, 0, 0, fmt.Errorf("summarization disabled: invalid OpenAI API key")
+	}
+
+	resp, err := s.createChatCompletion(
+		ctx,
+		openai.ChatCompletionRequest{
+			Model:       LLMModel,
+			Messages:    metadataMessages(title, text, s.truncate),
+			MaxTokens:   metadataMaxTokens,
+			Temperature: 0.5,
+		},
+	)
+
+	if err != nil {
+		return "", nil, 0, 0, fmt.Errorf("failed to generate suggestions: %w", err)
+	}
+
+	if len(resp.Choices) == 0 {
+		return "", nil, 0, 0, fmt.Errorf("no suggestions generated")
+	}
+
+	// Parse the response
+	response := resp.Choices[0].Message.Content
+	category, tags, err = parseMetadataResponse(response)
+	return category, tags, resp.Usage.PromptTokens, resp.Usage.CompletionTokens, err
+}
+
+// RefreshShortSummary returns the short summary to store with a new summary:
+// a fresh one from ShortSummary when short summaries are on, or "" without
+// calling the API when they are off, s is nil, or summary is empty. Storing
+// "" clears the old short summary, which no longer matches.
+func (s *Summarizer) RefreshShortSummary(ctx context.Context, title, summary string) (short string, inputTokens, outputTokens int, err error) {
+	if s == nil || !s.short || summary == "" {
+		return "", 0, 0, nil
+	}
+	return s.ShortSummary(ctx, title, summary)
+}
+
+// ShortSummary boils a page's summary down to a single line for lists.
+// Working from the summary rather than the page keeps the call cheap.
+// Returns the line, input token count, output token count, and any error.
+func (s *Summarizer) ShortSummary(ctx context.Context, title, summary string) (string, int, int, error) {
+	if s.client == nil {
+		return "", 0, 0, fmt.Errorf("OpenAI client not configured")
+	}
+	if !s.Enabled() {
+		return "", 0, 0, fmt.Errorf("summarization disabled: invalid OpenAI API key")
+	}
+
+	resp, err := s.createChatCompletion(
+		ctx,
+		openai.ChatCompletionRequest{
+			Model:       LLMModel,
+			Messages:    shortSummaryMessages(title, summary),
+			MaxTokens:   shortSummaryMaxTokens,
+			Temperature: 0.5,
+		},
+	)
+
+	if err != nil {
+		return "", 0, 0, fmt.Errorf("failed to generate short summary: %w", err)
+	}
+
+	if len(resp.Choices) == 0 {
+		return "", 0, 0, fmt.Errorf("no short summary generated")
+	}
+
+	// Keep to one line even if the model wraps or quotes it.
+	line := strings.Join(strings.Fields(resp.Choices[0].Message.Content), " ")
+	line = strings.Trim(line, `"`)
+	return line, resp.Usage.PromptTokens, resp.Usage.CompletionTokens, nil
+}
+
+// Request limits. Page text beyond the character limit is cut off before it
+// is sent.
+const (
+	summaryMaxChars       = 8000
+	summaryMaxTokens      = 200
+	shortSummaryMaxTokens = 40
+	metadataMaxChars      = 6000
+	metadataMaxTokens     = 150
+)
+
+// summaryMessages builds the chat messages Summarize sends for a page, with
+// text over the limit cut as style says.
+func summaryMessages(title, text string, style TruncateStyle) []openai.ChatCompletionMessage {
+	// Truncate text if too long (GPT-4 has limits)
+	text = truncateText(text, summaryMaxChars, style)
+	return []openai.ChatCompletionMessage{
+		{
+			Role:    openai.ChatMessageRoleSystem,
+			Content: "You are a helpful assistant that summarizes web content concisely.",
+		},
+		{
+			Role:    openai.ChatMessageRoleUser,
+			Content: fmt.Sprintf("Please provide a concise summary (2-3 sentences) of the following web page:\n\nTitle: %s\n\nContent:\n%s", title, text),
+		},
+	}
+}
 
-	// Truncate text if too long
-	maxLength := 6000
-	if len(text) > maxLength {
-		text = text[:maxLength] + "..."
+// shortSummaryMessages builds the chat messages ShortSummary sends for a
+// page's summary.
+func shortSummaryMessages(title, summary string) []openai.ChatCompletionMessage {
+	return []openai.ChatCompletionMessage{
+		{
+			Role:    openai.ChatMessageRoleSystem,
+			Content: "You are a helpful assistant that summarizes web content concisely.",
+		},
+		{
+			Role:    openai.ChatMessageRoleUser,
+			Content: fmt.Sprintf("Condense this summary of a web page into one line of at most 15 words, without repeating the title:\n\nTitle: %s\n\nSummary:\n%s", title, summary),
+		},
 	}
+}
 
+// metadataMessages is summaryMessages for SuggestMetadata.
+func metadataMessages(title, text string, style TruncateStyle) []openai.ChatCompletionMessage {
+	text = truncateText(text, metadataMaxChars, style)
 	prompt := fmt.Sprintf(`Analyze the following web page and suggest:
 1. A single category (e.g., "Technology", "Business", "Health", "Education", etc.)
 2. 3-5 relevant tags (comma-separated, lowercase)
@@ -87,38 +251,112 @@ Content:
 Respond in the format:
 Category: <category>
 Tags: <tag1>, <tag2>, <tag3>`, title, text)
-
-	resp, err := s.client.CreateChatCompletion(
-		ctx,
-		openai.ChatCompletionRequest{
-			Model: openai.GPT4oMini,
-			Messages: []openai.ChatCompletionMessage{
-				{
-					Role:    openai.ChatMessageRoleSystem,
-					Content: "You are a helpful assistant that categorizes and tags web content. Always respond in the exact format requested.",
-				},
-				{
-					Role:    openai.ChatMessageRoleUser,
-					Content: prompt,
-				},
-			},
-			MaxTokens:   150,
-			Temperature: 0.5,
+	return []openai.ChatCompletionMessage{
+		{
+			Role:    openai.ChatMessageRoleSystem,
+			Content: "You are a helpful assistant that categorizes and tags web content. Always respond in the exact format requested.",
 		},
-	)
+		{
+			Role:    openai.ChatMessageRoleUser,
+			Content: prompt,
+		},
+	}
+}
 
-	if err != nil {
-		return "", nil, 0, 0, fmt.Errorf("failed to generate suggestions: %w", err)
+// Retry policy for rate limits and server errors, so bulk runs ride out
+// transient throttling instead of skipping links.
+const (
+	maxChatAttempts = 4
+	baseRetryDelay  = 2 * time.Second
+	maxRetryDelay   = 30 * time.Second
+)
+
+// createChatCompletion sends a chat request, retrying 429 and 5xx responses
+// with exponential backoff, or after the server's Retry-After if it sent one.
+// The whole exchange is limited to the summarizer's timeout.
+func (s *Summarizer) createChatCompletion(parent context.Context, req openai.ChatCompletionRequest) (openai.ChatCompletionResponse, error) {
+	ctx := parent
+	if s.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(parent, s.timeout)
+		defer cancel()
 	}
 
-	if len(resp.Choices) == 0 {
-		return "", nil, 0, 0, fmt.Errorf("no suggestions generated")
+	// timedOut distinguishes our own time limit from the caller giving up.
+	timedOut := func() bool {
+		return parent.Err() == nil && errors.Is(ctx.Err(), context.DeadlineExceeded)
 	}
 
-	// Parse the response
-	response := resp.Choices[0].Message.Content
-	category, tags, err = parseMetadataResponse(response)
-	return category, tags, resp.Usage.PromptTokens, resp.Usage.CompletionTokens, err
+	for attempt := 1; ; attempt++ {
+		resp, err := s.client.CreateChatCompletion(ctx, req)
+		if err != nil && timedOut() {
+			return resp, fmt.Errorf("OpenAI request timed out after %s", s.timeout)
+		}
+		if err == nil || attempt == maxChatAttempts || !retryable(err) {
+			return resp, err
+		}
+
+		delay := retryDelay(resp.Header().Get("Retry-After"), attempt)
+		slog.Debug("OpenAI request failed, retrying", "attempt", attempt, "delay", delay, "error", err)
+		t := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			if timedOut() {
+				return resp, fmt.Errorf("OpenAI request timed out after %s: %w", s.timeout, err)
+			}
+			return resp, err
+		case <-t.C:
+		}
+	}
+}
+
+// retryable reports whether an OpenAI error is worth retrying: rate limits
+// and server errors, but not an exhausted quota, which will not clear by
+// waiting.
+func retryable(err error) bool {
+	var status int
+	var apiErr *openai.APIError
+	var reqErr *openai.RequestError
+	switch {
+	case errors.As(err, &apiErr):
+		if apiErr.Type == "insufficient_quota" || apiErr.Code == "insufficient_quota" {
+			return false
+		}
+		status = apiErr.HTTPStatusCode
+	case errors.As(err, &reqErr):
+		status = reqErr.HTTPStatusCode
+	}
+	return status == http.StatusTooManyRequests || status >= 500
+}
+
+// rejectedKey reports whether an OpenAI error is the API refusing the key
+// itself (401 or 403), as opposed to a failure that may clear on its own.
+func rejectedKey(err error) bool {
+	var status int
+	var apiErr *openai.APIError
+	var reqErr *openai.RequestError
+	switch {
+	case errors.As(err, &apiErr):
+		status = apiErr.HTTPStatusCode
+	case errors.As(err, &reqErr):
+		status = reqErr.HTTPStatusCode
+	}
+	return status == http.StatusUnauthorized || status == http.StatusForbidden
+}
+
+// retryDelay returns how long to wait before the next attempt: the
+// Retry-After header (seconds or an HTTP date) when present, otherwise
+// baseRetryDelay doubled for each failed attempt. Either is capped at
+// maxRetryDelay.
+func retryDelay(retryAfter string, attempt int) time.Duration {
+	delay := baseRetryDelay << (attempt - 1)
+	if secs, err := strconv.Atoi(retryAfter); err == nil && secs >= 0 {
+		delay = time.Duration(secs) * time.Second
+	} else if at, err := http.ParseTime(retryAfter); err == nil {
+		delay = time.Until(at)
+	}
+	return min(max(delay, 0), maxRetryDelay)
 }
 
 // parseMetadataResponse parses the LLM response to extract category and tags
@@ -182,12 +420,5 @@ func parseMetadataResponse(response string) (category string, tags []string, err
 		}
 	}
 
-	if category == "" {
-		category = "General"
-	}
-	if len(tags) == 0 {
-		tags = []string{"uncategorized"}
-	}
-
 	return category, tags, nil
 }
@@ -0,0 +1,61 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+func TestValidateDisablesOnlyOnRejectedKey(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		wantEnabled bool
+	}{
+		{"unauthorized", http.StatusUnauthorized, false},
+		{"forbidden", http.StatusForbidden, false},
+		{"server error", http.StatusInternalServerError, true},
+		{"rate limited", http.StatusTooManyRequests, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				w.Write([]byte(`{"error":{"message":"nope","type":"test_error"}}`))
+			}))
+			defer srv.Close()
+
+			cfg := openai.DefaultConfig("test-key")
+			cfg.BaseURL = srv.URL + "/v1"
+			s := &Summarizer{client: openai.NewClientWithConfig(cfg)}
+
+			if err := s.Validate(context.Background()); err == nil {
+				t.Fatal("Validate returned nil, want an error")
+			}
+			if got := s.Enabled(); got != tt.wantEnabled {
+				t.Errorf("Enabled() = %v, want %v", got, tt.wantEnabled)
+			}
+		})
+	}
+
+	t.Run("network error", func(t *testing.T) {
+		srv := httptest.NewServer(http.NotFoundHandler())
+		srv.Close()
+
+		cfg := openai.DefaultConfig("test-key")
+		cfg.BaseURL = srv.URL + "/v1"
+		s := &Summarizer{client: openai.NewClientWithConfig(cfg)}
+
+		if err := s.Validate(context.Background()); err == nil {
+			t.Fatal("Validate returned nil, want an error")
+		}
+		if !s.Enabled() {
+			t.Error("Enabled() = false after a network error, want true")
+		}
+	})
+}
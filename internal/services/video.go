@@ -0,0 +1,84 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// oembedEndpoints maps known video hosts (as returned by DomainFromURL) to
+// their oEmbed endpoint.
+var oembedEndpoints = map[string]string{
+	"youtube.com":   "https://www.youtube.com/oembed",
+	"m.youtube.com": "https://www.youtube.com/oembed",
+	"youtu.be":      "https://www.youtube.com/oembed",
+	"vimeo.com":     "https://vimeo.com/api/oembed.json",
+}
+
+// Video holds the oEmbed metadata for a video page.
+type Video struct {
+	Title       string `json:"title"`
+	Channel     string `json:"author_name"`
+	Description string `json:"description"`
+}
+
+// IsVideoURL reports whether rawURL is on a host with oEmbed support.
+func IsVideoURL(rawURL string) bool {
+	_, ok := oembedEndpoints[DomainFromURL(rawURL)]
+	return ok
+}
+
+// FetchVideo retrieves a video's title, channel, and (where the host provides
+// one) description via oEmbed. Video pages are mostly script, so this gives
+// far more useful content than extracting the HTML.
+func (f *Fetcher) FetchVideo(ctx context.Context, rawURL string) (Video, error) {
+	endpoint, ok := oembedEndpoints[DomainFromURL(rawURL)]
+	if !ok {
+		return Video{}, fmt.Errorf("not a known video host: %s", rawURL)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		endpoint+"?format=json&url="+url.QueryEscape(rawURL), nil)
+	if err != nil {
+		return Video{}, fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return Video{}, fmt.Errorf("failed to fetch oEmbed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return Video{}, fmt.Errorf("unexpected oEmbed status code: %d", resp.StatusCode)
+	}
+
+	var v Video
+	if err := json.NewDecoder(resp.Body).Decode(&v); err != nil {
+		return Video{}, fmt.Errorf("failed to decode oEmbed: %w", err)
+	}
+	v.Title = strings.TrimSpace(v.Title)
+	v.Channel = strings.TrimSpace(v.Channel)
+	v.Description = strings.TrimSpace(v.Description)
+	return v, nil
+}
+
+// Text renders the video metadata as Markdown for storage as link content.
+func (v Video) Text() string {
+	var b strings.Builder
+	b.WriteString("# " + v.Title + "\n")
+	if v.Channel != "" {
+		b.WriteString("\nChannel: " + v.Channel + "\n")
+	}
+	if v.Description != "" {
+		b.WriteString("\n" + v.Description + "\n")
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// Tag returns the channel name as a tag, or "" if the channel is unknown.
+func (v Video) Tag() string {
+	return strings.ToLower(v.Channel)
+}
@@ -0,0 +1,61 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Webhook POSTs link events as JSON to a user-configured URL.
+type Webhook struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhook(url string) *Webhook {
+	return &Webhook{
+		url: url,
+		client: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+}
+
+// LinkAddedEvent is the payload sent when a link is added.
+type LinkAddedEvent struct {
+	URL     string   `json:"url"`
+	Title   string   `json:"title"`
+	Summary string   `json:"summary"`
+	Tags    []string `json:"tags"`
+}
+
+// LinkAdded delivers ev to the webhook. Any non-2xx response is an error.
+func (w *Webhook) LinkAdded(ctx context.Context, ev LinkAddedEvent) error {
+	if ev.Tags == nil {
+		ev.Tags = []string{}
+	}
+	body, err := json.Marshal(ev)
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to deliver webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected webhook status code: %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -10,7 +10,6 @@ import (
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
-	"github.com/pkg/browser"
 
 	"mccwk.com/lm/internal/database"
 	"mccwk.com/lm/internal/models"
@@ -29,11 +28,17 @@ type ActivitiesModel struct {
 	activities         []models.Activity
 	filteredActivities []models.Activity
 	cursor             int
+	loading            bool        // a list load is in flight
+	count              countPrefix // pending numeric prefix, e.g. the 5 in 5j
 	db                 *database.Database
 	ctx                context.Context
 	fetcher            *services.Fetcher
 	extractor          *services.Extractor
 	summarizer         *services.Summarizer
+	statusRule         services.StatusRule
+	categoryRules      services.CategoryRules
+	linkDefaults       services.LinkDefaults
+	tagger             *localTagger
 	links              []models.Link
 	showLinks          bool
 
@@ -42,6 +47,7 @@ type ActivitiesModel struct {
 
 	// Search and focus
 	searchInput textinput.Model
+	cleared     clearedSearch
 	focus       panelFocus
 
 	// Create activity inputs
@@ -56,8 +62,10 @@ type ActivitiesModel struct {
 	detailViewport viewport.Model
 	viewportReady  bool
 
-	width  int
-	height int
+	width      int
+	height     int
+	splitRatio float64 // list panel's share of the width, set by Model
+	browser    string  // command links open with, "" for the system opener; set by Model
 }
 
 func NewActivitiesModel(db *database.Database) ActivitiesModel {
@@ -94,6 +102,12 @@ func (m *ActivitiesModel) SetServices(fetcher *services.Fetcher, extractor *serv
 	m.summarizer = summarizer
 }
 
+// HasUnsavedChanges reports whether the embedded add-link form has edits
+// that quitting would lose.
+func (m ActivitiesModel) HasUnsavedChanges() bool {
+	return m.mode == activitiesAddLinkMode && m.addLinkModel.HasUnsavedChanges()
+}
+
 func (m ActivitiesModel) Update(msg tea.Msg) (ActivitiesModel, tea.Cmd) {
 	var cmd tea.Cmd
 
@@ -103,11 +117,7 @@ func (m ActivitiesModel) Update(msg tea.Msg) (ActivitiesModel, tea.Cmd) {
 		m.height = msg.Height
 
 		// Calculate responsive widths for split view
-		leftWidth := int(float64(m.width) * 0.35)
-		if leftWidth < 30 {
-			leftWidth = 30
-		}
-		rightWidth := m.width - leftWidth - 8
+		_, rightWidth := splitWidths(m.width, m.splitRatio)
 
 		// Calculate height for detail viewport
 		detailHeight := m.height - 12
@@ -176,8 +186,12 @@ func (m ActivitiesModel) Update(msg tea.Msg) (ActivitiesModel, tea.Cmd) {
 		return m, nil
 
 	case activitiesLoadedMsg:
+		id := func(a models.Activity) int64 { return a.ID }
+		selected := selectedID(m.filteredActivities, m.cursor, id)
+		m.loading = false
 		m.activities = msg.activities
 		m.filterActivities()
+		m.cursor = reselect(m.filteredActivities, selected, m.cursor, id)
 		// Automatically load links for the first activity
 		if len(m.filteredActivities) > 0 && m.cursor < len(m.filteredActivities) {
 			return m, m.loadActivityLinks(m.filteredActivities[m.cursor].ID)
@@ -205,7 +219,7 @@ func (m ActivitiesModel) Update(msg tea.Msg) (ActivitiesModel, tea.Cmd) {
 }
 
 func (m ActivitiesModel) handleViewMode(msg tea.KeyMsg) (ActivitiesModel, tea.Cmd) {
-	halfPage := (m.height - 15) / 2
+	halfPage := listRows(m.height, 4) / 2
 	if halfPage < 1 {
 		halfPage = 1
 	}
@@ -228,25 +242,63 @@ func (m ActivitiesModel) handleViewMode(msg tea.KeyMsg) (ActivitiesModel, tea.Cm
 			m.searchInput.Blur()
 		}
 		return m, nil
+	case "left", "h":
+		// ←/h and →/l move between the list and detail panels; in the
+		// search box they are ordinary input.
+		if m.focus != panelFocusSearch {
+			m.focus = focusLeft(m.focus)
+			return m, nil
+		}
+	case "right", "l":
+		if m.focus != panelFocusSearch {
+			m.focus = focusRight(m.focus)
+			return m, nil
+		}
+	case "<", ">":
+		// < and > narrow and widen the list panel on every tab.
+		if m.focus != panelFocusSearch {
+			return m, resizeSplit(msg.String() == ">")
+		}
+	case ":":
+		if m.focus != panelFocusSearch {
+			return m, openPalette
+		}
 	}
 
 	switch m.focus {
 	case panelFocusList:
+		// Digits build a count prefix applied to the next up/down move.
+		if m.count.push(msg.String()) {
+			return m, nil
+		}
+		n := m.count.take()
 		switch msg.String() {
 		case "up", "k":
 			if m.cursor > 0 {
-				m.cursor--
+				m.cursor = max(m.cursor-n, 0)
 				if len(m.filteredActivities) > 0 {
 					return m, m.loadActivityLinks(m.filteredActivities[m.cursor].ID)
 				}
 			}
 		case "down", "j":
 			if m.cursor < len(m.filteredActivities)-1 {
-				m.cursor++
+				m.cursor = min(m.cursor+n, len(m.filteredActivities)-1)
 				if len(m.filteredActivities) > 0 {
 					return m, m.loadActivityLinks(m.filteredActivities[m.cursor].ID)
 				}
 			}
+		case "g", "home":
+			if len(m.filteredActivities) > 0 {
+				m.cursor = 0
+				m.detailViewport.GotoTop()
+				return m, m.loadActivityLinks(m.filteredActivities[m.cursor].ID)
+			}
+		case "G", "end":
+			if len(m.filteredActivities) > 0 {
+				m.cursor = len(m.filteredActivities) - 1
+				m.detailViewport.GotoTop()
+				return m, m.loadActivityLinks(m.filteredActivities[m.cursor].ID)
+			}
 		case "pgup", "ctrl+u":
 			m.cursor -= halfPage
 			if m.cursor < 0 {
@@ -263,13 +315,8 @@ func (m ActivitiesModel) handleViewMode(msg tea.KeyMsg) (ActivitiesModel, tea.Cm
 			if len(m.filteredActivities) > 0 {
 				return m, m.loadActivityLinks(m.filteredActivities[m.cursor].ID)
 			}
-		case "ctrl+a":
-			m.mode = activitiesCreateMode
-			m.createFocus = 0
-			m.focus = panelFocusSearch
-			m.searchInput.Blur()
-			m.nameInput.Focus()
-			m.descInput.Blur()
+		case "ctrl+a", "n":
+			m.startCreate()
 		case "ctrl+o":
 			if m.showLinks && len(m.links) > 0 {
 				return m, m.openLinks()
@@ -288,6 +335,14 @@ func (m ActivitiesModel) handleViewMode(msg tea.KeyMsg) (ActivitiesModel, tea.Cm
 				m.detailViewport, cmd = m.detailViewport.Update(msg)
 				return m, cmd
 			}
+		case "g", "home":
+			if m.viewportReady {
+				m.detailViewport.GotoTop()
+			}
+		case "G", "end":
+			if m.viewportReady {
+				m.detailViewport.GotoBottom()
+			}
 		case "up", "k":
 			if m.viewportReady && m.showLinks {
 				m.detailViewport.ScrollUp(1)
@@ -297,13 +352,22 @@ func (m ActivitiesModel) handleViewMode(msg tea.KeyMsg) (ActivitiesModel, tea.Cm
 				m.detailViewport.ScrollDown(1)
 			}
 		case "ctrl+a":
-			if len(m.filteredActivities) > 0 && m.cursor < len(m.filteredActivities) {
+			// With no activity to add a link to, create one instead.
+			if len(m.filteredActivities) == 0 {
+				m.startCreate()
+				return m, nil
+			}
+			if m.cursor < len(m.filteredActivities) {
 				m.mode = activitiesAddLinkMode
 				m.addLinkModel = NewAddLinkModel()
+				m.addLinkModel.statusRule = m.statusRule
+				m.addLinkModel.categoryRules = m.categoryRules
+				m.addLinkModel.defaults = m.linkDefaults
+				m.addLinkModel.tagger = m.tagger
 				m.addLinkModel.inModal = true
-				return m, func() tea.Msg {
+				return m, tea.Batch(func() tea.Msg {
 					return tea.WindowSizeMsg{Width: m.width, Height: m.height}
-				}
+				}, loadTaxonomy(m.db))
 			}
 		case "ctrl+o":
 			if m.showLinks && len(m.links) > 0 {
@@ -352,21 +416,23 @@ func (m ActivitiesModel) handleViewMode(msg tea.KeyMsg) (ActivitiesModel, tea.Cm
 			}
 			return m, nil
 		case "ctrl+a":
-			m.mode = activitiesCreateMode
-			m.createFocus = 0
-			m.searchInput.Blur()
-			m.nameInput.Focus()
-			m.descInput.Blur()
+			m.startCreate()
 			return m, nil
+		case "n":
+			// With nothing to search, n starts a new activity as in the list.
+			if len(m.activities) == 0 && m.searchInput.Value() == "" {
+				m.startCreate()
+				return m, nil
+			}
 		case "ctrl+o", "enter":
 			if m.showLinks && len(m.links) > 0 {
 				return m, m.openLinks()
 			}
 			return m, nil
 		case "esc":
-			m.searchInput.SetValue("")
+			notice := m.cleared.esc(&m.searchInput)
 			m.filterActivities()
-			return m, nil
+			return m, notice
 		}
 		var cmd tea.Cmd
 		m.searchInput, cmd = m.searchInput.Update(msg)
@@ -383,7 +449,7 @@ func (m ActivitiesModel) handleViewMode(msg tea.KeyMsg) (ActivitiesModel, tea.Cm
 }
 
 func (m *ActivitiesModel) filterActivities() {
-	query := strings.ToLower(m.searchInput.Value())
+	query := m.searchInput.Value()
 	if query == "" {
 		m.filteredActivities = m.activities
 		if m.cursor >= len(m.filteredActivities) {
@@ -391,18 +457,24 @@ func (m *ActivitiesModel) filterActivities() {
 		}
 		return
 	}
-	m.filteredActivities = []models.Activity{}
-	for _, a := range m.activities {
-		if strings.Contains(strings.ToLower(a.Name), query) ||
-			(a.Description.Valid && strings.Contains(strings.ToLower(a.Description.String), query)) {
-			m.filteredActivities = append(m.filteredActivities, a)
-		}
-	}
+	m.filteredActivities = filterRanked(m.activities, query, func(a models.Activity) (string, []string) {
+		return a.Name, []string{a.Description.String}
+	})
 	if m.cursor >= len(m.filteredActivities) {
 		m.cursor = 0
 	}
 }
 
+// startCreate opens the new-activity form.
+func (m *ActivitiesModel) startCreate() {
+	m.mode = activitiesCreateMode
+	m.createFocus = 0
+	m.focus = panelFocusSearch
+	m.searchInput.Blur()
+	m.nameInput.Focus()
+	m.descInput.Blur()
+}
+
 func (m ActivitiesModel) handleCreateMode(msg tea.KeyMsg) (ActivitiesModel, tea.Cmd) {
 	switch msg.String() {
 	case "esc":
@@ -440,6 +512,22 @@ func (m ActivitiesModel) handleCreateMode(msg tea.KeyMsg) (ActivitiesModel, tea.
 	return m, cmd
 }
 
+// Breadcrumb describes what the tab is showing, for the context line under
+// the tab bar.
+func (m ActivitiesModel) Breadcrumb() []string {
+	if m.mode == activitiesCreateMode {
+		return []string{"New activity"}
+	}
+	if len(m.filteredActivities) == 0 || m.cursor >= len(m.filteredActivities) {
+		return []string{"No activities"}
+	}
+	crumbs := []string{m.filteredActivities[m.cursor].Name}
+	if m.mode == activitiesAddLinkMode {
+		return append(crumbs, "Add link")
+	}
+	return append(crumbs, linkCount(len(m.links)))
+}
+
 func (m ActivitiesModel) View() string {
 	switch m.mode {
 	case activitiesViewMode:
@@ -490,11 +578,7 @@ func (m ActivitiesModel) viewActivities() string {
 		return "Loading..."
 	}
 
-	leftWidth := int(float64(m.width) * 0.35)
-	if leftWidth < 30 {
-		leftWidth = 30
-	}
-	rightWidth := m.width - leftWidth - 8
+	leftWidth, rightWidth := splitWidths(m.width, m.splitRatio)
 
 	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("6"))
 	selectedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("10")).Bold(true)
@@ -521,21 +605,22 @@ func (m ActivitiesModel) viewActivities() string {
 	if len(m.filteredActivities) == 0 {
 		if m.searchInput.Value() != "" {
 			leftContent.WriteString(dimStyle.Render("No activities match your search.\n"))
+		} else if m.loading {
+			leftContent.WriteString(dimStyle.Render("Loading...\n"))
 		} else {
-			leftContent.WriteString(dimStyle.Render("No activities yet. Press Ctrl+A to create one!\n"))
+			leftContent.WriteString(dimStyle.Render("No activities yet. Press Ctrl+A or n to create one!\n"))
 		}
 	} else {
-		maxItems := m.height - 15
-		if maxItems < 3 {
-			maxItems = 3
-		}
-		startIdx := 0
-		endIdx := len(m.filteredActivities)
-		if m.cursor >= maxItems {
-			startIdx = m.cursor - maxItems + 1
-		}
-		if endIdx > startIdx+maxItems {
-			endIdx = startIdx + maxItems
+		maxItems := listRows(m.height, 4)
+		startIdx, endIdx := listWindow(len(m.filteredActivities), m.cursor, maxItems, func(i int) int {
+			if a := m.filteredActivities[i]; a.Description.Valid && a.Description.String != "" {
+				return 2
+			}
+			return 1
+		})
+		above, below := moreIndicators(startIdx, endIdx, len(m.filteredActivities))
+		if above != "" {
+			leftContent.WriteString(dimStyle.Render(above) + "\n")
 		}
 
 		for i := startIdx; i < endIdx; i++ {
@@ -562,7 +647,10 @@ func (m ActivitiesModel) viewActivities() string {
 				leftContent.WriteString(dimStyle.Render("  "+desc) + "\n")
 			}
 		}
-		if len(m.filteredActivities) > maxItems {
+		if below != "" {
+			leftContent.WriteString(dimStyle.Render(below) + "\n")
+		}
+		if endIdx-startIdx < len(m.filteredActivities) {
 			leftContent.WriteString("\n" + dimStyle.Render(fmt.Sprintf("  [%d/%d activities]", m.cursor+1, len(m.filteredActivities))))
 		}
 	}
@@ -600,8 +688,7 @@ func (m ActivitiesModel) viewActivities() string {
 					}
 					detailContent.WriteString(fmt.Sprintf("• %s\n", title))
 					detailContent.WriteString(dimStyle.Render("  "+link.Url) + "\n")
-					if link.Summary.Valid && link.Summary.String != "" {
-						summary := link.Summary.String
+					if summary := listSummary(link); summary != "" {
 						wrapped := wrapText(summary, rightWidth-6)
 						detailContent.WriteString(dimStyle.Render("  "+wrapped) + "\n")
 					}
@@ -636,9 +723,9 @@ func (m ActivitiesModel) viewActivities() string {
 	var helpMsg string
 	switch m.focus {
 	case panelFocusList:
-		helpMsg = "Tab: detail • ↑/↓/j/k: navigate • PgUp/PgDn/Ctrl+U/D: jump • Ctrl+A: new • Ctrl+O: open links • Esc: search"
+		helpMsg = "Tab: detail • ↑/↓/j/k: navigate • PgUp/PgDn/Ctrl+U/D: jump • g/G: top/bottom • Ctrl+A/n: new • Ctrl+O: open links • Esc: search"
 	case panelFocusDetail:
-		helpMsg = "Tab: search • ↑/↓/j/k/PgUp/PgDn: scroll • Ctrl+A: add link • Ctrl+O: open links • Esc: search"
+		helpMsg = "Tab: search • ↑/↓/j/k/PgUp/PgDn: scroll • g/G: top/bottom • Ctrl+A: add link • Ctrl+O: open links • Esc: search"
 	default:
 		helpMsg = "type to search • Tab: list • ↑/↓: navigate • Ctrl+A: new • Ctrl+O: open links • Esc: clear"
 	}
@@ -694,9 +781,11 @@ func (m ActivitiesModel) loadActivityLinks(activityID int64) tea.Cmd {
 func (m ActivitiesModel) openLinks() tea.Cmd {
 	return func() tea.Msg {
 		for _, link := range m.links {
-			_ = browser.OpenURL(link.Url)
+			if services.OpenURL(m.browser, link.Url) == nil {
+				m.db.RecordOpen(m.ctx, link.ID)
+			}
 		}
-		return nil
+		return linksVisitedMsg{links: m.links}
 	}
 }
 
@@ -5,7 +5,9 @@ import (
 	"database/sql"
 	"fmt"
 	"strings"
+	"sync"
 
+	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
@@ -18,16 +20,26 @@ import (
 
 type AddLinkModel struct {
 	urlInput      textinput.Model
+	titleInput    textinput.Model
 	categoryInput textinput.Model
 	tagsInput     textinput.Model
-	focusIndex    int  // 0=url, 1=category, 2=tags, 3=summary viewport, 4=content viewport, 5=Save(btn), 6=Cancel(btn)
+	focusIndex    int  // 0=url, 1=title, 2=category, 3=tags, 4=summary viewport, 5=content viewport, 6=Save(btn), 7=Cancel(btn)
 	inModal       bool // whether rendered in modal
+	statusRule    services.StatusRule
+	categoryRules services.CategoryRules
+	defaults      services.LinkDefaults
+	tagger        *localTagger // suggests tags when the LLM does not
 
 	// Save/unsaved state
 	linkID        *int64
 	savedCategory string
 	savedTags     []string
+	savedTitle    string // the link's stored title: as fetched, or as last saved
 	pendingSave   bool
+	// Set when this form created the link, until its first metadata save
+	// reports it as added.
+	announce bool
+	addedURL string
 
 	// Viewports for scrolling
 	contentViewport viewport.Model
@@ -38,13 +50,26 @@ type AddLinkModel struct {
 	// Processing state
 	isProcessing bool
 	processStage string // e.g. "Fetching...", "Extracting...", "Summarizing..."
+	thin         bool   // the page came back but almost no text was extracted
+	titleOnly    bool   // save the page title without content or summary (Ctrl+E)
 	previewText  string
 	summary      string
+	summaryShort string // one-line summary for lists, if one was written
+
+	// Content editing (Ctrl+X): cut the extracted text down to the part
+	// worth keeping, or paste your own in its place
+	contentEditor  textarea.Model
+	editingContent bool
+	contentEdited  bool // edited since the link was last saved
 
 	// Suggested values
 	suggestedCategory string
 	suggestedTags     []string
 
+	// Autocompletion from existing categories/tags
+	categoryComplete completer
+	tagsComplete     completer
+
 	width  int
 	height int
 
@@ -63,6 +88,11 @@ func NewAddLinkModelForTask(taskID *int64) AddLinkModel {
 	urlInput.Width = 40
 	urlInput.Prompt = "> "
 
+	titleInput := textinput.New()
+	titleInput.Placeholder = "the page's title"
+	titleInput.Width = 40
+	titleInput.Prompt = "> "
+
 	categoryInput := textinput.New()
 	categoryInput.Placeholder = "e.g., Technology"
 	categoryInput.Width = 40
@@ -73,12 +103,20 @@ func NewAddLinkModelForTask(taskID *int64) AddLinkModel {
 	tagsInput.Width = 40
 	tagsInput.Prompt = "> "
 
+	contentEditor := textarea.New()
+	contentEditor.Placeholder = "Paste or type the content to keep..."
+	contentEditor.ShowLineNumbers = false
+
 	return AddLinkModel{
-		urlInput:      urlInput,
-		categoryInput: categoryInput,
-		tagsInput:     tagsInput,
-		focusIndex:    0,
-		taskID:        taskID,
+		urlInput:         urlInput,
+		titleInput:       titleInput,
+		categoryInput:    categoryInput,
+		tagsInput:        tagsInput,
+		contentEditor:    contentEditor,
+		focusIndex:       0,
+		taskID:           taskID,
+		categoryComplete: newCompleter(false),
+		tagsComplete:     newCompleter(true),
 	}
 }
 
@@ -88,22 +126,36 @@ func (m AddLinkModel) Init() tea.Cmd {
 
 func (m AddLinkModel) resetForm() AddLinkModel {
 	m.urlInput.SetValue("")
+	m.titleInput.SetValue("")
 	m.categoryInput.SetValue("")
 	m.tagsInput.SetValue("")
 	m.isProcessing = false
 	m.processStage = ""
+	m.thin = false
+	m.titleOnly = false
 	m.previewText = ""
 	m.summary = ""
+	m.summaryShort = ""
+	m.editingContent = false
+	m.contentEdited = false
+	m.contentEditor.Blur()
+	m.contentEditor.SetValue("")
 	m.suggestedCategory = ""
 	m.suggestedTags = nil
 	m.linkID = nil
 	m.savedCategory = ""
 	m.savedTags = nil
+	m.savedTitle = ""
 	m.pendingSave = false
+	m.announce = false
+	m.addedURL = ""
 	m.focusIndex = 0
 	m.urlInput.Focus()
+	m.titleInput.Blur()
 	m.categoryInput.Blur()
 	m.tagsInput.Blur()
+	m.categoryComplete.refresh("")
+	m.tagsComplete.refresh("")
 	if m.viewportReady {
 		m.contentViewport.SetContent("")
 		m.contentViewport.GotoTop()
@@ -175,6 +227,11 @@ func (m AddLinkModel) Update(msg tea.Msg, db *database.Database, fetcher *servic
 			m.contentViewport.Height = contentViewportLines
 		}
 
+		// The editor fills the modal, inside its border, padding, and the
+		// lines around it.
+		m.contentEditor.SetWidth(min(max(m.width-10, 60), 100) - 6)
+		m.contentEditor.SetHeight(max(m.height-10, 20) - 10)
+
 		return m, nil
 
 	case tea.KeyMsg:
@@ -183,19 +240,52 @@ func (m AddLinkModel) Update(msg tea.Msg, db *database.Database, fetcher *servic
 			return m, nil
 		}
 
+		// The content editor takes every key until Ctrl+X closes it.
+		if m.editingContent {
+			if msg.String() == "ctrl+x" {
+				m.finishContentEdit()
+				return m, nil
+			}
+			m.contentEditor, cmd = m.contentEditor.Update(msg)
+			return m, cmd
+		}
+
+		// An open completion dropdown takes Tab and the arrow keys.
+		if c := m.focusedCompleter(); c != nil && c.active() {
+			switch msg.String() {
+			case "tab":
+				if m.focusIndex == 2 {
+					m.categoryInput.SetValue(c.accept(m.categoryInput.Value()))
+					m.categoryInput.CursorEnd()
+				} else {
+					m.tagsInput.SetValue(c.accept(m.tagsInput.Value()))
+					m.tagsInput.CursorEnd()
+				}
+				c.refresh("")
+				return m, nil
+			case "up":
+				c.move(-1)
+				return m, nil
+			case "down":
+				c.move(1)
+				return m, nil
+			}
+		}
+
 		switch msg.String() {
 		case "tab":
 			// Cycle focus; in modal include buttons
 			m.focusIndex++
-			maxIdx := 2
+			maxIdx := 3
 			if m.inModal {
-				maxIdx = 6
+				maxIdx = 7
 			}
 			if m.focusIndex > maxIdx {
 				m.focusIndex = 0
 			}
 
 			m.urlInput.Blur()
+			m.titleInput.Blur()
 			m.categoryInput.Blur()
 			m.tagsInput.Blur()
 
@@ -203,8 +293,10 @@ func (m AddLinkModel) Update(msg tea.Msg, db *database.Database, fetcher *servic
 			case 0:
 				m.urlInput.Focus()
 			case 1:
-				m.categoryInput.Focus()
+				m.titleInput.Focus()
 			case 2:
+				m.categoryInput.Focus()
+			case 3:
 				m.tagsInput.Focus()
 			}
 
@@ -214,15 +306,16 @@ func (m AddLinkModel) Update(msg tea.Msg, db *database.Database, fetcher *servic
 			// Cycle backward; in modal include buttons
 			m.focusIndex--
 			minIdx := 0
-			maxIdx := 2
+			maxIdx := 3
 			if m.inModal {
-				maxIdx = 6
+				maxIdx = 7
 			}
 			if m.focusIndex < minIdx {
 				m.focusIndex = maxIdx
 			}
 
 			m.urlInput.Blur()
+			m.titleInput.Blur()
 			m.categoryInput.Blur()
 			m.tagsInput.Blur()
 
@@ -230,32 +323,37 @@ func (m AddLinkModel) Update(msg tea.Msg, db *database.Database, fetcher *servic
 			case 0:
 				m.urlInput.Focus()
 			case 1:
-				m.categoryInput.Focus()
+				m.titleInput.Focus()
 			case 2:
+				m.categoryInput.Focus()
+			case 3:
 				m.tagsInput.Focus()
 			}
 
 			return m, nil
 
 		case "ctrl+n":
-			// Cycle focus forward: url(0) -> category(1) -> tags(2) -> summary(3) -> content(4) -> url(0)
+			// Cycle focus forward: url(0) -> title(1) -> category(2) -> tags(3) -> summary(4) -> content(5) -> url(0)
 			m.focusIndex++
-			if m.focusIndex > 4 {
+			if m.focusIndex > 5 {
 				m.focusIndex = 0
 			}
 
 			// Update input focus
 			m.urlInput.Blur()
+			m.titleInput.Blur()
 			m.categoryInput.Blur()
 			m.tagsInput.Blur()
 
-			if m.focusIndex <= 2 {
+			if m.focusIndex <= 3 {
 				switch m.focusIndex {
 				case 0:
 					m.urlInput.Focus()
 				case 1:
-					m.categoryInput.Focus()
+					m.titleInput.Focus()
 				case 2:
+					m.categoryInput.Focus()
+				case 3:
 					m.tagsInput.Focus()
 				}
 			}
@@ -265,21 +363,24 @@ func (m AddLinkModel) Update(msg tea.Msg, db *database.Database, fetcher *servic
 			// Cycle focus backward
 			m.focusIndex--
 			if m.focusIndex < 0 {
-				m.focusIndex = 4
+				m.focusIndex = 5
 			}
 
 			// Update input focus
 			m.urlInput.Blur()
+			m.titleInput.Blur()
 			m.categoryInput.Blur()
 			m.tagsInput.Blur()
 
-			if m.focusIndex <= 2 {
+			if m.focusIndex <= 3 {
 				switch m.focusIndex {
 				case 0:
 					m.urlInput.Focus()
 				case 1:
-					m.categoryInput.Focus()
+					m.titleInput.Focus()
 				case 2:
+					m.categoryInput.Focus()
+				case 3:
 					m.tagsInput.Focus()
 				}
 			}
@@ -288,11 +389,11 @@ func (m AddLinkModel) Update(msg tea.Msg, db *database.Database, fetcher *servic
 		case "pgup", "pgdown":
 
 			// Scroll the focused viewport
-			if m.focusIndex == 3 && m.summaryReady {
+			if m.focusIndex == 4 && m.summaryReady {
 				// Scroll summary
 				m.summaryViewport, cmd = m.summaryViewport.Update(msg)
 				return m, cmd
-			} else if m.focusIndex == 4 && m.viewportReady {
+			} else if m.focusIndex == 5 && m.viewportReady {
 				// Scroll content
 				m.contentViewport, cmd = m.contentViewport.Update(msg)
 				return m, cmd
@@ -302,6 +403,42 @@ func (m AddLinkModel) Update(msg tea.Msg, db *database.Database, fetcher *servic
 				return m, cmd
 			}
 
+		case "ctrl+e":
+			// Toggle saving the link as a bare reference.
+			m.titleOnly = !m.titleOnly
+			return m, nil
+
+		case "ctrl+x":
+			// Edit the content down before saving.
+			if m.linkID == nil {
+				return m, notifyCmd("info", "Fetch the link first, then edit its content")
+			}
+			m.urlInput.Blur()
+			m.titleInput.Blur()
+			m.categoryInput.Blur()
+			m.tagsInput.Blur()
+			m.focusIndex = 5
+			m.editingContent = true
+			m.contentEditor.SetValue(m.previewText)
+			m.contentEditor.CursorStart()
+			return m, m.contentEditor.Focus()
+
+		case "ctrl+g", "ctrl+t":
+			// Rewrite the summary, or its one-line version, on its own.
+			if m.linkID == nil {
+				return m, notifyCmd("info", "Fetch the link first, then rewrite its summary")
+			}
+			title := strings.TrimSpace(m.titleInput.Value())
+			if msg.String() == "ctrl+g" {
+				m.isProcessing = true
+				return m, tea.Batch(regenerateSummaryCmd(ctx, db, summarizer, *m.linkID, title), notifyCmd("info", "Rewriting summary..."))
+			}
+			if m.summary == "" {
+				return m, notifyCmd("info", "No summary to shorten")
+			}
+			m.isProcessing = true
+			return m, tea.Batch(regenerateShortSummaryCmd(ctx, db, summarizer, *m.linkID, title, m.summary), notifyCmd("info", "Rewriting short summary..."))
+
 		case "ctrl+l":
 			// Accept LLM suggestions
 			if m.suggestedCategory != "" {
@@ -310,12 +447,14 @@ func (m AddLinkModel) Update(msg tea.Msg, db *database.Database, fetcher *servic
 			if len(m.suggestedTags) > 0 {
 				m.tagsInput.SetValue(strings.Join(m.suggestedTags, ", "))
 			}
+			m.categoryComplete.refresh("")
+			m.tagsComplete.refresh("")
 			return m, nil
 
 		case "enter":
 			// Activate buttons if focused in modal
 			if m.inModal && !m.isProcessing {
-				if m.focusIndex == 5 { // Save button
+				if m.focusIndex == 6 { // Save button
 					if m.linkID == nil {
 						url := m.urlInput.Value()
 						if url != "" {
@@ -323,6 +462,7 @@ func (m AddLinkModel) Update(msg tea.Msg, db *database.Database, fetcher *servic
 							m.processStage = "Fetching..."
 							m.previewText = ""
 							m.summary = ""
+							m.summaryShort = ""
 							m.suggestedCategory = ""
 							m.suggestedTags = nil
 							m.pendingSave = true
@@ -335,42 +475,51 @@ func (m AddLinkModel) Update(msg tea.Msg, db *database.Database, fetcher *servic
 					}
 					return m, m.saveMetadata(db)
 				}
-				if m.focusIndex == 6 { // Cancel button — closes the dialog
+				if m.focusIndex == 7 { // Cancel button — closes the dialog
 					return m, func() tea.Msg { return addLinkCloseRequestedMsg{} }
 				}
 			}
-			url := m.urlInput.Value()
-			if url != "" && !m.isProcessing {
-				m.isProcessing = true
-				m.previewText = ""
-				m.summary = ""
-				m.suggestedCategory = ""
-				m.suggestedTags = nil
-				if m.viewportReady {
-					m.contentViewport.SetContent("")
-				}
-				m.processStage = "Fetching..."
-				return m, tea.Batch(notifyCmd("info", "Fetching..."), m.fetchLink(url, db, fetcher, ctx))
+			if m.urlInput.Value() != "" && !m.isProcessing {
+				return m.startFetch(db, fetcher, ctx)
 			}
 
 		}
 
+	case taxonomyLoadedMsg:
+		m.categoryComplete.setOptions(msg.categories)
+		m.tagsComplete.setOptions(msg.tags)
+		return m, nil
+
 	case linkFetchedMsg:
 		m.processStage = "Extracting..."
-		return m, tea.Batch(notifyCmd("info", "Extracting..."), m.extractLink(msg.url, msg.html, extractor))
+		if m.titleOnly {
+			return m, tea.Batch(notifyCmd("info", "Extracting..."), m.extractTitle(msg.url, msg.html, fetcher, extractor))
+		}
+		return m, tea.Batch(notifyCmd("info", "Extracting..."), m.extractLink(msg.url, msg.html, fetcher, extractor, ctx))
 
 	case linkExtractedMsg:
 		m.processStage = "Summarizing..."
-		return m, tea.Batch(notifyCmd("info", "Summarizing..."), m.summarizeAndSave(msg.url, msg.title, msg.text, msg.content, msg.preview, db, summarizer, ctx))
+		m.thin = msg.thin
+		if m.titleOnly {
+			// Video links arrive here straight from oEmbed with a
+			// description; drop that too.
+			msg.text, msg.content, msg.preview = "", "", ""
+			summarizer = nil
+		}
+		return m, tea.Batch(notifyCmd("info", "Summarizing..."), m.summarizeAndSave(msg.url, msg.title, msg.text, msg.content, msg.preview, msg.tag, msg.html, msg.images, db, summarizer, ctx))
 
 	case linkProcessCompleteMsg:
 		m.processStage = ""
 		m.isProcessing = false
 		m.previewText = msg.preview
 		m.summary = msg.summary
+		m.summaryShort = msg.short
 		m.suggestedCategory = msg.category
 		m.suggestedTags = msg.tags
 		m.linkID = &msg.linkID
+		m.announce = msg.created
+		m.addedURL = msg.url
+		m.savedTitle = msg.title
 
 		// Update viewport contents
 		if m.viewportReady {
@@ -384,6 +533,9 @@ func (m AddLinkModel) Update(msg tea.Msg, db *database.Database, fetcher *servic
 		}
 
 		// Auto-fill if empty
+		if m.titleInput.Value() == "" {
+			m.titleInput.SetValue(msg.title)
+		}
 		if m.categoryInput.Value() == "" && msg.category != "" {
 			m.categoryInput.SetValue(msg.category)
 		}
@@ -391,9 +543,18 @@ func (m AddLinkModel) Update(msg tea.Msg, db *database.Database, fetcher *servic
 			m.tagsInput.SetValue(strings.Join(msg.tags, ", "))
 		}
 
+		var warn tea.Cmd
+		if m.thin && msg.created {
+			warn = notifyCmd("warning", "Extraction may have failed: content is very short. Edit the link (e) and Reload to retry")
+		}
+		m.thin = false
+
 		if m.pendingSave {
 			m.pendingSave = false
-			return m, tea.Batch(m.saveMetadata(db), notifyCmd("info", "Link saved!"))
+			return m, tea.Batch(m.saveMetadata(db), notifyCmd("info", "Link saved!"), warn)
+		}
+		if warn != nil {
+			return m, warn
 		}
 		return m, notifyCmd("info", "Link fetched!")
 
@@ -402,6 +563,21 @@ func (m AddLinkModel) Update(msg tea.Msg, db *database.Database, fetcher *servic
 		m.processStage = ""
 		return m, notifyCmd("error", msg.err.Error())
 
+	case summaryRegeneratedMsg:
+		m.isProcessing = false
+		if msg.err != nil {
+			return m, notifyCmd("error", msg.err.Error())
+		}
+		if msg.short {
+			m.summaryShort = msg.text
+			return m, notifyCmd("info", "Short summary rewritten")
+		}
+		m.summary = msg.text
+		if m.summaryReady {
+			m.summaryViewport.GotoTop()
+		}
+		return m, notifyCmd("info", "Summary rewritten")
+
 	case metadataSavedMsg:
 		// update saved state for highlighting
 		m.savedCategory = strings.TrimSpace(m.categoryInput.Value())
@@ -415,11 +591,19 @@ func (m AddLinkModel) Update(msg tea.Msg, db *database.Database, fetcher *servic
 			}
 		}
 		m.savedTags = curTags
+		m.contentEdited = false
+		if title := strings.TrimSpace(m.titleInput.Value()); title != "" {
+			m.savedTitle = title
+		}
+		cmds := []tea.Cmd{notifyCmd("info", "Link saved!")}
+		if m.announce {
+			m.announce = false
+			ev := services.LinkAddedEvent{URL: m.addedURL, Title: m.savedTitle, Summary: m.summary, Tags: curTags}
+			cmds = append(cmds, func() tea.Msg { return linkAddedMsg{event: ev} })
+		}
 		// Close the dialog after saving and notify
-		return m, tea.Batch(
-			notifyCmd("info", "Link saved!"),
-			func() tea.Msg { return addLinkCloseRequestedMsg{} },
-		)
+		cmds = append(cmds, func() tea.Msg { return addLinkCloseRequestedMsg{} })
+		return m, tea.Batch(cmds...)
 	}
 
 	// Update the focused input
@@ -427,14 +611,50 @@ func (m AddLinkModel) Update(msg tea.Msg, db *database.Database, fetcher *servic
 	case 0:
 		m.urlInput, cmd = m.urlInput.Update(msg)
 	case 1:
-		m.categoryInput, cmd = m.categoryInput.Update(msg)
+		m.titleInput, cmd = m.titleInput.Update(msg)
 	case 2:
+		m.categoryInput, cmd = m.categoryInput.Update(msg)
+		if _, ok := msg.(tea.KeyMsg); ok {
+			m.categoryComplete.refresh(m.categoryInput.Value())
+		}
+	case 3:
 		m.tagsInput, cmd = m.tagsInput.Update(msg)
+		if _, ok := msg.(tea.KeyMsg); ok {
+			m.tagsComplete.refresh(m.tagsInput.Value())
+		}
 	}
 
 	return m, cmd
 }
 
+// focusedCompleter returns the completer for the focused input, if any.
+func (m *AddLinkModel) focusedCompleter() *completer {
+	switch m.focusIndex {
+	case 2:
+		return &m.categoryComplete
+	case 3:
+		return &m.tagsComplete
+	}
+	return nil
+}
+
+// completionView renders the dropdown for the input at idx when it is focused.
+func (m AddLinkModel) completionView(idx int) string {
+	if m.focusIndex != idx {
+		return ""
+	}
+	var v string
+	if idx == 2 {
+		v = m.categoryComplete.view()
+	} else {
+		v = m.tagsComplete.view()
+	}
+	if v == "" {
+		return ""
+	}
+	return v + "\n"
+}
+
 func (m AddLinkModel) View() string {
 	const minTerminalHeight = 24
 	const minTerminalWidth = 80
@@ -473,8 +693,9 @@ func (m AddLinkModel) View() string {
 
 		content := titleStyle.Render("Add Link") + "\n\n"
 		content += m.urlInput.View() + "\n\n"
-		content += m.categoryInput.View() + "\n\n"
-		content += m.tagsInput.View() + "\n\n"
+		content += m.titleInput.View() + "\n\n"
+		content += m.categoryInput.View() + "\n" + m.completionView(2) + "\n"
+		content += m.tagsInput.View() + "\n" + m.completionView(3) + "\n"
 
 		content += warningStyle.Render(fmt.Sprintf(
 			"⚠ Terminal too narrow (width: %d, need: %d)\n"+
@@ -529,35 +750,12 @@ func (m AddLinkModel) View() string {
 	leftContent += "\n\n"
 	leftContent += lipgloss.NewStyle().Bold(true).Render("URL:") + "\n" + m.urlInput.View() + "\n\n"
 	// Highlight unsaved fields
-	unsavedCat := m.linkID != nil && strings.TrimSpace(m.categoryInput.Value()) != strings.TrimSpace(m.savedCategory)
-	unsavedTags := false
-	if m.linkID != nil {
-		curTags := []string{}
-		if strings.TrimSpace(m.tagsInput.Value()) != "" {
-			for _, s := range strings.Split(m.tagsInput.Value(), ",") {
-				t := strings.ToLower(strings.TrimSpace(s))
-				if t != "" {
-					curTags = append(curTags, t)
-				}
-			}
-		}
-		// simple set compare
-		if len(curTags) != len(m.savedTags) {
-			unsavedTags = true
-		} else {
-			mset := map[string]struct{}{}
-			for _, t := range m.savedTags {
-				mset[t] = struct{}{}
-			}
-			for _, t := range curTags {
-				if _, ok := mset[t]; !ok {
-					unsavedTags = true
-					break
-				}
-			}
-		}
-	}
+	unsavedTitle, unsavedCat, unsavedTags := m.unsavedFields()
 
+	titleLabel := "Title:"
+	if unsavedTitle {
+		titleLabel = lipgloss.NewStyle().Foreground(lipgloss.Color("11")).Render("Title (unsaved):")
+	}
 	catLabel := "Category:"
 	if unsavedCat {
 		catLabel = lipgloss.NewStyle().Foreground(lipgloss.Color("11")).Render("Category (unsaved):")
@@ -567,8 +765,10 @@ func (m AddLinkModel) View() string {
 		tagLabel = lipgloss.NewStyle().Foreground(lipgloss.Color("11")).Render("Tags (unsaved):")
 	}
 
-	leftContent += lipgloss.NewStyle().Bold(true).Render(catLabel) + "\n" + m.categoryInput.View() + "\n\n"
-	leftContent += lipgloss.NewStyle().Bold(true).Render(tagLabel) + "\n" + m.tagsInput.View() + "\n\n"
+	leftContent += lipgloss.NewStyle().Bold(true).Render(titleLabel) + "\n" + m.titleInput.View() + "\n\n"
+	leftContent += lipgloss.NewStyle().Bold(true).Render(catLabel) + "\n" + m.categoryInput.View() + "\n" + m.completionView(2) + "\n"
+	leftContent += lipgloss.NewStyle().Bold(true).Render(tagLabel) + "\n" + m.tagsInput.View() + "\n" + m.completionView(3) + "\n"
+	leftContent += m.titleOnlyView() + "\n\n"
 
 	// Progress indicator — detailed stage shown via bubbleup notification overlay.
 	if m.processStage != "" {
@@ -594,7 +794,7 @@ func (m AddLinkModel) View() string {
 	summaryBoxContent := ""
 
 	// Add visual indicator if this viewport has focus
-	if m.focusIndex == 3 {
+	if m.focusIndex == 4 {
 		summaryBoxContent = lipgloss.NewStyle().
 			Bold(true).
 			Foreground(lipgloss.Color("10")).
@@ -609,6 +809,9 @@ func (m AddLinkModel) View() string {
 		if summaryContent == "" {
 			summaryContent = "Summary will appear here..."
 		}
+		if m.summaryShort != "" {
+			summaryContent = "Short: " + m.summaryShort + "\n\n" + summaryContent
+		}
 		m.summaryViewport.SetContent(summaryContent)
 
 		// Render viewport
@@ -631,7 +834,7 @@ func (m AddLinkModel) View() string {
 	contentBoxContent := ""
 
 	// Add visual indicator if this viewport has focus
-	if m.focusIndex == 4 {
+	if m.focusIndex == 5 {
 		contentBoxContent = lipgloss.NewStyle().
 			Bold(true).
 			Foreground(lipgloss.Color("12")).
@@ -675,20 +878,116 @@ func (m AddLinkModel) View() string {
 	// Help text
 	helpText := "\n" + lipgloss.NewStyle().
 		Foreground(lipgloss.Color("241")).
-		Render("Tab: cycle inputs • Ctrl+N/P: cycle sections • Enter: submit • Ctrl+R: reset • Ctrl+L: accept • PgUp/PgDn: scroll focused")
+		Render("Tab: cycle inputs • Ctrl+N/P: cycle sections • Enter: submit • Ctrl+R: reset • Ctrl+L: accept • Ctrl+G/Ctrl+T: rewrite summary/short • PgUp/PgDn: scroll focused")
 
 	return mainContent + helpText
 }
 
+// finishContentEdit closes the content editor, keeping its text as the
+// content to save if it was changed.
+func (m *AddLinkModel) finishContentEdit() {
+	m.editingContent = false
+	m.contentEditor.Blur()
+	text := strings.TrimSpace(m.contentEditor.Value())
+	if text == strings.TrimSpace(m.previewText) {
+		return
+	}
+	m.previewText = text
+	m.contentEdited = true
+	if m.viewportReady {
+		m.contentViewport.SetContent(text)
+		m.contentViewport.GotoTop()
+	}
+}
+
+// titleOnlyView renders the Ctrl+E title-only toggle.
+func (m AddLinkModel) titleOnlyView() string {
+	check := "[ ]"
+	if m.titleOnly {
+		check = "[x]"
+	}
+	return lipgloss.NewStyle().Foreground(lipgloss.Color("243")).Render(check + " Title only, no content or summary (Ctrl+E)")
+}
+
+// unsavedFields reports whether the title, category, and tags inputs differ
+// from what was last saved for the link. A blank title keeps the stored one.
+func (m AddLinkModel) unsavedFields() (title, category, tags bool) {
+	if m.linkID == nil {
+		return false, false, false
+	}
+	t := strings.TrimSpace(m.titleInput.Value())
+	title = t != "" && t != m.savedTitle
+	category = strings.TrimSpace(m.categoryInput.Value()) != strings.TrimSpace(m.savedCategory)
+
+	curTags := []string{}
+	if strings.TrimSpace(m.tagsInput.Value()) != "" {
+		for _, s := range strings.Split(m.tagsInput.Value(), ",") {
+			t := strings.ToLower(strings.TrimSpace(s))
+			if t != "" {
+				curTags = append(curTags, t)
+			}
+		}
+	}
+	// simple set compare
+	if len(curTags) != len(m.savedTags) {
+		return title, category, true
+	}
+	mset := map[string]struct{}{}
+	for _, t := range m.savedTags {
+		mset[t] = struct{}{}
+	}
+	for _, t := range curTags {
+		if _, ok := mset[t]; !ok {
+			return title, category, true
+		}
+	}
+	return title, category, false
+}
+
+// HasUnsavedChanges reports whether closing the form would lose work: a
+// save still waiting on processing, or title/category/tag/content edits not
+// yet saved.
+func (m AddLinkModel) HasUnsavedChanges() bool {
+	if m.pendingSave || m.editingContent || m.contentEdited {
+		return true
+	}
+	title, category, tags := m.unsavedFields()
+	return title || category || tags
+}
+
 // ViewModal renders a compact version of the add link form suitable for modal display
 func (m AddLinkModel) saveMetadata(db *database.Database) tea.Cmd {
 	linkID := m.linkID
+	title := strings.TrimSpace(m.titleInput.Value())
+	titleChanged, _, _ := m.unsavedFields()
 	category := strings.TrimSpace(m.categoryInput.Value())
 	tagStr := m.tagsInput.Value()
+	contentEdited, content := m.contentEdited, m.previewText
 	return func() tea.Msg {
 		if linkID == nil {
 			return linkProcessErrorMsg{err: fmt.Errorf("no link to save")}
 		}
+		if contentEdited {
+			err := db.Queries.SetLinkContent(context.Background(), models.SetLinkContentParams{
+				Content:       sql.NullString{String: content, Valid: content != ""},
+				ContentLength: services.WordCount(content),
+				ID:            *linkID,
+			})
+			if err != nil {
+				return linkProcessErrorMsg{err: fmt.Errorf("content save failed: %w", err)}
+			}
+		}
+		// An edited title overrides the page's own, including on refetch.
+		if titleChanged {
+			err := db.Queries.SetLinkTitle(context.Background(), models.SetLinkTitleParams{
+				Title:       sql.NullString{String: title, Valid: true},
+				CustomTitle: true,
+				ID:          *linkID,
+			})
+			if err != nil {
+				return linkProcessErrorMsg{err: fmt.Errorf("title save failed: %w", err)}
+			}
+		}
 		// Save category if provided
 		if category != "" {
 			cat, err := db.Queries.GetCategoryByName(context.Background(), category)
@@ -704,6 +1003,9 @@ func (m AddLinkModel) saveMetadata(db *database.Database) tea.Cmd {
 			}
 			// Link category
 			_ = db.Queries.LinkCategory(context.Background(), models.LinkCategoryParams{LinkID: *linkID, CategoryID: cat.ID})
+			if err := db.ApplyCategoryDefaultTags(context.Background(), *linkID, cat); err != nil {
+				return linkProcessErrorMsg{err: fmt.Errorf("category default tags failed: %w", err)}
+			}
 		}
 		// Save tags
 		if strings.TrimSpace(tagStr) != "" {
@@ -744,34 +1046,20 @@ func (m AddLinkModel) ViewModal(maxWidth, maxHeight int) string {
 		content.WriteString(titleStyle.Render("Add Link") + "\n\n")
 	}
 
+	// The content editor takes the whole dialog while it is open.
+	if m.editingContent {
+		content.WriteString(lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("12")).Render("Page Content (editing):") + "\n")
+		content.WriteString(m.contentEditor.View() + "\n\n")
+		content.WriteString(dimStyle.Render("Delete what you don't need, or paste your own text • Ctrl+X: done • Esc: close without saving"))
+		return content.String()
+	}
+
 	// Inputs with unsaved highlighting
 	content.WriteString(m.urlInput.View() + "\n\n")
-	unsavedCat := m.linkID != nil && strings.TrimSpace(m.categoryInput.Value()) != strings.TrimSpace(m.savedCategory)
-	unsavedTags := false
-	if m.linkID != nil {
-		curTags := []string{}
-		if strings.TrimSpace(m.tagsInput.Value()) != "" {
-			for _, s := range strings.Split(m.tagsInput.Value(), ",") {
-				t := strings.ToLower(strings.TrimSpace(s))
-				if t != "" {
-					curTags = append(curTags, t)
-				}
-			}
-		}
-		if len(curTags) != len(m.savedTags) {
-			unsavedTags = true
-		} else {
-			mset := map[string]struct{}{}
-			for _, t := range m.savedTags {
-				mset[t] = struct{}{}
-			}
-			for _, t := range curTags {
-				if _, ok := mset[t]; !ok {
-					unsavedTags = true
-					break
-				}
-			}
-		}
+	unsavedTitle, unsavedCat, unsavedTags := m.unsavedFields()
+	titleLabel := "Title:"
+	if unsavedTitle {
+		titleLabel = lipgloss.NewStyle().Foreground(lipgloss.Color("11")).Render("Title (unsaved):")
 	}
 	catLabel := "Category:"
 	if unsavedCat {
@@ -781,10 +1069,13 @@ func (m AddLinkModel) ViewModal(maxWidth, maxHeight int) string {
 	if unsavedTags {
 		tagLabel = lipgloss.NewStyle().Foreground(lipgloss.Color("11")).Render("Tags (unsaved):")
 	}
+	content.WriteString(lipgloss.NewStyle().Bold(true).Render(titleLabel) + "\n")
+	content.WriteString(m.titleInput.View() + "\n\n")
 	content.WriteString(lipgloss.NewStyle().Bold(true).Render(catLabel) + "\n")
-	content.WriteString(m.categoryInput.View() + "\n\n")
+	content.WriteString(m.categoryInput.View() + "\n" + m.completionView(2) + "\n")
 	content.WriteString(lipgloss.NewStyle().Bold(true).Render(tagLabel) + "\n")
-	content.WriteString(m.tagsInput.View() + "\n\n")
+	content.WriteString(m.tagsInput.View() + "\n" + m.completionView(3) + "\n")
+	content.WriteString(m.titleOnlyView() + "\n\n")
 
 	// Progress indicator (modal)
 	if m.processStage != "" {
@@ -810,7 +1101,7 @@ func (m AddLinkModel) ViewModal(maxWidth, maxHeight int) string {
 	}
 
 	// Summary preview (if available)
-	summaryFocused := m.focusIndex == 3
+	summaryFocused := m.focusIndex == 4
 	summaryStyle := lipgloss.NewStyle().Bold(true)
 	if summaryFocused {
 		summaryStyle = summaryStyle.Foreground(lipgloss.Color("10"))
@@ -826,16 +1117,27 @@ func (m AddLinkModel) ViewModal(maxWidth, maxHeight int) string {
 			if len(summaryPreview) > 200 {
 				summaryPreview = summaryPreview[:197] + "..."
 			}
-			content.WriteString(dimStyle.Render(summaryPreview) + "\n\n")
+			content.WriteString(dimStyle.Render(summaryPreview) + "\n")
+			if m.summaryShort != "" {
+				content.WriteString(dimStyle.Render("Short: "+m.summaryShort) + "\n")
+			}
+			content.WriteString("\n")
 		} else {
 			content.WriteString(dimStyle.Render("(summary will appear here after fetching)") + "\n\n")
 		}
 	}
 
-	// Content section focus indicator (content not shown in modal view)
-	if m.focusIndex == 4 {
-		contentFocusStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("12"))
-		content.WriteString(contentFocusStyle.Render("▶ Page Content: (visible in full view)") + "\n\n")
+	// Page content is only shown while it is being edited.
+	contentFocusStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("12"))
+	switch {
+	case m.focusIndex == 5:
+		label := "▶ Page Content: Ctrl+X to edit"
+		if m.contentEdited {
+			label = "▶ Page Content (unsaved, " + formatCount(services.WordCount(m.previewText)) + " words): Ctrl+X to edit"
+		}
+		content.WriteString(contentFocusStyle.Render(label) + "\n\n")
+	case m.contentEdited:
+		content.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("11")).Render("Page Content (unsaved, "+formatCount(services.WordCount(m.previewText))+" words)") + "\n\n")
 	}
 
 	// Buttons row (Save, Cancel)
@@ -845,13 +1147,13 @@ func (m AddLinkModel) ViewModal(maxWidth, maxHeight int) string {
 		Padding(0, 1)
 
 	saveStyle := btnBase
-	if m.focusIndex == 5 {
+	if m.focusIndex == 6 {
 		saveStyle = saveStyle.Bold(true).Foreground(lipgloss.Color("10")).BorderForeground(lipgloss.Color("10"))
 	}
 	saveBtn := saveStyle.Render(" Save ")
 
 	cancelStyle := btnBase
-	if m.focusIndex == 6 {
+	if m.focusIndex == 7 {
 		cancelStyle = cancelStyle.Bold(true).Foreground(lipgloss.Color("9")).BorderForeground(lipgloss.Color("9"))
 	}
 	cancelBtn := cancelStyle.Render(" Cancel ")
@@ -859,24 +1161,40 @@ func (m AddLinkModel) ViewModal(maxWidth, maxHeight int) string {
 	content.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, saveBtn, "  ", cancelBtn) + "\n\n")
 
 	// Help text
-	content.WriteString(dimStyle.Render("Tab: cycle fields • Enter: submit/save/click • Esc: close"))
+	content.WriteString(dimStyle.Render("Tab: cycle fields • Enter: submit/save/click • Ctrl+X: edit content • Ctrl+G/Ctrl+T: rewrite summary/short • Esc: close"))
 
 	return content.String()
 }
 
+// startFetch starts processing the URL in the form, clearing anything shown
+// for a previous one.
+func (m AddLinkModel) startFetch(db *database.Database, fetcher *services.Fetcher, ctx context.Context) (AddLinkModel, tea.Cmd) {
+	m.isProcessing = true
+	m.previewText = ""
+	m.contentEdited = false
+	m.summary = ""
+	m.summaryShort = ""
+	m.suggestedCategory = ""
+	m.suggestedTags = nil
+	if m.viewportReady {
+		m.contentViewport.SetContent("")
+	}
+	m.processStage = "Fetching..."
+	return m, tea.Batch(notifyCmd("info", "Fetching..."), m.fetchLink(m.urlInput.Value(), db, fetcher, ctx))
+}
+
 // fetchLink is stage 1: check if link exists (return complete) or fetch HTML.
 func (m AddLinkModel) fetchLink(url string, db *database.Database, fetcher *services.Fetcher, ctx context.Context) tea.Cmd {
 	return func() tea.Msg {
 		// Check if link already exists
-		existingLink, err := db.Queries.GetLinkByURL(ctx, url)
-		if err == nil {
-			return linkProcessCompleteMsg{
-				linkID:   existingLink.ID,
-				preview:  existingLink.Content.String,
-				summary:  existingLink.Summary.String,
-				category: "",
-				tags:     []string{},
-				llmCost:  0,
+		if msg, ok := existingLinkMsg(ctx, db, url); ok {
+			return msg
+		}
+		// Video pages are mostly script; their oEmbed metadata needs no extraction.
+		if services.IsVideoURL(url) {
+			if video, err := fetcher.FetchVideo(ctx, url); err == nil {
+				text := video.Text()
+				return linkExtractedMsg{url: url, title: video.Title, text: text, content: text, preview: text, tag: video.Tag()}
 			}
 		}
 		html, err := fetcher.FetchURL(ctx, url)
@@ -887,22 +1205,83 @@ func (m AddLinkModel) fetchLink(url string, db *database.Database, fetcher *serv
 	}
 }
 
-// extractLink is stage 2: extract text from fetched HTML.
-func (m AddLinkModel) extractLink(url, html string, extractor *services.Extractor) tea.Cmd {
+// existingLinkMsg returns the completion message for url if it is already
+// saved, restoring it from the trash if needed.
+func existingLinkMsg(ctx context.Context, db *database.Database, url string) (tea.Msg, bool) {
+	existingLink, err := db.Queries.GetLinkByURL(ctx, url)
+	if err != nil {
+		return nil, false
+	}
+	if existingLink.DeletedAt.Valid {
+		// Re-adding a trashed link restores it.
+		if err := db.Queries.RestoreLink(ctx, existingLink.ID); err != nil {
+			return linkProcessErrorMsg{err: fmt.Errorf("restore failed: %w", err)}, true
+		}
+	}
+	return linkProcessCompleteMsg{
+		linkID:   existingLink.ID,
+		title:    existingLink.Title.String,
+		preview:  existingLink.Content.String,
+		summary:  existingLink.Summary.String,
+		short:    existingLink.SummaryShort.String,
+		category: "",
+		tags:     []string{},
+		llmCost:  0,
+	}, true
+}
+
+// extractLink is stage 2: extract text from fetched HTML, rendering the page
+// with JavaScript if the plain HTML had almost none.
+func (m AddLinkModel) extractLink(url, html string, fetcher *services.Fetcher, extractor *services.Extractor, ctx context.Context) tea.Cmd {
 	return func() tea.Msg {
-		title, text, err := extractor.ExtractText(html, url)
+		title, text, canonical, err := extractor.ExtractText(html, url)
 		if err != nil {
 			return linkProcessErrorMsg{err: fmt.Errorf("extraction failed: %w", err)}
 		}
+		page := services.RenderIfShort(ctx, fetcher, extractor, url, services.Page{Title: title, Text: text, Canonical: canonical, HTML: html})
+		title, text, canonical, html = page.Title, page.Text, page.Canonical, page.HTML
+		// Prefer the page's canonical URL so variants of it dedup together.
+		if canonical != "" {
+			url = canonical
+		}
 		preview := text
 		content := extractor.TruncateText(text, 10000)
-		return linkExtractedMsg{url: url, title: title, text: text, content: content, preview: preview}
+		msg := linkExtractedMsg{url: url, title: title, text: text, content: content, preview: preview, thin: services.ThinExtraction(html, text)}
+		msg.images = extractor.ExtractImages(html, url)
+		if fetcher.ArchivesHTML() {
+			msg.html = html
+		}
+		return msg
+	}
+}
+
+// extractTitle is stage 2 for a title-only link: read the title and
+// canonical URL, skipping the content.
+func (m AddLinkModel) extractTitle(url, html string, fetcher *services.Fetcher, extractor *services.Extractor) tea.Cmd {
+	return func() tea.Msg {
+		title, canonical, err := extractor.ExtractTitle(html, url)
+		if err != nil {
+			return linkProcessErrorMsg{err: fmt.Errorf("extraction failed: %w", err)}
+		}
+		if canonical != "" {
+			url = canonical
+		}
+		msg := linkExtractedMsg{url: url, title: title}
+		if fetcher.ArchivesHTML() {
+			msg.html = html
+		}
+		return msg
 	}
 }
 
 // summarizeAndSave is stage 3: summarize with AI and save to DB.
-func (m AddLinkModel) summarizeAndSave(url, title, text, content, preview string, db *database.Database, summarizer *services.Summarizer, ctx context.Context) tea.Cmd {
+func (m AddLinkModel) summarizeAndSave(url, title, text, content, preview, tag, html string, images []services.Image, db *database.Database, summarizer *services.Summarizer, ctx context.Context) tea.Cmd {
 	return func() tea.Msg {
+		// The URL may have been swapped for a canonical one that is already saved.
+		if msg, ok := existingLinkMsg(ctx, db, url); ok {
+			return msg
+		}
+
 		var summary string
 		var category string
 		var tags []string
@@ -911,39 +1290,68 @@ func (m AddLinkModel) summarizeAndSave(url, title, text, content, preview string
 		if summarizer != nil {
 			var inTok, outTok int
 			summary, inTok, outTok, _ = summarizer.Summarize(ctx, title, text)
+			db.RecordLLMUsage(ctx, services.LLMModel, database.OpSummarize, inTok, outTok, services.LLMCost(inTok, outTok))
 			totalInputTokens += inTok
 			totalOutputTokens += outTok
 			category, tags, inTok, outTok, _ = summarizer.SuggestMetadata(ctx, title, text)
+			db.RecordLLMUsage(ctx, services.LLMModel, database.OpSuggestMetadata, inTok, outTok, services.LLMCost(inTok, outTok))
 			totalInputTokens += inTok
 			totalOutputTokens += outTok
 		}
 
-		// GPT-4o-mini pricing: $0.150/1M input tokens, $0.600/1M output tokens
-		llmCost := float64(totalInputTokens)*0.15/1_000_000.0 +
-			float64(totalOutputTokens)*0.60/1_000_000.0
+		llmCost := services.LLMCost(totalInputTokens, totalOutputTokens)
 
-		if category == "" {
-			category = "General"
+		if ruleCategory := m.categoryRules.Category(url, title); ruleCategory != "" {
+			category = ruleCategory
 		}
+		category = m.defaults.CategoryOr(category)
+		// Without an LLM, or if it suggested nothing, fall back to the page's
+		// most distinctive terms.
+		if len(tags) == 0 {
+			tags = m.tagger.suggest(ctx, db, title, text)
+		}
+		if tag != "" {
+			tags = append(tags, tag)
+		}
+		tags = m.defaults.TagsOr(tags)
 		if len(tags) == 0 {
 			tags = []string{}
 		}
 
+		words := services.WordCount(text)
 		link, err := db.Queries.CreateLink(ctx, models.CreateLinkParams{
-			Url:     url,
-			Title:   sql.NullString{String: title, Valid: title != ""},
-			Content: sql.NullString{String: content, Valid: content != ""},
-			Summary: sql.NullString{String: summary, Valid: summary != ""},
-			Status:  "read_later",
+			Url:           url,
+			Title:         sql.NullString{String: title, Valid: title != ""},
+			Content:       sql.NullString{String: content, Valid: content != ""},
+			Summary:       sql.NullString{String: summary, Valid: summary != ""},
+			Status:        m.statusRule.Status(words),
+			Domain:        services.DomainFromURL(url),
+			ContentLength: words,
+			Source:        "manual",
 		})
 		if err != nil {
 			return linkProcessErrorMsg{err: fmt.Errorf("save failed: %w", err)}
 		}
+		_ = db.Queries.UpdateLinkFetchedAt(ctx, link.ID)
+		db.ArchiveHTML(ctx, link.ID, html)
+		db.SaveImages(ctx, link.ID, storedImages(images))
+		m.tagger.add(content)
+		var summaryShort string
+		if summary != "" {
+			_ = db.Queries.UpdateLinkSummarizedAt(ctx, link.ID)
+			var inTok, outTok int
+			summaryShort, inTok, outTok = refreshShortSummary(ctx, db, summarizer, link.ID, title, summary)
+			llmCost += services.LLMCost(inTok, outTok)
+		}
 
 		return linkProcessCompleteMsg{
 			linkID:   link.ID,
+			created:  true,
+			url:      url,
+			title:    title,
 			preview:  preview,
 			summary:  summary,
+			short:    summaryShort,
 			category: category,
 			tags:     tags,
 			llmCost:  llmCost,
@@ -951,6 +1359,43 @@ func (m AddLinkModel) summarizeAndSave(url, title, text, content, preview string
 	}
 }
 
+// localTagger proposes tags for a page by TF-IDF against the content of the
+// saved links. The index is built from the database on first use and kept
+// for the session, counting each link the form saves, so a suggestion does
+// not reload every link's content.
+type localTagger struct {
+	mu        sync.Mutex
+	suggester *services.TagSuggester // nil until first needed
+}
+
+// suggest returns up to five tags for the page.
+func (t *localTagger) suggest(ctx context.Context, db *database.Database, title, text string) []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.suggester == nil {
+		contents, err := db.Queries.ListLinkContents(ctx)
+		if err != nil {
+			return nil
+		}
+		corpus := make([]string, 0, len(contents))
+		for _, c := range contents {
+			corpus = append(corpus, c.String)
+		}
+		t.suggester = services.NewTagSuggester(corpus)
+	}
+	return t.suggester.Suggest(title, text, 5)
+}
+
+// add counts a newly saved link's content. Before the index is built there
+// is nothing to do, since building it reads the link from the database.
+func (t *localTagger) add(content string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.suggester != nil && content != "" {
+		t.suggester.Add(content)
+	}
+}
+
 // Messages
 
 type linkFetchedMsg struct {
@@ -964,12 +1409,20 @@ type linkExtractedMsg struct {
 	text    string
 	content string
 	preview string
+	tag     string           // extra tag to suggest, e.g. a video's channel
+	thin    bool             // almost no text was extracted from a full page
+	html    string           // the page's HTML, if the fetcher archives pages
+	images  []services.Image // images in the content, if the extractor keeps them
 }
 
 type linkProcessCompleteMsg struct {
 	linkID   int64
+	created  bool // false when the URL was already saved
+	url      string
+	title    string
 	preview  string
 	summary  string
+	short    string // one-line summary, if one was written
 	category string
 	tags     []string
 	llmCost  float64 // USD cost of LLM calls (0 if no LLM was used)
@@ -982,3 +1435,9 @@ type linkProcessErrorMsg struct {
 type addLinkCloseRequestedMsg struct{}
 
 type metadataSavedMsg struct{}
+
+// linkAddedMsg reports a newly added link once its category and tags are
+// saved.
+type linkAddedMsg struct {
+	event services.LinkAddedEvent
+}
@@ -10,10 +10,10 @@ import (
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
-	"github.com/pkg/browser"
 
 	"mccwk.com/lm/internal/database"
 	"mccwk.com/lm/internal/models"
+	"mccwk.com/lm/internal/services"
 )
 
 type categoriesMode int
@@ -21,12 +21,15 @@ type categoriesMode int
 const (
 	categoriesViewMode categoriesMode = iota
 	categoriesCreateMode
+	categoriesEditMode
 )
 
 type CategoriesModel struct {
 	categories         []models.Category
 	filteredCategories []models.Category
 	cursor             int
+	loading            bool        // a list load is in flight
+	count              countPrefix // pending numeric prefix, e.g. the 5 in 5j
 	db                 *database.Database
 	ctx                context.Context
 	mode               categoriesMode
@@ -34,19 +37,27 @@ type CategoriesModel struct {
 
 	// Search and focus
 	searchInput textinput.Model
+	cleared     clearedSearch
 	focus       panelFocus
 
 	// Detail viewport for links panel
 	detailViewport viewport.Model
 	viewportReady  bool
 
-	// Create mode
+	// Awaiting y/n before archiving every link in the selected category
+	confirmArchive bool
+
+	// Create / edit mode
 	nameInput   textinput.Model
 	descInput   textinput.Model
+	tagsInput   textinput.Model
 	createFocus int
+	editingID   int64 // category being edited in categoriesEditMode
 
-	width  int
-	height int
+	width      int
+	height     int
+	splitRatio float64 // list panel's share of the width, set by Model
+	browser    string  // command links open with, "" for the system opener; set by Model
 }
 
 func NewCategoriesModel(db *database.Database) CategoriesModel {
@@ -66,6 +77,11 @@ func NewCategoriesModel(db *database.Database) CategoriesModel {
 	descInput.Width = 50
 	descInput.Prompt = "Description: "
 
+	tagsInput := textinput.New()
+	tagsInput.Placeholder = "Comma-separated, applied on assign..."
+	tagsInput.Width = 50
+	tagsInput.Prompt = "Default tags: "
+
 	return CategoriesModel{
 		db:          db,
 		ctx:         context.Background(),
@@ -73,7 +89,9 @@ func NewCategoriesModel(db *database.Database) CategoriesModel {
 		searchInput: searchInput,
 		nameInput:   nameInput,
 		descInput:   descInput,
+		tagsInput:   tagsInput,
 		focus:       panelFocusSearch,
+		loading:     true,
 	}
 }
 
@@ -87,11 +105,7 @@ func (m CategoriesModel) Update(msg tea.Msg) (CategoriesModel, tea.Cmd) {
 		m.width = msg.Width
 		m.height = msg.Height
 
-		leftWidth := int(float64(m.width) * 0.35)
-		if leftWidth < 30 {
-			leftWidth = 30
-		}
-		rightWidth := m.width - leftWidth - 8
+		_, rightWidth := splitWidths(m.width, m.splitRatio)
 
 		detailHeight := m.height - 12
 		if detailHeight < 5 {
@@ -113,42 +127,62 @@ func (m CategoriesModel) Update(msg tea.Msg) (CategoriesModel, tea.Cmd) {
 		switch m.mode {
 		case categoriesViewMode:
 			return m.handleViewMode(msg)
-		case categoriesCreateMode:
+		case categoriesCreateMode, categoriesEditMode:
 			return m.handleCreateMode(msg)
 		}
 
 	case categoriesLoadedMsg:
+		id := func(c models.Category) int64 { return c.ID }
+		selected := selectedID(m.filteredCategories, m.cursor, id)
+		m.loading = false
 		m.categories = msg.categories
 		m.filterCategories()
+		m.cursor = reselect(m.filteredCategories, selected, m.cursor, id)
 		if len(m.filteredCategories) > 0 {
 			return m, m.loadCategoryLinks(m.filteredCategories[m.cursor].ID)
 		}
 		return m, nil
 
 	case categoryCreatedMsg:
-		m.mode = categoriesViewMode
-		m.nameInput.SetValue("")
-		m.descInput.SetValue("")
-		m.nameInput.Blur()
-		m.descInput.Blur()
-		m.searchInput.Focus()
+		m.closeForm()
 		return m, tea.Batch(m.loadCategories(), notifyCmd("info", "Category created!"))
 
+	case categoryUpdatedMsg:
+		m.closeForm()
+		return m, tea.Batch(m.loadCategories(), notifyCmd("info", "Category updated!"))
+
 	case categoryLinksLoadedMsg:
 		m.links = msg.links
 		m.updateLinksView()
 		return m, nil
+
+	case categoryLinksArchivedMsg:
+		var cmds []tea.Cmd
+		if len(m.filteredCategories) > 0 && m.cursor < len(m.filteredCategories) {
+			cmds = append(cmds, m.loadCategoryLinks(m.filteredCategories[m.cursor].ID))
+		}
+		cmds = append(cmds, notifyCmd("success", fmt.Sprintf("Archived %d links", msg.count)))
+		return m, tea.Batch(cmds...)
 	}
 
 	return m, nil
 }
 
 func (m CategoriesModel) handleViewMode(msg tea.KeyMsg) (CategoriesModel, tea.Cmd) {
-	halfPage := (m.height - 15) / 2
+	halfPage := listRows(m.height, 4) / 2
 	if halfPage < 1 {
 		halfPage = 1
 	}
 
+	// A pending archive confirmation swallows the next key.
+	if m.confirmArchive {
+		m.confirmArchive = false
+		if msg.String() == "y" && len(m.filteredCategories) > 0 && m.cursor < len(m.filteredCategories) {
+			return m, m.archiveCategoryLinks(m.filteredCategories[m.cursor].ID)
+		}
+		return m, nil
+	}
+
 	// Tab / Shift+Tab cycle focus between search → list → detail.
 	switch msg.String() {
 	case "tab":
@@ -167,25 +201,61 @@ func (m CategoriesModel) handleViewMode(msg tea.KeyMsg) (CategoriesModel, tea.Cm
 			m.searchInput.Blur()
 		}
 		return m, nil
+	case "left", "h":
+		// ←/h and →/l move between the list and detail panels; in the
+		// search box they are ordinary input.
+		if m.focus != panelFocusSearch {
+			m.focus = focusLeft(m.focus)
+			return m, nil
+		}
+	case "right", "l":
+		if m.focus != panelFocusSearch {
+			m.focus = focusRight(m.focus)
+			return m, nil
+		}
+	case "<", ">":
+		// < and > narrow and widen the list panel on every tab.
+		if m.focus != panelFocusSearch {
+			return m, resizeSplit(msg.String() == ">")
+		}
+	case ":":
+		if m.focus != panelFocusSearch {
+			return m, openPalette
+		}
 	}
 
 	switch m.focus {
 	case panelFocusList:
+		// Digits build a count prefix applied to the next up/down move.
+		if m.count.push(msg.String()) {
+			return m, nil
+		}
+		n := m.count.take()
 		switch msg.String() {
 		case "up", "k":
 			if m.cursor > 0 {
-				m.cursor--
+				m.cursor = max(m.cursor-n, 0)
 				if len(m.filteredCategories) > 0 {
 					return m, m.loadCategoryLinks(m.filteredCategories[m.cursor].ID)
 				}
 			}
 		case "down", "j":
 			if m.cursor < len(m.filteredCategories)-1 {
-				m.cursor++
+				m.cursor = min(m.cursor+n, len(m.filteredCategories)-1)
 				if len(m.filteredCategories) > 0 {
 					return m, m.loadCategoryLinks(m.filteredCategories[m.cursor].ID)
 				}
 			}
+		case "g", "home":
+			if len(m.filteredCategories) > 0 {
+				m.cursor = 0
+				return m, m.loadCategoryLinks(m.filteredCategories[m.cursor].ID)
+			}
+		case "G", "end":
+			if len(m.filteredCategories) > 0 {
+				m.cursor = len(m.filteredCategories) - 1
+				return m, m.loadCategoryLinks(m.filteredCategories[m.cursor].ID)
+			}
 		case "pgup", "ctrl+u":
 			m.cursor -= halfPage
 			if m.cursor < 0 {
@@ -202,13 +272,25 @@ func (m CategoriesModel) handleViewMode(msg tea.KeyMsg) (CategoriesModel, tea.Cm
 			if len(m.filteredCategories) > 0 {
 				return m, m.loadCategoryLinks(m.filteredCategories[m.cursor].ID)
 			}
-		case "ctrl+a":
-			m.mode = categoriesCreateMode
-			m.createFocus = 0
-			m.focus = panelFocusSearch
-			m.searchInput.Blur()
-			m.nameInput.Focus()
-			m.descInput.Blur()
+		case "ctrl+a", "n":
+			m.startCreate()
+		case "e":
+			if len(m.filteredCategories) > 0 && m.cursor < len(m.filteredCategories) {
+				cat := m.filteredCategories[m.cursor]
+				m.mode = categoriesEditMode
+				m.editingID = cat.ID
+				m.nameInput.SetValue(cat.Name)
+				m.descInput.SetValue(cat.Description.String)
+				m.tagsInput.SetValue(cat.DefaultTags)
+				m.createFocus = 0
+				m.focus = panelFocusSearch
+				m.searchInput.Blur()
+				m.focusFormField()
+			}
+		case "A":
+			if len(m.links) > 0 {
+				m.confirmArchive = true
+			}
 		case "d":
 			if len(m.filteredCategories) > 0 && m.cursor < len(m.filteredCategories) {
 				return m, m.deleteCategory(m.filteredCategories[m.cursor].ID)
@@ -231,6 +313,14 @@ func (m CategoriesModel) handleViewMode(msg tea.KeyMsg) (CategoriesModel, tea.Cm
 				m.detailViewport, cmd = m.detailViewport.Update(msg)
 				return m, cmd
 			}
+		case "g", "home":
+			if m.viewportReady {
+				m.detailViewport.GotoTop()
+			}
+		case "G", "end":
+			if m.viewportReady {
+				m.detailViewport.GotoBottom()
+			}
 		case "up", "k":
 			if m.viewportReady {
 				m.detailViewport.ScrollUp(1)
@@ -239,6 +329,13 @@ func (m CategoriesModel) handleViewMode(msg tea.KeyMsg) (CategoriesModel, tea.Cm
 			if m.viewportReady {
 				m.detailViewport.ScrollDown(1)
 			}
+		case "A":
+			if len(m.links) > 0 {
+				m.confirmArchive = true
+			}
+		case "ctrl+a":
+			m.startCreate()
+			return m, nil
 		case "ctrl+o":
 			if len(m.links) > 0 {
 				return m, m.openLinks()
@@ -286,21 +383,23 @@ func (m CategoriesModel) handleViewMode(msg tea.KeyMsg) (CategoriesModel, tea.Cm
 			}
 			return m, nil
 		case "ctrl+a":
-			m.mode = categoriesCreateMode
-			m.createFocus = 0
-			m.searchInput.Blur()
-			m.nameInput.Focus()
-			m.descInput.Blur()
+			m.startCreate()
 			return m, nil
+		case "n":
+			// With nothing to search, n starts a new category as in the list.
+			if len(m.categories) == 0 && m.searchInput.Value() == "" {
+				m.startCreate()
+				return m, nil
+			}
 		case "ctrl+o":
 			if len(m.links) > 0 {
 				return m, m.openLinks()
 			}
 			return m, nil
 		case "esc":
-			m.searchInput.SetValue("")
+			notice := m.cleared.esc(&m.searchInput)
 			m.filterCategories()
-			return m, nil
+			return m, notice
 		}
 		var cmd tea.Cmd
 		m.searchInput, cmd = m.searchInput.Update(msg)
@@ -316,45 +415,82 @@ func (m CategoriesModel) handleViewMode(msg tea.KeyMsg) (CategoriesModel, tea.Cm
 	}
 }
 
+// startCreate opens the new-category form.
+func (m *CategoriesModel) startCreate() {
+	m.mode = categoriesCreateMode
+	m.createFocus = 0
+	m.focus = panelFocusSearch
+	m.searchInput.Blur()
+	m.focusFormField()
+}
+
 func (m CategoriesModel) handleCreateMode(msg tea.KeyMsg) (CategoriesModel, tea.Cmd) {
 	var cmd tea.Cmd
 
 	switch msg.String() {
 	case "esc":
-		m.mode = categoriesViewMode
-		m.nameInput.SetValue("")
-		m.descInput.SetValue("")
-		m.nameInput.Blur()
-		m.descInput.Blur()
-		m.searchInput.Focus()
+		m.closeForm()
 		return m, nil
-	case "tab", "shift+tab":
-		m.createFocus = (m.createFocus + 1) % 2
-		if m.createFocus == 0 {
-			m.nameInput.Focus()
-			m.descInput.Blur()
-		} else {
-			m.nameInput.Blur()
-			m.descInput.Focus()
-		}
+	case "tab":
+		m.createFocus = (m.createFocus + 1) % 3
+		m.focusFormField()
+		return m, nil
+	case "shift+tab":
+		m.createFocus = (m.createFocus + 2) % 3
+		m.focusFormField()
 		return m, nil
 	case "enter":
-		name := m.nameInput.Value()
+		name := strings.TrimSpace(m.nameInput.Value())
 		if name != "" {
-			return m, m.createCategory(name, m.descInput.Value())
+			defaultTags := strings.Join(database.ParseDefaultTags(m.tagsInput.Value()), ", ")
+			if m.mode == categoriesEditMode {
+				return m, m.updateCategory(m.editingID, name, m.descInput.Value(), defaultTags)
+			}
+			return m, m.createCategory(name, m.descInput.Value(), defaultTags)
 		}
 	}
 
-	if m.createFocus == 0 {
+	switch m.createFocus {
+	case 0:
 		m.nameInput, cmd = m.nameInput.Update(msg)
-	} else {
+	case 1:
 		m.descInput, cmd = m.descInput.Update(msg)
+	default:
+		m.tagsInput, cmd = m.tagsInput.Update(msg)
 	}
 	return m, cmd
 }
 
+// focusFormField focuses the create/edit input selected by createFocus.
+func (m *CategoriesModel) focusFormField() {
+	m.nameInput.Blur()
+	m.descInput.Blur()
+	m.tagsInput.Blur()
+	switch m.createFocus {
+	case 0:
+		m.nameInput.Focus()
+	case 1:
+		m.descInput.Focus()
+	default:
+		m.tagsInput.Focus()
+	}
+}
+
+// closeForm clears the create/edit form and returns to the list.
+func (m *CategoriesModel) closeForm() {
+	m.mode = categoriesViewMode
+	m.editingID = 0
+	m.nameInput.SetValue("")
+	m.descInput.SetValue("")
+	m.tagsInput.SetValue("")
+	m.nameInput.Blur()
+	m.descInput.Blur()
+	m.tagsInput.Blur()
+	m.searchInput.Focus()
+}
+
 func (m *CategoriesModel) filterCategories() {
-	query := strings.ToLower(m.searchInput.Value())
+	query := m.searchInput.Value()
 	if query == "" {
 		m.filteredCategories = m.categories
 		if m.cursor >= len(m.filteredCategories) {
@@ -363,13 +499,9 @@ func (m *CategoriesModel) filterCategories() {
 		return
 	}
 
-	m.filteredCategories = []models.Category{}
-	for _, cat := range m.categories {
-		if strings.Contains(strings.ToLower(cat.Name), query) ||
-			(cat.Description.Valid && strings.Contains(strings.ToLower(cat.Description.String), query)) {
-			m.filteredCategories = append(m.filteredCategories, cat)
-		}
-	}
+	m.filteredCategories = filterRanked(m.categories, query, func(cat models.Category) (string, []string) {
+		return cat.Name, []string{cat.Description.String}
+	})
 	if m.cursor >= len(m.filteredCategories) {
 		m.cursor = 0
 	}
@@ -394,8 +526,7 @@ func (m *CategoriesModel) updateLinksView() {
 			}
 			content.WriteString(fmt.Sprintf("• %s\n", title))
 			content.WriteString(urlStyle.Render("  "+link.Url) + "\n")
-			if link.Summary.Valid && link.Summary.String != "" {
-				summary := link.Summary.String
+			if summary := listSummary(link); summary != "" {
 				wrapped := wrapText(summary, m.detailViewport.Width-4)
 				content.WriteString(dimStyle.Render("  "+wrapped) + "\n")
 			}
@@ -406,11 +537,27 @@ func (m *CategoriesModel) updateLinksView() {
 	m.detailViewport.GotoTop()
 }
 
+// Breadcrumb describes what the tab is showing, for the context line under
+// the tab bar.
+func (m CategoriesModel) Breadcrumb() []string {
+	if m.mode == categoriesCreateMode {
+		return []string{"New category"}
+	}
+	if len(m.filteredCategories) == 0 || m.cursor >= len(m.filteredCategories) {
+		return []string{"No categories"}
+	}
+	crumbs := []string{m.filteredCategories[m.cursor].Name}
+	if m.mode == categoriesEditMode {
+		return append(crumbs, "Edit")
+	}
+	return append(crumbs, linkCount(len(m.links)))
+}
+
 func (m CategoriesModel) View() string {
 	switch m.mode {
 	case categoriesViewMode:
 		return m.viewCategories()
-	case categoriesCreateMode:
+	case categoriesCreateMode, categoriesEditMode:
 		return m.viewCreateCategory()
 	}
 	return ""
@@ -421,11 +568,7 @@ func (m CategoriesModel) viewCategories() string {
 		return "Loading..."
 	}
 
-	leftWidth := int(float64(m.width) * 0.35)
-	if leftWidth < 30 {
-		leftWidth = 30
-	}
-	rightWidth := m.width - leftWidth - 8
+	leftWidth, rightWidth := splitWidths(m.width, m.splitRatio)
 
 	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("6"))
 	selectedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("10")).Bold(true)
@@ -452,21 +595,23 @@ func (m CategoriesModel) viewCategories() string {
 	if len(m.filteredCategories) == 0 {
 		if m.searchInput.Value() != "" {
 			leftContent.WriteString(dimStyle.Render("No categories match your search.\n"))
+		} else if m.loading {
+			leftContent.WriteString(dimStyle.Render("Loading...\n"))
 		} else {
-			leftContent.WriteString(dimStyle.Render("No categories yet. Press Ctrl+A to create one!\n"))
+			leftContent.WriteString(dimStyle.Render("No categories yet. Press Ctrl+A or n to create one!\n"))
 		}
 	} else {
-		maxItems := m.height - 15
-		if maxItems < 3 {
-			maxItems = 3
-		}
-		startIdx := 0
-		endIdx := len(m.filteredCategories)
-		if m.cursor >= maxItems {
-			startIdx = m.cursor - maxItems + 1
-		}
-		if endIdx > startIdx+maxItems {
-			endIdx = startIdx + maxItems
+		maxItems := listRows(m.height, 4)
+		startIdx, endIdx := listWindow(len(m.filteredCategories), m.cursor, maxItems, func(i int) int {
+			// Only the selected category shows its description.
+			if c := m.filteredCategories[i]; i == m.cursor && c.Description.Valid && c.Description.String != "" {
+				return 2
+			}
+			return 1
+		})
+		above, below := moreIndicators(startIdx, endIdx, len(m.filteredCategories))
+		if above != "" {
+			leftContent.WriteString(dimStyle.Render(above) + "\n")
 		}
 
 		for i := startIdx; i < endIdx; i++ {
@@ -479,13 +624,22 @@ func (m CategoriesModel) viewCategories() string {
 			if i == m.cursor {
 				leftContent.WriteString(selectedStyle.Render(line) + "\n")
 				if cat.Description.Valid && cat.Description.String != "" {
-					leftContent.WriteString(dimStyle.Render("  "+cat.Description.String) + "\n")
+					// A wrapped description would take more rows than
+					// listWindow counted for it.
+					desc := cat.Description.String
+					if len(desc) > leftWidth-8 {
+						desc = desc[:leftWidth-11] + "..."
+					}
+					leftContent.WriteString(dimStyle.Render("  "+desc) + "\n")
 				}
 			} else {
 				leftContent.WriteString(line + "\n")
 			}
 		}
-		if len(m.filteredCategories) > maxItems {
+		if below != "" {
+			leftContent.WriteString(dimStyle.Render(below) + "\n")
+		}
+		if endIdx-startIdx < len(m.filteredCategories) {
 			leftContent.WriteString("\n" + dimStyle.Render(fmt.Sprintf("  [%d/%d categories]", m.cursor+1, len(m.filteredCategories))))
 		}
 	}
@@ -506,7 +660,11 @@ func (m CategoriesModel) viewCategories() string {
 	var rightContent string
 	if len(m.filteredCategories) > 0 && m.cursor < len(m.filteredCategories) {
 		cat := m.filteredCategories[m.cursor]
-		header := titleStyle.Render("Links in: "+cat.Name) + "\n\n"
+		header := titleStyle.Render("Links in: "+cat.Name) + "\n"
+		if cat.DefaultTags != "" {
+			header += dimStyle.Render("Default tags: "+cat.DefaultTags) + "\n"
+		}
+		header += "\n"
 
 		if m.viewportReady {
 			rightContent = header + m.detailViewport.View()
@@ -529,12 +687,16 @@ func (m CategoriesModel) viewCategories() string {
 	var helpMsg string
 	switch m.focus {
 	case panelFocusList:
-		helpMsg = "Tab: detail • ↑/↓/j/k: navigate • PgUp/PgDn/Ctrl+U/D: jump • Ctrl+A: new • d: delete • Ctrl+O: open links • Esc: search"
+		helpMsg = "Tab: detail • ↑/↓/j/k: navigate • PgUp/PgDn/Ctrl+U/D: jump • g/G: top/bottom • Ctrl+A/n: new • e: edit • d: delete • A: archive all • Ctrl+O: open links • Esc: search"
 	case panelFocusDetail:
-		helpMsg = "Tab: search • ↑/↓/j/k/PgUp/PgDn: scroll • Ctrl+O: open links • Esc: search"
+		helpMsg = "Tab: search • ↑/↓/j/k/PgUp/PgDn: scroll • g/G: top/bottom • Ctrl+A: new • A: archive all • Ctrl+O: open links • Esc: search"
 	default:
 		helpMsg = "type to search • Tab: list • ↑/↓: navigate • Ctrl+A: new • Ctrl+O: open links • Esc: clear"
 	}
+	if m.confirmArchive && len(m.filteredCategories) > 0 && m.cursor < len(m.filteredCategories) {
+		helpStyle = helpStyle.Foreground(lipgloss.Color("11")).Bold(true)
+		helpMsg = fmt.Sprintf("Archive all %d links in %q? y: confirm • any other key: cancel", len(m.links), m.filteredCategories[m.cursor].Name)
+	}
 	helpText := "\n" + helpStyle.Render(helpMsg)
 
 	return mainContent + helpText
@@ -552,13 +714,19 @@ func (m CategoriesModel) viewCreateCategory() string {
 		Padding(1, 2).
 		Width(56)
 
+	title, action := "Create New Category", "create"
+	if m.mode == categoriesEditMode {
+		title, action = "Edit Category", "save"
+	}
+
 	var content strings.Builder
-	content.WriteString(titleStyle.Render("Create New Category") + "\n\n")
+	content.WriteString(titleStyle.Render(title) + "\n\n")
 	content.WriteString(m.nameInput.View() + "\n\n")
 	content.WriteString(m.descInput.View() + "\n\n")
+	content.WriteString(m.tagsInput.View() + "\n\n")
 	content.WriteString(lipgloss.NewStyle().
 		Foreground(lipgloss.Color("241")).
-		Render("Tab: switch fields • Enter: create • Esc: cancel"))
+		Render("Tab: switch fields • Enter: " + action + " • Esc: cancel"))
 
 	modal := modalStyle.Render(content.String())
 
@@ -585,11 +753,12 @@ func (m CategoriesModel) loadCategoryLinks(categoryID int64) tea.Cmd {
 	}
 }
 
-func (m CategoriesModel) createCategory(name, description string) tea.Cmd {
+func (m CategoriesModel) createCategory(name, description, defaultTags string) tea.Cmd {
 	return func() tea.Msg {
 		_, err := m.db.Queries.CreateCategory(m.ctx, models.CreateCategoryParams{
 			Name:        name,
 			Description: sql.NullString{String: description, Valid: description != ""},
+			DefaultTags: defaultTags,
 		})
 		if err != nil {
 			return errMsg{err: err}
@@ -598,12 +767,43 @@ func (m CategoriesModel) createCategory(name, description string) tea.Cmd {
 	}
 }
 
+func (m CategoriesModel) updateCategory(id int64, name, description, defaultTags string) tea.Cmd {
+	return func() tea.Msg {
+		_, err := m.db.Queries.UpdateCategory(m.ctx, models.UpdateCategoryParams{
+			ID:          id,
+			Name:        name,
+			Description: sql.NullString{String: description, Valid: description != ""},
+			DefaultTags: defaultTags,
+		})
+		if err != nil {
+			return errMsg{err: err}
+		}
+		return categoryUpdatedMsg{}
+	}
+}
+
 func (m CategoriesModel) openLinks() tea.Cmd {
 	return func() tea.Msg {
 		for _, link := range m.links {
-			_ = browser.OpenURL(link.Url)
+			if services.OpenURL(m.browser, link.Url) == nil {
+				m.db.RecordOpen(m.ctx, link.ID)
+			}
+		}
+		return linksVisitedMsg{links: m.links}
+	}
+}
+
+// archiveCategoryLinks marks every link in the category as archived.
+func (m CategoriesModel) archiveCategoryLinks(categoryID int64) tea.Cmd {
+	return func() tea.Msg {
+		n, err := m.db.Queries.UpdateLinksStatusForCategory(m.ctx, models.UpdateLinksStatusForCategoryParams{
+			Status:     "archived",
+			CategoryID: categoryID,
+		})
+		if err != nil {
+			return errMsg{err: err}
 		}
-		return nil
+		return categoryLinksArchivedMsg{count: n}
 	}
 }
 
@@ -627,6 +827,12 @@ type categoriesLoadedMsg struct {
 
 type categoryCreatedMsg struct{}
 
+type categoryUpdatedMsg struct{}
+
 type categoryLinksLoadedMsg struct {
 	links []models.Link
 }
+
+type categoryLinksArchivedMsg struct {
+	count int64
+}
@@ -0,0 +1,158 @@
+package tui
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"mccwk.com/lm/internal/database"
+	"mccwk.com/lm/internal/models"
+)
+
+// CategoryPickerModel is a single-field dialog that moves a link into a
+// category, replacing any categories it had before.
+type CategoryPickerModel struct {
+	link     models.Link
+	input    textinput.Model
+	complete completer
+	saving   bool
+
+	db  *database.Database
+	ctx context.Context
+}
+
+func NewCategoryPickerModel(link models.Link, db *database.Database) CategoryPickerModel {
+	input := textinput.New()
+	input.Placeholder = "e.g., Technology"
+	input.Width = 40
+	input.Prompt = "> "
+	input.Focus()
+
+	return CategoryPickerModel{
+		link:     link,
+		input:    input,
+		complete: newCompleter(false),
+		db:       db,
+		ctx:      context.Background(),
+	}
+}
+
+func (m CategoryPickerModel) Init() tea.Cmd {
+	return tea.Batch(loadTaxonomy(m.db), textinput.Blink)
+}
+
+func (m CategoryPickerModel) Update(msg tea.Msg) (CategoryPickerModel, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case taxonomyLoadedMsg:
+		m.complete.setOptions(msg.categories)
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.saving {
+			return m, nil
+		}
+		switch msg.String() {
+		case "tab":
+			if m.complete.active() {
+				m.input.SetValue(m.complete.accept(m.input.Value()))
+				m.input.CursorEnd()
+				m.complete.refresh(m.input.Value())
+			}
+			return m, nil
+		case "up":
+			m.complete.move(-1)
+			return m, nil
+		case "down":
+			m.complete.move(1)
+			return m, nil
+		case "enter":
+			name := strings.TrimSpace(m.input.Value())
+			if name == "" {
+				return m, nil
+			}
+			m.saving = true
+			return m, m.moveToCategory(name)
+		}
+	}
+
+	m.input, cmd = m.input.Update(msg)
+	if _, ok := msg.(tea.KeyMsg); ok {
+		m.complete.refresh(m.input.Value())
+	}
+	return m, cmd
+}
+
+func (m CategoryPickerModel) View() string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("6"))
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("243"))
+
+	title := m.link.Title.String
+	if title == "" {
+		title = m.link.Url
+	}
+	if len(title) > 50 {
+		title = title[:47] + "..."
+	}
+
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("Move to Category") + "\n\n")
+	b.WriteString(dimStyle.Render(title) + "\n\n")
+	b.WriteString(m.input.View() + "\n")
+	if v := m.complete.view(); v != "" {
+		b.WriteString(v + "\n")
+	}
+	b.WriteString("\n" + dimStyle.Render("Enter: move (creates new categories) • Esc: cancel"))
+	return b.String()
+}
+
+// moveToCategory replaces the link's categories with the named one, creating
+// the category if it does not exist yet.
+func (m CategoryPickerModel) moveToCategory(name string) tea.Cmd {
+	linkID := m.link.ID
+	return func() tea.Msg {
+		cat, err := m.db.Queries.GetCategoryByName(m.ctx, name)
+		if err != nil {
+			cat, err = m.db.Queries.CreateCategory(m.ctx, models.CreateCategoryParams{
+				Name:        name,
+				Description: sql.NullString{Valid: false},
+			})
+			if err != nil {
+				return linkCategoryMovedMsg{err: fmt.Errorf("failed to create category: %w", err)}
+			}
+		}
+
+		current, err := m.db.Queries.GetCategoriesForLink(m.ctx, linkID)
+		if err != nil {
+			return linkCategoryMovedMsg{err: err}
+		}
+		for _, c := range current {
+			if c.ID == cat.ID {
+				continue
+			}
+			if err := m.db.Queries.UnlinkCategory(m.ctx, models.UnlinkCategoryParams{LinkID: linkID, CategoryID: c.ID}); err != nil {
+				return linkCategoryMovedMsg{err: err}
+			}
+		}
+
+		err = m.db.Queries.LinkCategory(m.ctx, models.LinkCategoryParams{LinkID: linkID, CategoryID: cat.ID})
+		if err != nil && !strings.Contains(err.Error(), "UNIQUE constraint failed") {
+			return linkCategoryMovedMsg{err: err}
+		}
+		if err := m.db.ApplyCategoryDefaultTags(m.ctx, linkID, cat); err != nil {
+			return linkCategoryMovedMsg{err: err}
+		}
+		return linkCategoryMovedMsg{category: cat.Name}
+	}
+}
+
+type linkCategoryMovedMsg struct {
+	category string
+	err      error
+}
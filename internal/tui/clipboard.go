@@ -0,0 +1,116 @@
+package tui
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+
+	"mccwk.com/lm/internal/models"
+)
+
+// markdownEscaper escapes the characters that would end a Markdown link's
+// text early.
+var markdownEscaper = strings.NewReplacer(`\`, `\\`, `[`, `\[`, `]`, `\]`)
+
+// markdownLink formats link as [title](url), falling back to the URL for the
+// text when the link has no title. URLs with spaces or parentheses are
+// wrapped in <> so they survive as one destination.
+func markdownLink(link models.Link) string {
+	title := strings.Join(strings.Fields(link.Title.String), " ")
+	if title == "" {
+		title = link.Url
+	}
+	dest := link.Url
+	if strings.ContainsAny(dest, " ()") {
+		dest = "<" + dest + ">"
+	}
+	return "[" + markdownEscaper.Replace(title) + "](" + dest + ")"
+}
+
+// copyMarkdownLinkCmd copies link to the clipboard as a Markdown link and
+// notifies whether that worked.
+func copyMarkdownLinkCmd(link models.Link) tea.Cmd {
+	return func() tea.Msg {
+		if err := clipboard.WriteAll(markdownLink(link)); err != nil {
+			return notifyMsg{level: "error", message: "Copy failed: " + err.Error()}
+		}
+		return notifyMsg{level: "info", message: "Copied as Markdown link"}
+	}
+}
+
+// copyContentCmd copies link's saved content to the clipboard: the raw
+// Markdown, or with plain set, the text as the detail view shows it at width
+// and maxColumn (see renderMarkdown).
+func copyContentCmd(link models.Link, plain bool, width, maxColumn int) tea.Cmd {
+	return func() tea.Msg {
+		text, format := link.Content.String, "Markdown"
+		if strings.TrimSpace(text) == "" {
+			return notifyMsg{level: "warning", message: "No content saved for this link"}
+		}
+		if plain {
+			text, format = plainContent(text, width, maxColumn), "plain text"
+		}
+		if err := clipboard.WriteAll(text); err != nil {
+			return notifyMsg{level: "error", message: "Copy failed: " + err.Error()}
+		}
+		return notifyMsg{level: "info", message: fmt.Sprintf("Copied %d characters as %s", utf8.RuneCountInString(text), format)}
+	}
+}
+
+// plainContent renders md as the detail view does and strips the styling,
+// trailing padding, and the left margin glamour adds to every line.
+func plainContent(md string, width, maxColumn int) string {
+	lines := strings.Split(ansi.Strip(renderMarkdown(md, width, maxColumn)), "\n")
+	margin := -1
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+		if lines[i] == "" {
+			continue
+		}
+		indent := len(lines[i]) - len(strings.TrimLeft(lines[i], " "))
+		if margin < 0 || indent < margin {
+			margin = indent
+		}
+	}
+	for i, line := range lines {
+		if line != "" && margin > 0 {
+			lines[i] = line[margin:]
+		}
+	}
+	return strings.Trim(strings.Join(lines, "\n"), "\n")
+}
+
+// clipboardURL returns text as a URL if it is a single http(s) URL with a
+// host, ignoring surrounding whitespace.
+func clipboardURL(text string) (string, bool) {
+	text = strings.TrimSpace(text)
+	if text == "" || strings.ContainsAny(text, " \t\n") {
+		return "", false
+	}
+	u, err := url.Parse(text)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", false
+	}
+	return text, true
+}
+
+// addFromClipboardCmd opens the add-link modal on the URL in the clipboard,
+// or warns when the clipboard holds something else.
+func addFromClipboardCmd() tea.Cmd {
+	return func() tea.Msg {
+		text, err := clipboard.ReadAll()
+		if err != nil {
+			return notifyMsg{level: "error", message: "Could not read the clipboard: " + err.Error()}
+		}
+		u, ok := clipboardURL(text)
+		if !ok {
+			return notifyMsg{level: "warning", message: "Clipboard does not contain a URL"}
+		}
+		return openAddLinkModalMsg{url: u}
+	}
+}
@@ -0,0 +1,149 @@
+package tui
+
+import (
+	"context"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"mccwk.com/lm/internal/database"
+)
+
+// maxCompletions caps the number of suggestions shown in a dropdown.
+const maxCompletions = 5
+
+// completer suggests existing names for a text input. In multi mode the input
+// holds a comma-separated list and only the token after the last comma is
+// completed.
+type completer struct {
+	options  []string
+	multi    bool
+	matches  []string
+	selected int
+}
+
+func newCompleter(multi bool) completer {
+	return completer{multi: multi}
+}
+
+// setOptions replaces the candidate names.
+func (c *completer) setOptions(options []string) {
+	c.options = options
+	c.matches = nil
+	c.selected = 0
+}
+
+// token returns the part of value being completed.
+func (c completer) token(value string) string {
+	if c.multi {
+		if i := strings.LastIndex(value, ","); i >= 0 {
+			value = value[i+1:]
+		}
+	}
+	return strings.TrimSpace(value)
+}
+
+// refresh recomputes matches for the current input value. Prefix matches are
+// listed before substring matches; exact matches hide the dropdown.
+func (c *completer) refresh(value string) {
+	c.matches = nil
+	c.selected = 0
+
+	tok := strings.ToLower(c.token(value))
+	if tok == "" {
+		return
+	}
+
+	var prefix, contains []string
+	for _, o := range c.options {
+		lo := strings.ToLower(o)
+		switch {
+		case lo == tok:
+			return
+		case strings.HasPrefix(lo, tok):
+			prefix = append(prefix, o)
+		case strings.Contains(lo, tok):
+			contains = append(contains, o)
+		}
+	}
+	c.matches = append(prefix, contains...)
+	if len(c.matches) > maxCompletions {
+		c.matches = c.matches[:maxCompletions]
+	}
+}
+
+// active reports whether there are suggestions to show.
+func (c completer) active() bool {
+	return len(c.matches) > 0
+}
+
+// move shifts the highlighted suggestion by delta, wrapping around.
+func (c *completer) move(delta int) {
+	if len(c.matches) == 0 {
+		return
+	}
+	c.selected = (c.selected + delta + len(c.matches)) % len(c.matches)
+}
+
+// accept returns value with the token under completion replaced by the
+// highlighted suggestion.
+func (c completer) accept(value string) string {
+	if len(c.matches) == 0 {
+		return value
+	}
+	choice := c.matches[c.selected]
+	if !c.multi {
+		return choice
+	}
+	if i := strings.LastIndex(value, ","); i >= 0 {
+		return value[:i+1] + " " + choice + ", "
+	}
+	return choice + ", "
+}
+
+// view renders the dropdown, or "" when there is nothing to suggest.
+func (c completer) view() string {
+	if len(c.matches) == 0 {
+		return ""
+	}
+	itemStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("243"))
+	selectedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("10")).Bold(true)
+
+	var b strings.Builder
+	for i, m := range c.matches {
+		if i == c.selected {
+			b.WriteString(selectedStyle.Render("  ▸ "+m) + "\n")
+		} else {
+			b.WriteString(itemStyle.Render("    "+m) + "\n")
+		}
+	}
+	b.WriteString(itemStyle.Render("  Tab: complete • ↑/↓: choose"))
+	return b.String()
+}
+
+// taxonomyLoadedMsg carries the existing category and tag names used for
+// autocompletion.
+type taxonomyLoadedMsg struct {
+	categories []string
+	tags       []string
+}
+
+// loadTaxonomy fetches all category and tag names.
+func loadTaxonomy(db *database.Database) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		var msg taxonomyLoadedMsg
+		if cats, err := db.Queries.ListCategories(ctx); err == nil {
+			for _, c := range cats {
+				msg.categories = append(msg.categories, c.Name)
+			}
+		}
+		if tags, err := db.Queries.ListTags(ctx); err == nil {
+			for _, t := range tags {
+				msg.tags = append(msg.tags, t.Name)
+			}
+		}
+		return msg
+	}
+}
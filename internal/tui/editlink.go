@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/textinput"
@@ -17,15 +18,29 @@ import (
 )
 
 type EditLinkModel struct {
-	link          models.Link
-	summaryInput  textarea.Model
-	categoryInput textinput.Model
-	tagsInput     textinput.Model
-	focusIndex    int // 0=summary, 1=category, 2=tags, 3=save, 4=reload
+	link           models.Link
+	titleInput     textinput.Model
+	summaryInput   textarea.Model
+	shortInput     textinput.Model
+	categoryInput  textinput.Model
+	tagsInput      textinput.Model
+	addedInput     textinput.Model
+	expiresInput   textinput.Model
+	autoRefresh    bool
+	needsAttention bool
+	focusIndex     int // 0=title, 1=summary, 2=short summary, 3=category, 4=tags, 5=added, 6=expires, 7=auto-refresh, 8=needs-attention, 9=save, 10=reload
+
+	// Autocompletion from existing categories/tags
+	categoryComplete completer
+	tagsComplete     completer
 
 	// Processing state
 	isProcessing bool
 
+	// Outcome of the last save or reload, shown above the buttons
+	message    string
+	messageErr bool
+
 	width  int
 	height int
 
@@ -37,6 +52,13 @@ type EditLinkModel struct {
 }
 
 func NewEditLinkModel(link models.Link, db *database.Database, ctx context.Context, fetcher *services.Fetcher, extractor *services.Extractor, summarizer *services.Summarizer) EditLinkModel {
+	titleInput := textinput.New()
+	titleInput.Placeholder = "the page's title"
+	titleInput.Width = 50
+	titleInput.Prompt = "Title: "
+	titleInput.SetValue(link.Title.String)
+	titleInput.Focus()
+
 	summaryInput := textarea.New()
 	summaryInput.Placeholder = "Enter summary..."
 	summaryInput.SetWidth(50)
@@ -44,7 +66,12 @@ func NewEditLinkModel(link models.Link, db *database.Database, ctx context.Conte
 	if link.Summary.Valid {
 		summaryInput.SetValue(link.Summary.String)
 	}
-	summaryInput.Focus()
+
+	shortInput := textinput.New()
+	shortInput.Placeholder = "one line for lists (Ctrl+T to write one)"
+	shortInput.Width = 50
+	shortInput.Prompt = "Short: "
+	shortInput.SetValue(link.SummaryShort.String)
 
 	categoryInput := textinput.New()
 	categoryInput.Placeholder = "e.g., Technology"
@@ -56,20 +83,46 @@ func NewEditLinkModel(link models.Link, db *database.Database, ctx context.Conte
 	tagsInput.Width = 50
 	tagsInput.Prompt = "Tags: "
 
+	addedInput := textinput.New()
+	addedInput.Placeholder = addedDateFormat
+	addedInput.Width = 50
+	addedInput.Prompt = "Added: "
+	addedInput.SetValue(link.CreatedAt.Local().Format(addedDateFormat))
+
+	expiresInput := textinput.New()
+	expiresInput.Placeholder = "YYYY-MM-DD, blank for never"
+	expiresInput.Width = 50
+	expiresInput.Prompt = "Expires: "
+	if link.ExpiresAt.Valid {
+		expiresInput.SetValue(link.ExpiresAt.Time.Local().Format(time.DateOnly))
+	}
+
 	return EditLinkModel{
-		link:          link,
-		summaryInput:  summaryInput,
-		categoryInput: categoryInput,
-		tagsInput:     tagsInput,
-		focusIndex:    0,
-		db:            db,
-		ctx:           ctx,
-		fetcher:       fetcher,
-		extractor:     extractor,
-		summarizer:    summarizer,
+		link:             link,
+		titleInput:       titleInput,
+		summaryInput:     summaryInput,
+		shortInput:       shortInput,
+		categoryInput:    categoryInput,
+		tagsInput:        tagsInput,
+		addedInput:       addedInput,
+		expiresInput:     expiresInput,
+		autoRefresh:      link.AutoRefresh,
+		needsAttention:   link.NeedsAttention,
+		focusIndex:       0,
+		categoryComplete: newCompleter(false),
+		tagsComplete:     newCompleter(true),
+		db:               db,
+		ctx:              ctx,
+		fetcher:          fetcher,
+		extractor:        extractor,
+		summarizer:       summarizer,
 	}
 }
 
+func (m EditLinkModel) Init() tea.Cmd {
+	return loadTaxonomy(m.db)
+}
+
 func (m EditLinkModel) Update(msg tea.Msg) (EditLinkModel, tea.Cmd) {
 	var cmd tea.Cmd
 
@@ -98,25 +151,59 @@ func (m EditLinkModel) Update(msg tea.Msg) (EditLinkModel, tea.Cmd) {
 			return m, nil
 		}
 
+		// An open completion dropdown takes Tab and the arrow keys.
+		if c := m.focusedCompleter(); c != nil && c.active() {
+			switch msg.String() {
+			case "tab":
+				if m.focusIndex == 3 {
+					m.categoryInput.SetValue(c.accept(m.categoryInput.Value()))
+					m.categoryInput.CursorEnd()
+				} else {
+					m.tagsInput.SetValue(c.accept(m.tagsInput.Value()))
+					m.tagsInput.CursorEnd()
+				}
+				c.refresh("")
+				return m, nil
+			case "up":
+				c.move(-1)
+				return m, nil
+			case "down":
+				c.move(1)
+				return m, nil
+			}
+		}
+
 		switch msg.String() {
 		case "tab":
 			// Cycle through inputs
 			m.focusIndex++
-			if m.focusIndex > 4 {
+			if m.focusIndex > 10 {
 				m.focusIndex = 0
 			}
 
+			m.titleInput.Blur()
 			m.summaryInput.Blur()
+			m.shortInput.Blur()
 			m.categoryInput.Blur()
 			m.tagsInput.Blur()
+			m.addedInput.Blur()
+			m.expiresInput.Blur()
 
 			switch m.focusIndex {
 			case 0:
-				m.summaryInput.Focus()
+				m.titleInput.Focus()
 			case 1:
-				m.categoryInput.Focus()
+				m.summaryInput.Focus()
 			case 2:
+				m.shortInput.Focus()
+			case 3:
+				m.categoryInput.Focus()
+			case 4:
 				m.tagsInput.Focus()
+			case 5:
+				m.addedInput.Focus()
+			case 6:
+				m.expiresInput.Focus()
 			}
 
 			return m, nil
@@ -125,77 +212,226 @@ func (m EditLinkModel) Update(msg tea.Msg) (EditLinkModel, tea.Cmd) {
 			// Cycle through inputs backward
 			m.focusIndex--
 			if m.focusIndex < 0 {
-				m.focusIndex = 4
+				m.focusIndex = 10
 			}
 
+			m.titleInput.Blur()
 			m.summaryInput.Blur()
+			m.shortInput.Blur()
 			m.categoryInput.Blur()
 			m.tagsInput.Blur()
+			m.addedInput.Blur()
+			m.expiresInput.Blur()
 
 			switch m.focusIndex {
 			case 0:
-				m.summaryInput.Focus()
+				m.titleInput.Focus()
 			case 1:
-				m.categoryInput.Focus()
+				m.summaryInput.Focus()
 			case 2:
+				m.shortInput.Focus()
+			case 3:
+				m.categoryInput.Focus()
+			case 4:
 				m.tagsInput.Focus()
+			case 5:
+				m.addedInput.Focus()
+			case 6:
+				m.expiresInput.Focus()
 			}
 
 			return m, nil
 
 		case "ctrl+s":
 			if !m.isProcessing {
-				m.isProcessing = true
-				return m, tea.Batch(m.saveChanges(), notifyCmd("info", "Saving..."))
+				return m.save()
 			}
 
 		case "ctrl+r":
 			if !m.isProcessing {
 				m.isProcessing = true
+				m.message = ""
 				return m, tea.Batch(m.reloadContent(), notifyCmd("info", "Reloading content..."))
 			}
+
+		case "ctrl+g":
+			if !m.isProcessing {
+				m.isProcessing = true
+				m.message = ""
+				return m, tea.Batch(regenerateSummaryCmd(m.ctx, m.db, m.summarizer, m.link.ID, m.titleInput.Value()), notifyCmd("info", "Rewriting summary..."))
+			}
+
+		case "ctrl+t":
+			if !m.isProcessing {
+				summary := strings.TrimSpace(m.summaryInput.Value())
+				if summary == "" {
+					return m, notifyCmd("info", "Write or generate a summary first")
+				}
+				m.isProcessing = true
+				m.message = ""
+				return m, tea.Batch(regenerateShortSummaryCmd(m.ctx, m.db, m.summarizer, m.link.ID, m.titleInput.Value(), summary), notifyCmd("info", "Rewriting short summary..."))
+			}
+		case " ":
+			if m.focusIndex == 7 {
+				m.autoRefresh = !m.autoRefresh
+				return m, nil
+			}
+			if m.focusIndex == 8 {
+				m.needsAttention = !m.needsAttention
+				return m, nil
+			}
 		case "enter":
 			if !m.isProcessing {
-				if m.focusIndex == 3 {
-					m.isProcessing = true
-					return m, tea.Batch(m.saveChanges(), notifyCmd("info", "Saving..."))
+				if m.focusIndex == 7 {
+					m.autoRefresh = !m.autoRefresh
+					return m, nil
+				}
+				if m.focusIndex == 8 {
+					m.needsAttention = !m.needsAttention
+					return m, nil
 				}
-				if m.focusIndex == 4 {
+				if m.focusIndex == 9 {
+					return m.save()
+				}
+				if m.focusIndex == 10 {
 					m.isProcessing = true
+					m.message = ""
 					return m, tea.Batch(m.reloadContent(), notifyCmd("info", "Reloading content..."))
 				}
 			}
 		}
 
+	case taxonomyLoadedMsg:
+		m.categoryComplete.setOptions(msg.categories)
+		m.tagsComplete.setOptions(msg.tags)
+		return m, nil
+
 	case editLinkCompleteMsg:
 		m.isProcessing = false
+		m.link.Title, m.link.CustomTitle = msg.title, msg.customTitle
+		m.message, m.messageErr = "Link updated.", false
 		return m, notifyCmd("info", "Link updated!")
 
+	case summaryRegeneratedMsg:
+		m.isProcessing = false
+		if msg.err != nil {
+			m.message, m.messageErr = msg.err.Error(), true
+			return m, notifyCmd("error", msg.err.Error())
+		}
+		if msg.short {
+			m.shortInput.SetValue(msg.text)
+			m.link.SummaryShort = sql.NullString{String: msg.text, Valid: msg.text != ""}
+			m.message, m.messageErr = "Short summary rewritten.", false
+		} else {
+			m.summaryInput.SetValue(msg.text)
+			m.message, m.messageErr = "Summary rewritten.", false
+		}
+		return m, nil
+
 	case editLinkErrorMsg:
 		m.isProcessing = false
+		m.message, m.messageErr = msg.err.Error(), true
 		return m, notifyCmd("error", msg.err.Error())
 
 	case reloadContentCompleteMsg:
 		m.isProcessing = false
+		if msg.summaryErr != nil {
+			m.message, m.messageErr = "Content reloaded, but the summary failed: "+msg.summaryErr.Error(), true
+			return m, notifyCmd("warning", "Content reloaded; summary failed")
+		}
 		if msg.summary != "" {
 			m.summaryInput.SetValue(msg.summary)
 		}
+		m.shortInput.SetValue(msg.short)
+		m.link.SummaryShort = sql.NullString{String: msg.short, Valid: msg.short != ""}
+		// The reload cleared the flag; keep the form from setting it again.
+		m.needsAttention = false
+		m.link.Title = msg.title
+		m.titleInput.SetValue(msg.title.String)
+		m.message, m.messageErr = "Content reloaded.", false
 		return m, notifyCmd("info", "Content reloaded!")
 	}
 
 	// Update the focused input
 	switch m.focusIndex {
 	case 0:
-		m.summaryInput, cmd = m.summaryInput.Update(msg)
+		m.titleInput, cmd = m.titleInput.Update(msg)
 	case 1:
-		m.categoryInput, cmd = m.categoryInput.Update(msg)
+		m.summaryInput, cmd = m.summaryInput.Update(msg)
 	case 2:
+		m.shortInput, cmd = m.shortInput.Update(msg)
+	case 3:
+		m.categoryInput, cmd = m.categoryInput.Update(msg)
+		if _, ok := msg.(tea.KeyMsg); ok {
+			m.categoryComplete.refresh(m.categoryInput.Value())
+		}
+	case 4:
 		m.tagsInput, cmd = m.tagsInput.Update(msg)
+		if _, ok := msg.(tea.KeyMsg); ok {
+			m.tagsComplete.refresh(m.tagsInput.Value())
+		}
+	case 5:
+		m.addedInput, cmd = m.addedInput.Update(msg)
+	case 6:
+		m.expiresInput, cmd = m.expiresInput.Update(msg)
 	}
 
 	return m, cmd
 }
 
+// addedDateFormat is how the edit form shows and reads a link's added date.
+// A bare date (2006-01-02) is accepted too.
+const addedDateFormat = "2006-01-02 15:04"
+
+// parseAddedDate reads the added date field in local time.
+func parseAddedDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range []string{addedDateFormat, time.DateOnly} {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid added date %q: use YYYY-MM-DD or YYYY-MM-DD HH:MM", value)
+}
+
+// parseExpiresAt reads the expires field; blank means the link never expires.
+func parseExpiresAt(value string) (sql.NullTime, error) {
+	if strings.TrimSpace(value) == "" {
+		return sql.NullTime{}, nil
+	}
+	t, err := services.ParseExpiryDate(value)
+	if err != nil {
+		return sql.NullTime{}, err
+	}
+	return sql.NullTime{Time: t.UTC(), Valid: true}, nil
+}
+
+// save validates the form and starts saving it.
+func (m EditLinkModel) save() (EditLinkModel, tea.Cmd) {
+	if _, err := parseAddedDate(m.addedInput.Value()); err != nil {
+		m.message, m.messageErr = err.Error(), true
+		return m, notifyCmd("error", err.Error())
+	}
+	if _, err := parseExpiresAt(m.expiresInput.Value()); err != nil {
+		m.message, m.messageErr = err.Error(), true
+		return m, notifyCmd("error", err.Error())
+	}
+	m.isProcessing = true
+	m.message = ""
+	return m, tea.Batch(m.saveChanges(), notifyCmd("info", "Saving..."))
+}
+
+// focusedCompleter returns the completer for the focused input, if any.
+func (m *EditLinkModel) focusedCompleter() *completer {
+	switch m.focusIndex {
+	case 3:
+		return &m.categoryComplete
+	case 4:
+		return &m.tagsComplete
+	}
+	return nil
+}
+
 func (m EditLinkModel) View() string {
 	titleStyle := lipgloss.NewStyle().
 		Bold(true).
@@ -218,10 +454,50 @@ func (m EditLinkModel) View() string {
 
 	// Inputs
 	labelStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("11"))
+	content.WriteString(m.titleInput.View() + "\n\n")
 	content.WriteString(labelStyle.Render("Summary:") + "\n")
 	content.WriteString(m.summaryInput.View() + "\n\n")
-	content.WriteString(m.categoryInput.View() + "\n\n")
-	content.WriteString(m.tagsInput.View() + "\n\n")
+	content.WriteString(m.shortInput.View() + "\n\n")
+	content.WriteString(m.categoryInput.View() + "\n")
+	if m.focusIndex == 3 && m.categoryComplete.active() {
+		content.WriteString(m.categoryComplete.view() + "\n")
+	}
+	content.WriteString("\n" + m.tagsInput.View() + "\n")
+	if m.focusIndex == 4 && m.tagsComplete.active() {
+		content.WriteString(m.tagsComplete.view() + "\n")
+	}
+	content.WriteString("\n" + m.addedInput.View() + "\n\n")
+	content.WriteString(m.expiresInput.View() + "\n\n")
+
+	// Auto-refresh toggle
+	check := "[ ]"
+	if m.autoRefresh {
+		check = "[x]"
+	}
+	toggle := check + " Auto-refresh: refetch in the background when opened or viewed"
+	if m.focusIndex == 7 {
+		toggle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("10")).Render(toggle)
+	}
+	content.WriteString(toggle + "\n")
+
+	// Needs-attention toggle
+	check = "[ ]"
+	if m.needsAttention {
+		check = "[x]"
+	}
+	toggle = check + " Needs attention: flagged as broken or needing a re-save"
+	if m.focusIndex == 8 {
+		toggle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("10")).Render(toggle)
+	}
+	content.WriteString(toggle + "\n\n")
+
+	if m.message != "" {
+		color := lipgloss.Color("10")
+		if m.messageErr {
+			color = lipgloss.Color("9")
+		}
+		content.WriteString(lipgloss.NewStyle().Foreground(color).Render(m.message) + "\n\n")
+	}
 
 	// Buttons and help
 	btnBase := lipgloss.NewStyle().
@@ -231,39 +507,112 @@ func (m EditLinkModel) View() string {
 
 	// Save button
 	saveStyle := btnBase
-	if m.focusIndex == 3 {
+	if m.focusIndex == 9 {
 		saveStyle = saveStyle.Bold(true).Foreground(lipgloss.Color("10")).BorderForeground(lipgloss.Color("10"))
 	}
 	saveBtn := saveStyle.Render(" Save ")
 
 	// Reload button
 	reloadStyle := btnBase
-	if m.focusIndex == 4 {
+	if m.focusIndex == 10 {
 		reloadStyle = reloadStyle.Bold(true).Foreground(lipgloss.Color("12")).BorderForeground(lipgloss.Color("12"))
 	}
 	reloadBtn := reloadStyle.Render(" Reload ")
 
 	content.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, saveBtn, "  ", reloadBtn) + "\n\n")
 	// Help text
-	content.WriteString(dimStyle.Render("Tab: cycle • Enter on Save/Reload: perform action • Esc: close"))
+	content.WriteString(dimStyle.Render("Tab: cycle • Space: toggle option • Enter on Save/Reload: perform action • Ctrl+G/Ctrl+T: rewrite summary/short summary • Esc: close"))
 
 	return content.String()
 }
 
 func (m EditLinkModel) saveChanges() tea.Cmd {
 	return func() tea.Msg {
+		// An edited title overrides the page's own from now on; clearing the
+		// field hands the title back to the next refetch.
+		title, customTitle := m.link.Title, m.link.CustomTitle
+		if value := strings.TrimSpace(m.titleInput.Value()); value == "" {
+			customTitle = false
+		} else if value != m.link.Title.String {
+			title, customTitle = sql.NullString{String: value, Valid: true}, true
+		}
+
 		// Update link summary
 		summary := m.summaryInput.Value()
 		_, err := m.db.Queries.UpdateLink(m.ctx, models.UpdateLinkParams{
-			ID:      m.link.ID,
-			Title:   m.link.Title,
-			Content: m.link.Content,
-			Summary: sql.NullString{String: summary, Valid: summary != ""},
-			Status:  m.link.Status,
+			ID:            m.link.ID,
+			Title:         title,
+			Content:       m.link.Content,
+			Summary:       sql.NullString{String: summary, Valid: summary != ""},
+			Status:        m.link.Status,
+			ContentLength: m.link.ContentLength,
 		})
 		if err != nil {
 			return editLinkErrorMsg{err: fmt.Errorf("failed to update link: %w", err)}
 		}
+		err = m.db.Queries.SetLinkTitle(m.ctx, models.SetLinkTitleParams{
+			Title:       title,
+			CustomTitle: customTitle,
+			ID:          m.link.ID,
+		})
+		if err != nil {
+			return editLinkErrorMsg{err: fmt.Errorf("failed to update title: %w", err)}
+		}
+		err = m.db.Queries.SetLinkAutoRefresh(m.ctx, models.SetLinkAutoRefreshParams{
+			AutoRefresh: m.autoRefresh,
+			ID:          m.link.ID,
+		})
+		if err != nil {
+			return editLinkErrorMsg{err: fmt.Errorf("failed to update auto-refresh: %w", err)}
+		}
+		err = m.db.Queries.SetLinkNeedsAttention(m.ctx, models.SetLinkNeedsAttentionParams{
+			NeedsAttention: m.needsAttention,
+			ID:             m.link.ID,
+		})
+		if err != nil {
+			return editLinkErrorMsg{err: fmt.Errorf("failed to update needs-attention flag: %w", err)}
+		}
+
+		// Only touch the added date when it was edited, so an untouched form
+		// keeps the original seconds.
+		if value := strings.TrimSpace(m.addedInput.Value()); value != m.link.CreatedAt.Local().Format(addedDateFormat) {
+			added, err := parseAddedDate(value)
+			if err != nil {
+				return editLinkErrorMsg{err: err}
+			}
+			err = m.db.Queries.UpdateLinkCreatedAt(m.ctx, models.UpdateLinkCreatedAtParams{
+				CreatedAt: added.UTC(),
+				ID:        m.link.ID,
+			})
+			if err != nil {
+				return editLinkErrorMsg{err: fmt.Errorf("failed to update added date: %w", err)}
+			}
+		}
+
+		expiresAt, err := parseExpiresAt(m.expiresInput.Value())
+		if err != nil {
+			return editLinkErrorMsg{err: err}
+		}
+		if expiresAt.Valid != m.link.ExpiresAt.Valid || !expiresAt.Time.Equal(m.link.ExpiresAt.Time) {
+			err = m.db.Queries.SetLinkExpiresAt(m.ctx, models.SetLinkExpiresAtParams{
+				ExpiresAt: expiresAt,
+				ID:        m.link.ID,
+			})
+			if err != nil {
+				return editLinkErrorMsg{err: fmt.Errorf("failed to update expiry date: %w", err)}
+			}
+		}
+
+		short := strings.TrimSpace(m.shortInput.Value())
+		if short != m.link.SummaryShort.String {
+			err = m.db.Queries.SetLinkSummaryShort(m.ctx, models.SetLinkSummaryShortParams{
+				SummaryShort: sql.NullString{String: short, Valid: short != ""},
+				ID:           m.link.ID,
+			})
+			if err != nil {
+				return editLinkErrorMsg{err: fmt.Errorf("failed to update short summary: %w", err)}
+			}
+		}
 
 		// Handle category
 		categoryName := strings.TrimSpace(m.categoryInput.Value())
@@ -293,6 +642,10 @@ func (m EditLinkModel) saveChanges() tea.Cmd {
 					return editLinkErrorMsg{err: fmt.Errorf("failed to link category: %w", err)}
 				}
 			}
+
+			if err := m.db.ApplyCategoryDefaultTags(m.ctx, m.link.ID, category); err != nil {
+				return editLinkErrorMsg{err: err}
+			}
 		}
 
 		// Handle tags
@@ -329,44 +682,60 @@ func (m EditLinkModel) saveChanges() tea.Cmd {
 			}
 		}
 
-		return editLinkCompleteMsg{}
+		return editLinkCompleteMsg{title: title, customTitle: customTitle}
 	}
 }
 
 func (m EditLinkModel) reloadContent() tea.Cmd {
 	return func() tea.Msg {
-		// Fetch the URL
-		html, err := m.fetcher.FetchURL(m.ctx, m.link.Url)
+		// Fetch and extract the URL
+		page, err := services.FetchPage(m.ctx, m.fetcher, m.extractor, m.link.Url)
 		if err != nil {
-			return editLinkErrorMsg{err: fmt.Errorf("fetch failed: %w", err)}
+			return editLinkErrorMsg{err: err}
 		}
-
-		// Extract text
-		title, text, err := m.extractor.ExtractText(html, m.link.Url)
-		if err != nil {
-			return editLinkErrorMsg{err: fmt.Errorf("extraction failed: %w", err)}
+		title, text := page.Title, page.Text
+		if title == "" || m.link.CustomTitle {
+			title = m.link.Title.String
 		}
+		newTitle := sql.NullString{String: title, Valid: title != ""}
 
 		// Truncate content for storage
 		content := m.extractor.TruncateText(text, 10000)
 
-		// Generate summary if OpenAI is configured
-		var summary string
+		// Generate summary if OpenAI is configured. On failure keep the
+		// current summary rather than blanking it, and report why.
+		summary := m.link.Summary.String
+		var summaryErr error
 		if m.summarizer != nil {
-			summary, _, _, _ = m.summarizer.Summarize(m.ctx, title, text)
+			s, inTok, outTok, err := m.summarizer.Summarize(m.ctx, title, text)
+			m.db.RecordLLMUsage(m.ctx, services.LLMModel, database.OpSummarize, inTok, outTok, services.LLMCost(inTok, outTok))
+			if err != nil {
+				summaryErr = err
+			} else {
+				summary = s
+			}
 		}
 
 		// Update link
 		_, err = m.db.Queries.UpdateLink(m.ctx, models.UpdateLinkParams{
-			ID:      m.link.ID,
-			Title:   sql.NullString{String: title, Valid: title != ""},
-			Content: sql.NullString{String: content, Valid: content != ""},
-			Summary: sql.NullString{String: summary, Valid: summary != ""},
-			Status:  m.link.Status,
+			ID:            m.link.ID,
+			Title:         newTitle,
+			Content:       sql.NullString{String: content, Valid: content != ""},
+			Summary:       sql.NullString{String: summary, Valid: summary != ""},
+			Status:        m.link.Status,
+			ContentLength: services.WordCount(text),
 		})
 		if err != nil {
 			return editLinkErrorMsg{err: fmt.Errorf("failed to update link: %w", err)}
 		}
+		m.db.ArchiveHTML(m.ctx, m.link.ID, page.HTML)
+		m.db.SaveImages(m.ctx, m.link.ID, storedImages(page.Images))
+		short := m.link.SummaryShort.String
+		if m.summarizer != nil && summaryErr == nil {
+			short, _, _ = refreshShortSummary(m.ctx, m.db, m.summarizer, m.link.ID, title, summary)
+		}
+		_ = m.db.Queries.SetLinkNeedsAttention(m.ctx, models.SetLinkNeedsAttentionParams{ID: m.link.ID})
+		_ = m.db.Queries.DeleteLinkCapture(m.ctx, m.link.ID)
 
 		// Update fetched_at timestamp
 		err = m.db.Queries.UpdateLinkFetchedAt(m.ctx, m.link.ID)
@@ -374,17 +743,25 @@ func (m EditLinkModel) reloadContent() tea.Cmd {
 			return editLinkErrorMsg{err: fmt.Errorf("failed to update fetched_at: %w", err)}
 		}
 
-		return reloadContentCompleteMsg{summary: summary}
+		return reloadContentCompleteMsg{title: newTitle, summary: summary, short: short, summaryErr: summaryErr}
 	}
 }
 
 // Messages
-type editLinkCompleteMsg struct{}
+// editLinkCompleteMsg carries the saved title, so a later reload knows
+// whether to keep it.
+type editLinkCompleteMsg struct {
+	title       sql.NullString
+	customTitle bool
+}
 
 type editLinkErrorMsg struct {
 	err error
 }
 
 type reloadContentCompleteMsg struct {
-	summary string
+	title      sql.NullString
+	summary    string
+	short      string
+	summaryErr error // set when the content reloaded but summarizing failed
 }
@@ -0,0 +1,116 @@
+package tui
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Scores for how a search word matched, used to rank results: a word in an
+// item's name beats one found only in its other text, which beats a fuzzy
+// match on the name.
+const (
+	scoreNameMatch  = 100
+	scoreFieldMatch = 50
+	maxFuzzyScore   = scoreFieldMatch - 1
+)
+
+// matchWords scores how well an item matches the free-text words of a search
+// box; every word must match, in any order. A word matches where name or one
+// of fields contains it or, failing that, fuzzily against name: its letters
+// in order but not necessarily together, so "gocon" finds "Go Concurrency
+// Patterns". Only the name is matched fuzzily, as long text would match
+// almost anything. A word starting with ' must appear exactly. words are
+// expected to be lowercased.
+func matchWords(words []string, name string, fields ...string) (score int, ok bool) {
+	name = strings.ToLower(name)
+	for _, w := range words {
+		exact := strings.HasPrefix(w, "'")
+		if exact {
+			if w = w[1:]; w == "" {
+				continue
+			}
+		}
+		switch {
+		case strings.Contains(name, w):
+			score += scoreNameMatch
+		case fieldsContain(fields, w):
+			score += scoreFieldMatch
+		case !exact:
+			s, ok := fuzzyScore(w, name)
+			if !ok {
+				return 0, false
+			}
+			score += s
+		default:
+			return 0, false
+		}
+	}
+	return score, true
+}
+
+// fieldsContain reports whether any of fields contains w, ignoring case.
+func fieldsContain(fields []string, w string) bool {
+	for _, f := range fields {
+		if strings.Contains(strings.ToLower(f), w) {
+			return true
+		}
+	}
+	return false
+}
+
+// fuzzyScore matches the runes of pattern against text in order. Each match
+// scores a point, more at the start of a word or right after the previous
+// match, and each rune skipped between matches costs one (up to three per
+// gap), so tight matches on word starts rank first. ok is false if text does
+// not contain every rune of pattern in order.
+func fuzzyScore(pattern, text string) (score int, ok bool) {
+	p := []rune(pattern)
+	if len(p) == 0 {
+		return 0, true
+	}
+	j, last, prev := 0, -1, ' '
+	for i, r := range []rune(text) {
+		if r == p[j] {
+			score++
+			if !unicode.IsLetter(prev) && !unicode.IsDigit(prev) {
+				score += 8
+			}
+			if last == i-1 {
+				score += 4
+			} else if last >= 0 {
+				score -= min(i-last-1, 3)
+			}
+			last = i
+			if j++; j == len(p) {
+				return min(max(score, 1), maxFuzzyScore), true
+			}
+		}
+		prev = r
+	}
+	return 0, false
+}
+
+// filterRanked returns the items whose text matches the search query (see
+// matchWords), best matches first; items that score the same keep their
+// order. text returns an item's name and its other searchable text.
+func filterRanked[T any](items []T, query string, text func(T) (name string, fields []string)) []T {
+	words := strings.Fields(strings.ToLower(query))
+	type match struct {
+		item  T
+		score int
+	}
+	var matches []match
+	for _, item := range items {
+		name, fields := text(item)
+		if score, ok := matchWords(words, name, fields...); ok {
+			matches = append(matches, match{item, score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].score > matches[j].score })
+	filtered := make([]T, len(matches))
+	for i, m := range matches {
+		filtered[i] = m.item
+	}
+	return filtered
+}
@@ -0,0 +1,76 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"mccwk.com/lm/internal/database"
+	"mccwk.com/lm/internal/models"
+	"mccwk.com/lm/internal/services"
+)
+
+// imagesMarkdown lists a link's kept images for the detail view, marking
+// the one selected for opening or downloading.
+func imagesMarkdown(images []models.LinkImage, selected int) string {
+	var b strings.Builder
+	b.WriteString("**Images** (i: next • v: view • V: download):\n\n")
+	for i, img := range images {
+		alt := img.Alt
+		if alt == "" {
+			alt = "untitled"
+		}
+		marker := ""
+		if i == selected {
+			marker = "▶ "
+		}
+		fmt.Fprintf(&b, "%d. %s%s — %s\n", i+1, marker, alt, img.Url)
+	}
+	return b.String() + "\n"
+}
+
+// imageLabel names an image in notifications.
+func imageLabel(images []models.LinkImage, i int) string {
+	label := fmt.Sprintf("Image %d of %d", i+1, len(images))
+	if images[i].Alt != "" {
+		label += ": " + images[i].Alt
+	}
+	return label
+}
+
+// openImageCmd opens an image with the browser command.
+func openImageCmd(browser string, img models.LinkImage) tea.Cmd {
+	return func() tea.Msg {
+		if err := services.OpenURL(browser, img.Url); err != nil {
+			return notifyMsg{level: "error", message: "Could not open image: " + err.Error()}
+		}
+		return nil
+	}
+}
+
+// downloadImageCmd saves an image to the image folder and reports where.
+func downloadImageCmd(fetcher *services.Fetcher, img models.LinkImage) tea.Cmd {
+	return func() tea.Msg {
+		path, err := fetcher.DownloadImage(context.Background(), img.Url, services.DefaultImageDir())
+		if err != nil {
+			return notifyMsg{level: "error", message: "Download failed: " + err.Error()}
+		}
+		return notifyMsg{level: "success", message: "Saved image to " + path}
+	}
+}
+
+// storedImages converts images extracted from a page for db.SaveImages,
+// keeping nil as nil so extractors that do not keep images leave the stored
+// ones alone.
+func storedImages(images []services.Image) []database.Image {
+	if images == nil {
+		return nil
+	}
+	stored := make([]database.Image, len(images))
+	for i, img := range images {
+		stored[i] = database.Image(img)
+	}
+	return stored
+}
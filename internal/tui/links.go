@@ -4,14 +4,15 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"math/rand"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
-	"github.com/pkg/browser"
 
 	"mccwk.com/lm/internal/database"
 	"mccwk.com/lm/internal/models"
@@ -22,10 +23,14 @@ import (
 type linksSortMode int
 
 const (
-	linksSortDateDesc  linksSortMode = iota // newest first (default)
-	linksSortDateAsc                        // oldest first
-	linksSortTitleAsc                       // A → Z
-	linksSortTitleDesc                      // Z → A
+	linksSortDateDesc   linksSortMode = iota // newest first (default)
+	linksSortDateAsc                         // oldest first
+	linksSortTitleAsc                        // A → Z
+	linksSortTitleDesc                       // Z → A
+	linksSortLengthAsc                       // shortest content first
+	linksSortLengthDesc                      // longest content first
+
+	linksSortModeCount // number of sort modes; keep last
 )
 
 func (s linksSortMode) String() string {
@@ -36,6 +41,10 @@ func (s linksSortMode) String() string {
 		return "title A-Z"
 	case linksSortTitleDesc:
 		return "title Z-A"
+	case linksSortLengthAsc:
+		return "shortest"
+	case linksSortLengthDesc:
+		return "longest"
 	default:
 		return "date ↓"
 	}
@@ -43,34 +52,85 @@ func (s linksSortMode) String() string {
 
 type LinksModel struct {
 	links         []models.Link
+	linkTags      map[int64][]string // tag names by link ID, for tag: searches
 	filteredLinks []models.Link
 	cursor        int
+	loading       bool        // a list load is in flight
+	count         countPrefix // pending numeric prefix, e.g. the 5 in 5j
 	db            *database.Database
 	ctx           context.Context
 
 	// Search and sort
 	searchInput textinput.Model
+	cleared     clearedSearch
 	focus       panelFocus
 	sortMode    linksSortMode
+	// searchContent also matches the query against page content (ctrl+f).
+	// Off by default: scanning every link's content on each keystroke is
+	// slow for large libraries.
+	searchContent bool
+	// dense shows one line per link, without the summary (z). Saved in the
+	// settings table.
+	dense bool
 
 	// Detail view
 	detailViewport viewport.Model
 	viewportReady  bool
+	detailLines    []string // plain-text lines of the detail view, for n/N search
+	matchIdx       int      // index of the current n/N search match, -1 if none
+
+	// Images kept for the selected link (KEEP_IMAGES), and the one i selects
+	images    []models.LinkImage
+	imageIdx  int
+	imagesFor int64 // link the images belong to
 
 	// Edit mode
 	editMode      bool
 	editLinkModel EditLinkModel
 
+	// Category picker (c)
+	pickingCategory bool
+	categoryPicker  CategoryPickerModel
+
+	// Task/activity picker (T)
+	pickingWork bool
+	workPicker  WorkPickerModel
+
 	// Refetch state
 	refetching bool
 
+	// A summary rewrite (S) is running
+	resummarizing bool
+
+	// Trash view: list soft-deleted links instead of live ones
+	showTrash bool
+
+	// Archive view: list archived links, which the default view hides
+	showArchived bool
+
+	// History view (H): links opened in the browser, most recent first
+	showOpened bool
+
+	// Domain filter (w): only show links from this site when set
+	domainFilter string
+
+	// Attention filter (F): only show links flagged as needing attention
+	attentionOnly bool
+
+	// Link to select once the list reloads, after a jump from the command
+	// palette
+	jumpTo int64
+
 	// Services for edit dialog and refetch
 	fetcher    *services.Fetcher
 	extractor  *services.Extractor
 	summarizer *services.Summarizer
 
-	width  int
-	height int
+	width        int
+	height       int
+	splitRatio   float64 // list panel's share of the width, set by Model
+	readingWidth int     // widest the detail text runs, 0 for the panel width; set by Model
+	browser      string  // command links open with, "" for the system opener; set by Model
 }
 
 func NewLinksModel(db *database.Database) LinksModel {
@@ -85,6 +145,7 @@ func NewLinksModel(db *database.Database) LinksModel {
 		ctx:         context.Background(),
 		searchInput: searchInput,
 		focus:       panelFocusSearch,
+		loading:     true,
 	}
 }
 
@@ -95,7 +156,7 @@ func (m *LinksModel) SetServices(fetcher *services.Fetcher, extractor *services.
 }
 
 func (m LinksModel) Init() tea.Cmd {
-	return tea.Batch(m.loadLinks(), textinput.Blink)
+	return tea.Batch(m.loadLinks(), m.loadDense(), textinput.Blink)
 }
 
 func (m LinksModel) Update(msg tea.Msg) (LinksModel, tea.Cmd) {
@@ -107,11 +168,7 @@ func (m LinksModel) Update(msg tea.Msg) (LinksModel, tea.Cmd) {
 		m.height = msg.Height
 
 		// Calculate responsive widths for split view
-		leftWidth := int(float64(m.width) * 0.35)
-		if leftWidth < 30 {
-			leftWidth = 30
-		}
-		rightWidth := m.width - leftWidth - 8
+		_, rightWidth := splitWidths(m.width, m.splitRatio)
 
 		// Calculate height for detail viewport
 		// Account for: title(2) + tabs(3) + search(3) + footer(2) + borders(2)
@@ -145,14 +202,43 @@ func (m LinksModel) Update(msg tea.Msg) (LinksModel, tea.Cmd) {
 			return m, cmd
 		}
 
-		halfPage := (m.height - 15) / 2
+		// If picking a category, delegate to the picker
+		if m.pickingCategory {
+			if msg.String() == "esc" {
+				m.pickingCategory = false
+				return m, nil
+			}
+			m.categoryPicker, cmd = m.categoryPicker.Update(msg)
+			return m, cmd
+		}
+
+		// If picking a task or activity, delegate to the picker
+		if m.pickingWork {
+			if msg.String() == "esc" {
+				m.pickingWork = false
+				return m, nil
+			}
+			m.workPicker, cmd = m.workPicker.Update(msg)
+			return m, cmd
+		}
+
+		halfPage := listRows(m.height, 5) / 2
 		if halfPage < 1 {
 			halfPage = 1
 		}
 
 		// Tab / Shift+Tab cycle focus between search → list → detail.
-		// s cycles the sort mode from any focus area.
+		// s cycles the sort mode from any focus area; ctrl+f toggles
+		// searching page content.
 		switch msg.String() {
+		case "ctrl+f":
+			m.searchContent = !m.searchContent
+			m.filterLinks()
+			m.updateDetailView()
+			if m.searchContent {
+				return m, notifyCmd("info", "Searching titles, URLs, summaries, and content")
+			}
+			return m, notifyCmd("info", "Searching titles, URLs, and summaries")
 		case "tab":
 			m.focus = (m.focus + 1) % 3
 			if m.focus == panelFocusSearch {
@@ -160,7 +246,7 @@ func (m LinksModel) Update(msg tea.Msg) (LinksModel, tea.Cmd) {
 			} else {
 				m.searchInput.Blur()
 			}
-			return m, nil
+			return m, m.visitDetail()
 		case "shift+tab":
 			m.focus = (m.focus + 2) % 3 // -1 mod 3
 			if m.focus == panelFocusSearch {
@@ -168,30 +254,69 @@ func (m LinksModel) Update(msg tea.Msg) (LinksModel, tea.Cmd) {
 			} else {
 				m.searchInput.Blur()
 			}
-			return m, nil
+			return m, m.visitDetail()
+		case "left", "h":
+			// ←/h and →/l move between the list and detail panels; in the
+			// search box they are ordinary input.
+			if m.focus != panelFocusSearch {
+				m.focus = focusLeft(m.focus)
+				return m, nil
+			}
+		case "right", "l":
+			if m.focus != panelFocusSearch {
+				m.focus = focusRight(m.focus)
+				return m, m.visitDetail()
+			}
+		case "<", ">":
+			// < and > narrow and widen the list panel on every tab.
+			if m.focus != panelFocusSearch {
+				return m, resizeSplit(msg.String() == ">")
+			}
+		case ":":
+			if m.focus != panelFocusSearch {
+				return m, openPalette
+			}
 		case "s":
 			// Only cycle sort when focus is NOT on the search input
 			// (so typing 's' in search still filters).
 			if m.focus != panelFocusSearch {
-				m.sortMode = (m.sortMode + 1) % 4
+				m.sortMode = (m.sortMode + 1) % linksSortModeCount
 				m.filterLinks()
 				m.updateDetailView()
 				return m, nil
 			}
+		case "z":
+			if m.focus != panelFocusSearch {
+				m.dense = !m.dense
+				return m, m.saveDense()
+			}
 		}
 
 		switch m.focus {
 		case panelFocusList:
 			// List-focused: navigate with arrows/j/k, open with Enter/Ctrl+O, back to search with Esc.
+			// Digits build a count prefix applied to the next up/down move.
+			if m.count.push(msg.String()) {
+				return m, nil
+			}
+			n := m.count.take()
 			switch msg.String() {
 			case "up", "k":
 				if m.cursor > 0 {
-					m.cursor--
+					m.cursor = max(m.cursor-n, 0)
 					m.updateDetailView()
 				}
 			case "down", "j":
 				if m.cursor < len(m.filteredLinks)-1 {
-					m.cursor++
+					m.cursor = min(m.cursor+n, len(m.filteredLinks)-1)
+					m.updateDetailView()
+				}
+			case "g", "home":
+				m.cursor = 0
+				m.updateDetailView()
+			case "G", "end":
+				if len(m.filteredLinks) > 0 {
+					m.cursor = len(m.filteredLinks) - 1
 					m.updateDetailView()
 				}
 			case "pgup", "ctrl+u":
@@ -206,19 +331,128 @@ func (m LinksModel) Update(msg tea.Msg) (LinksModel, tea.Cmd) {
 					m.cursor = len(m.filteredLinks) - 1
 				}
 				m.updateDetailView()
+			case "q":
+				if len(m.filteredLinks) > 0 && m.cursor < len(m.filteredLinks) {
+					return m, showQRCodeCmd(m.filteredLinks[m.cursor].Url)
+				}
+			case "y":
+				if len(m.filteredLinks) > 0 && m.cursor < len(m.filteredLinks) {
+					return m, copyMarkdownLinkCmd(m.filteredLinks[m.cursor])
+				}
+			case "e":
+				if !m.showTrash && len(m.filteredLinks) > 0 && m.cursor < len(m.filteredLinks) {
+					m.editMode = true
+					m.editLinkModel = NewEditLinkModel(m.filteredLinks[m.cursor], m.db, m.ctx, m.fetcher, m.extractor, m.summarizer)
+					m.editLinkModel, _ = m.editLinkModel.Update(tea.WindowSizeMsg{Width: m.width, Height: m.height})
+					return m, m.editLinkModel.Init()
+				}
+			case "c":
+				if !m.showTrash && len(m.filteredLinks) > 0 && m.cursor < len(m.filteredLinks) {
+					m.pickingCategory = true
+					m.categoryPicker = NewCategoryPickerModel(m.filteredLinks[m.cursor], m.db)
+					return m, m.categoryPicker.Init()
+				}
+			case "T":
+				// Add the link to a task or activity without fetching it again.
+				if !m.showTrash && len(m.filteredLinks) > 0 && m.cursor < len(m.filteredLinks) {
+					m.pickingWork = true
+					m.workPicker = NewWorkPickerModel(m.filteredLinks[m.cursor], m.db)
+					return m, m.workPicker.Init()
+				}
+			case "w":
+				// Toggle filtering to the selected link's site.
+				if m.domainFilter != "" {
+					m.domainFilter = ""
+				} else if len(m.filteredLinks) > 0 && m.cursor < len(m.filteredLinks) {
+					m.domainFilter = m.filteredLinks[m.cursor].Domain
+				}
+				m.cursor = 0
+				m.filterLinks()
+				m.updateDetailView()
+			case "F":
+				m.attentionOnly = !m.attentionOnly
+				m.cursor = 0
+				m.filterLinks()
+				m.updateDetailView()
+			case "!":
+				// Flag the selected link as broken or needing a re-save, or
+				// clear the flag.
+				if !m.showTrash && len(m.filteredLinks) > 0 && m.cursor < len(m.filteredLinks) {
+					link := m.filteredLinks[m.cursor]
+					return m, setLinkNeedsAttention(m.ctx, m.db, link.ID, !link.NeedsAttention)
+				}
+			case "t":
+				m.showTrash = !m.showTrash
+				m.showArchived = false
+				m.showOpened = false
+				m.cursor = 0
+				m.loading = true
+				return m, m.loadLinks()
+			case "A":
+				m.showArchived = !m.showArchived
+				m.showTrash = false
+				m.showOpened = false
+				m.cursor = 0
+				m.loading = true
+				return m, m.loadLinks()
+			case "H":
+				m.showOpened = !m.showOpened
+				m.showTrash = false
+				m.showArchived = false
+				m.cursor = 0
+				m.loading = true
+				return m, m.loadLinks()
+			case "a":
+				// Archive the selected link, or unarchive it in the archive view.
+				if !m.showTrash && len(m.filteredLinks) > 0 && m.cursor < len(m.filteredLinks) {
+					return m, m.setArchived(m.filteredLinks[m.cursor].ID, !m.showArchived)
+				}
+			case "d":
+				if !m.showTrash && len(m.filteredLinks) > 0 && m.cursor < len(m.filteredLinks) {
+					return m, m.deleteLink(m.filteredLinks[m.cursor].ID)
+				}
+			case "r":
+				if m.showTrash && len(m.filteredLinks) > 0 && m.cursor < len(m.filteredLinks) {
+					return m, m.restoreLink(m.filteredLinks[m.cursor].ID)
+				}
+			case "D":
+				if m.showTrash && len(m.filteredLinks) > 0 && m.cursor < len(m.filteredLinks) {
+					return m, m.purgeLink(m.filteredLinks[m.cursor].ID)
+				}
+			case "*":
+				// Jump to a random link to help rediscover old entries,
+				// never the one already selected.
+				if len(m.filteredLinks) > 1 {
+					next := rand.Intn(len(m.filteredLinks) - 1)
+					if next >= m.cursor {
+						next++
+					}
+					m.cursor = next
+					m.updateDetailView()
+				}
 			case "enter", "ctrl+o":
 				if len(m.filteredLinks) > 0 && m.cursor < len(m.filteredLinks) {
-					return m, m.openLink(m.filteredLinks[m.cursor].Url)
+					return m, m.openLink(m.filteredLinks[m.cursor])
+				}
+			case "o":
+				// Read the saved copy when the live page is gone or paywalled.
+				if len(m.filteredLinks) > 0 && m.cursor < len(m.filteredLinks) {
+					return m, openSavedCopyCmd(m.ctx, m.db, m.browser, m.filteredLinks[m.cursor])
+				}
+			case "O":
+				// Open and archive in one go, for working through the queue.
+				if !m.showTrash && len(m.filteredLinks) > 0 && m.cursor < len(m.filteredLinks) {
+					link := m.filteredLinks[m.cursor]
+					if link.Status == "archived" {
+						return m, m.openLink(link)
+					}
+					return m, tea.Batch(m.openLink(link), m.setArchived(link.ID, true))
 				}
 			case "ctrl+r":
-				if !m.refetching && len(m.filteredLinks) > 0 && m.cursor < len(m.filteredLinks) {
-					m.refetching = true
-					return m, tea.Batch(
-						m.refetchCurrentLink(m.filteredLinks[m.cursor]),
-						notifyCmd("info", "Refetching..."),
-					)
+				if cmd := m.refetchSelected(); cmd != nil {
+					return m, cmd
 				}
-			case "ctrl+a":
+			case "ctrl+a", "n":
 				return m, func() tea.Msg { return openAddLinkModalMsg{} }
 			case "esc":
 				m.focus = panelFocusSearch
@@ -234,6 +468,60 @@ func (m LinksModel) Update(msg tea.Msg) (LinksModel, tea.Cmd) {
 					m.detailViewport, cmd = m.detailViewport.Update(msg)
 					return m, cmd
 				}
+			case "q":
+				if len(m.filteredLinks) > 0 && m.cursor < len(m.filteredLinks) {
+					return m, showQRCodeCmd(m.filteredLinks[m.cursor].Url)
+				}
+			case "y":
+				if len(m.filteredLinks) > 0 && m.cursor < len(m.filteredLinks) {
+					return m, copyMarkdownLinkCmd(m.filteredLinks[m.cursor])
+				}
+			case "c", "C":
+				// Copy the content itself, as Markdown or as plain text.
+				if len(m.filteredLinks) > 0 && m.cursor < len(m.filteredLinks) {
+					return m, copyContentCmd(m.filteredLinks[m.cursor], msg.String() == "C", m.detailViewport.Width, m.readingWidth)
+				}
+			case "o":
+				if len(m.filteredLinks) > 0 && m.cursor < len(m.filteredLinks) {
+					return m, openSavedCopyCmd(m.ctx, m.db, m.browser, m.filteredLinks[m.cursor])
+				}
+			case "i":
+				// Step through the link's images, keeping the scroll position.
+				if len(m.images) > 0 {
+					offset := m.detailViewport.YOffset
+					m.imageIdx = (m.imageIdx + 1) % len(m.images)
+					m.updateDetailView()
+					m.detailViewport.SetYOffset(offset)
+					return m, notifyCmd("info", imageLabel(m.images, m.imageIdx))
+				}
+				return m, notifyCmd("info", "No images kept for this link (set KEEP_IMAGES=true and refetch)")
+			case "v":
+				if m.imageIdx < len(m.images) {
+					return m, openImageCmd(m.browser, m.images[m.imageIdx])
+				}
+			case "V":
+				if m.imageIdx < len(m.images) && m.fetcher != nil {
+					return m, tea.Batch(
+						downloadImageCmd(m.fetcher, m.images[m.imageIdx]),
+						notifyCmd("info", "Downloading "+imageLabel(m.images, m.imageIdx)),
+					)
+				}
+			case "n":
+				if m.viewportReady {
+					return m, m.jumpToMatch(1)
+				}
+			case "N":
+				if m.viewportReady {
+					return m, m.jumpToMatch(-1)
+				}
+			case "g", "home":
+				if m.viewportReady {
+					m.detailViewport.GotoTop()
+				}
+			case "G", "end":
+				if m.viewportReady {
+					m.detailViewport.GotoBottom()
+				}
 			case "up", "k":
 				if m.viewportReady {
 					m.detailViewport.ScrollUp(1)
@@ -243,13 +531,18 @@ func (m LinksModel) Update(msg tea.Msg) (LinksModel, tea.Cmd) {
 					m.detailViewport.ScrollDown(1)
 				}
 			case "ctrl+r":
-				if !m.refetching && len(m.filteredLinks) > 0 && m.cursor < len(m.filteredLinks) {
-					m.refetching = true
-					return m, tea.Batch(
-						m.refetchCurrentLink(m.filteredLinks[m.cursor]),
-						notifyCmd("info", "Refetching..."),
-					)
+				if cmd := m.refetchSelected(); cmd != nil {
+					return m, cmd
+				}
+			case "S":
+				// Summarize the saved content again, without refetching.
+				if !m.resummarizing && len(m.filteredLinks) > 0 && m.cursor < len(m.filteredLinks) {
+					link := m.filteredLinks[m.cursor]
+					m.resummarizing = true
+					return m, tea.Batch(regenerateSummaryCmd(m.ctx, m.db, m.summarizer, link.ID, link.Title.String), notifyCmd("info", "Summarizing..."))
 				}
+			case "ctrl+a":
+				return m, func() tea.Msg { return openAddLinkModalMsg{} }
 			case "esc":
 				m.focus = panelFocusSearch
 				m.searchInput.Focus()
@@ -273,15 +566,20 @@ func (m LinksModel) Update(msg tea.Msg) (LinksModel, tea.Cmd) {
 				return m, nil
 			case "enter", "ctrl+o":
 				if len(m.filteredLinks) > 0 && m.cursor < len(m.filteredLinks) {
-					return m, m.openLink(m.filteredLinks[m.cursor].Url)
+					return m, m.openLink(m.filteredLinks[m.cursor])
 				}
 				return m, nil
 			case "ctrl+a":
 				return m, func() tea.Msg { return openAddLinkModalMsg{} }
+			case "n":
+				// With nothing to search, n adds a link as in the list.
+				if !m.showTrash && !m.showArchived && !m.showOpened && len(m.links) == 0 && m.searchInput.Value() == "" {
+					return m, func() tea.Msg { return openAddLinkModalMsg{} }
+				}
 			case "esc":
-				m.searchInput.SetValue("")
+				notice := m.cleared.esc(&m.searchInput)
 				m.filterLinks()
-				return m, nil
+				return m, notice
 			}
 			// All other keys feed the search input for live filtering.
 			m.searchInput, cmd = m.searchInput.Update(msg)
@@ -289,14 +587,48 @@ func (m LinksModel) Update(msg tea.Msg) (LinksModel, tea.Cmd) {
 			return m, cmd
 		}
 
+	case linksDenseLoadedMsg:
+		m.dense = msg.dense
+		return m, nil
+
 	case linksLoadedMsg:
+		selected := selectedID(m.filteredLinks, m.cursor, linkIDOf)
+		offset := m.detailViewport.YOffset
+		m.loading = false
 		m.links = msg.links
+		m.linkTags = msg.tags
 		m.filterLinks()
+		if m.jumpTo != 0 {
+			return m, m.selectJumpTarget()
+		}
+		m.cursor = reselect(m.filteredLinks, selected, m.cursor, linkIDOf)
 		if len(m.filteredLinks) > 0 {
 			m.updateDetailView()
+			// Still on the same link: keep the reading position.
+			if m.filteredLinks[m.cursor].ID == selected {
+				m.detailViewport.SetYOffset(offset)
+			}
 		}
 		return m, nil
 
+	case jumpToLinkMsg:
+		// Show the view the link is in, with nothing filtering it out.
+		link := msg.link
+		m.showTrash = link.DeletedAt.Valid
+		m.showArchived = !m.showTrash && link.Status == "archived"
+		m.showOpened = false
+		m.domainFilter = ""
+		m.attentionOnly = false
+		m.searchInput.SetValue("")
+		m.searchInput.Blur()
+		m.focus = panelFocusList
+		m.jumpTo = link.ID
+		m.loading = true
+		return m, m.loadLinks()
+
+	case refetchSelectedMsg:
+		return m, m.refetchSelected()
+
 	case linkRefetchedMsg:
 		m.refetching = false
 		if msg.err != nil {
@@ -304,9 +636,61 @@ func (m LinksModel) Update(msg tea.Msg) (LinksModel, tea.Cmd) {
 		}
 		return m, tea.Batch(m.loadLinks(), notifyCmd("success", "Refetched: "+msg.title))
 
+	case summaryRegeneratedMsg:
+		m.resummarizing = false
+		if m.editMode {
+			m.editLinkModel, cmd = m.editLinkModel.Update(msg)
+			return m, cmd
+		}
+		if msg.err != nil {
+			return m, notifyCmd("error", "Summary failed: "+msg.err.Error())
+		}
+		return m, tea.Batch(m.loadLinks(), notifyCmd("success", fmt.Sprintf("Summary updated ($%.5f)", msg.cost)))
+
 	case linkDeletedMsg:
-		return m, m.loadLinks()
+		return m, tea.Batch(m.loadLinks(), notifyCmd("info", "Moved to trash (t: view trash)"))
+
+	case linkRestoredMsg:
+		return m, tea.Batch(m.loadLinks(), notifyCmd("info", "Restored from trash"))
+
+	case linkPurgedMsg:
+		return m, tea.Batch(m.loadLinks(), notifyCmd("info", "Deleted permanently"))
+
+	case linkArchivedMsg:
+		if msg.archived {
+			return m, tea.Batch(m.loadLinks(), notifyCmd("info", "Archived (A: view archive)"))
+		}
+		return m, tea.Batch(m.loadLinks(), notifyCmd("info", "Unarchived"))
+
+	case linkAttentionMsg:
+		if msg.flagged {
+			return m, tea.Batch(m.loadLinks(), notifyCmd("info", "Flagged as needing attention (F: show flagged)"))
+		}
+		return m, tea.Batch(m.loadLinks(), notifyCmd("info", "Flag cleared"))
+
+	case linkCategoryMovedMsg:
+		m.pickingCategory = false
+		if msg.err != nil {
+			return m, notifyCmd("error", "Move failed: "+msg.err.Error())
+		}
+		m.updateDetailView()
+		return m, notifyCmd("info", "Moved to "+msg.category)
+
+	case linkAddedToWorkMsg:
+		m.pickingWork = false
+		if msg.err != nil {
+			return m, notifyCmd("error", "Add failed: "+msg.err.Error())
+		}
+		return m, notifyCmd("info", fmt.Sprintf("Added to %s %q", msg.item.kind(), msg.item.name))
 	default:
+		if m.pickingCategory {
+			m.categoryPicker, cmd = m.categoryPicker.Update(msg)
+			return m, cmd
+		}
+		if m.pickingWork {
+			m.workPicker, cmd = m.workPicker.Update(msg)
+			return m, cmd
+		}
 		if m.editMode {
 			m.editLinkModel, cmd = m.editLinkModel.Update(msg)
 			return m, cmd
@@ -316,7 +700,47 @@ func (m LinksModel) Update(msg tea.Msg) (LinksModel, tea.Cmd) {
 	return m, nil
 }
 
+// Breadcrumb describes what the tab is showing, for the context line under
+// the tab bar.
+func (m LinksModel) Breadcrumb() []string {
+	var crumbs []string
+	switch {
+	case m.showTrash:
+		crumbs = append(crumbs, "Trash")
+	case m.showArchived:
+		crumbs = append(crumbs, "Archive")
+	case m.showOpened:
+		crumbs = append(crumbs, "Recently opened")
+	}
+	if m.domainFilter != "" {
+		crumbs = append(crumbs, m.domainFilter)
+	}
+	if m.attentionOnly {
+		crumbs = append(crumbs, "Needs attention")
+	}
+	return append(crumbs, listPosition(m.cursor, len(m.filteredLinks)))
+}
+
 func (m LinksModel) View() string {
+	if m.pickingCategory {
+		modal := lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("10")).
+			Padding(1, 2).
+			Width(56).
+			Render(m.categoryPicker.View())
+		return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modal)
+	}
+	if m.pickingWork {
+		modal := lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("10")).
+			Padding(1, 2).
+			Width(56).
+			Render(m.workPicker.View())
+		return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modal)
+	}
+
 	// Show edit dialog if in edit mode
 	if m.editMode {
 		modalWidth := m.width - 20
@@ -355,11 +779,7 @@ func (m LinksModel) View() string {
 	}
 
 	// Calculate responsive widths
-	leftWidth := int(float64(m.width) * 0.35)
-	if leftWidth < 30 {
-		leftWidth = 30
-	}
-	rightWidth := m.width - leftWidth - 8
+	leftWidth, rightWidth := splitWidths(m.width, m.splitRatio)
 
 	// Title and search bar
 	titleStyle := lipgloss.NewStyle().
@@ -398,54 +818,61 @@ func (m LinksModel) View() string {
 
 	sortStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("243"))
 	sortIndicator := sortStyle.Render(fmt.Sprintf("  sort: %s", m.sortMode.String()))
+	if m.showOpened {
+		sortIndicator = sortStyle.Render("  sort: last opened")
+	}
+	if m.domainFilter != "" {
+		sortIndicator += sortStyle.Render("  • site: " + m.domainFilter)
+	}
+	if m.searchContent {
+		sortIndicator += sortStyle.Render("  • full text")
+	}
+	if m.attentionOnly {
+		sortIndicator += sortStyle.Render("  • needs attention")
+	}
+	if m.showTrash {
+		sortIndicator += lipgloss.NewStyle().Foreground(lipgloss.Color("11")).Bold(true).Render("  • TRASH")
+	}
+	if m.showArchived {
+		sortIndicator += lipgloss.NewStyle().Foreground(lipgloss.Color("11")).Bold(true).Render("  • ARCHIVE")
+	}
+	if m.showOpened {
+		sortIndicator += lipgloss.NewStyle().Foreground(lipgloss.Color("11")).Bold(true).Render("  • RECENTLY OPENED")
+	}
 	leftContent := searchBox + "\n" + sortIndicator + "\n\n"
 
 	if len(m.filteredLinks) == 0 {
-		if m.searchInput.Value() != "" {
+		if m.showTrash && m.searchInput.Value() == "" {
+			leftContent += dimStyle.Render("Trash is empty. Press t to go back.\n")
+		} else if m.showArchived && m.searchInput.Value() == "" {
+			leftContent += dimStyle.Render("No archived links. Press A to go back.\n")
+		} else if m.showOpened && m.searchInput.Value() == "" && !m.loading {
+			leftContent += dimStyle.Render("No links opened yet. Press H to go back.\n")
+		} else if m.searchInput.Value() != "" {
 			leftContent += dimStyle.Render("No links match your search.\n")
+		} else if m.attentionOnly && !m.loading {
+			leftContent += dimStyle.Render("No links need attention. Press F to show all.\n")
+		} else if m.loading {
+			leftContent += dimStyle.Render("Loading...\n")
 		} else {
-			leftContent += dimStyle.Render("No links yet. Press Ctrl+A to add one!\n")
+			leftContent += dimStyle.Render("No links yet. Press Ctrl+A or n to add one!\n")
 		}
 	} else {
 		// rowsFor returns the number of display rows a link occupies:
 		// 1 for title only, 2 when a summary line is also shown.
-		rowsFor := func(link models.Link) int {
-			if link.Summary.Valid && link.Summary.String != "" {
+		rowsFor := func(i int) int {
+			link := m.filteredLinks[i]
+			if !m.dense && listSummary(link) != "" {
 				return 2
 			}
 			return 1
 		}
 
-		// Available rows for the list area.
-		maxRows := m.height - 15
-		if maxRows < 3 {
-			maxRows = 3
-		}
-
-		// Find startIdx so the cursor is always in the visible window.
-		// Walk backwards from the cursor until we've used maxRows rows.
-		startIdx := 0
-		if m.cursor < len(m.filteredLinks) {
-			rowsInView := 0
-			for i := m.cursor; i >= 0; i-- {
-				rowsInView += rowsFor(m.filteredLinks[i])
-				if rowsInView > maxRows {
-					startIdx = i + 1
-					break
-				}
-			}
-		}
-
-		// Find endIdx by counting rows forward from startIdx.
-		endIdx := startIdx
-		rowsShown := 0
-		for i := startIdx; i < len(m.filteredLinks); i++ {
-			r := rowsFor(m.filteredLinks[i])
-			if rowsShown+r > maxRows {
-				break
-			}
-			rowsShown += r
-			endIdx = i + 1
+		maxRows := listRows(m.height, 5)
+		startIdx, endIdx := listWindow(len(m.filteredLinks), m.cursor, maxRows, rowsFor)
+		above, below := moreIndicators(startIdx, endIdx, len(m.filteredLinks))
+		if above != "" {
+			leftContent += dimStyle.Render(above) + "\n"
 		}
 
 		for i := startIdx; i < endIdx; i++ {
@@ -459,6 +886,12 @@ func (m LinksModel) View() string {
 			if title == "" {
 				title = link.Url
 			}
+			if link.NeedsAttention {
+				title = attentionMarker + " " + title
+			}
+			if marker := expiryMarker(link); marker != "" {
+				title = marker + " " + title
+			}
 			// Truncate title to fit
 			if len(title) > leftWidth-8 {
 				title = title[:leftWidth-11] + "..."
@@ -468,13 +901,14 @@ func (m LinksModel) View() string {
 
 			if i == m.cursor {
 				leftContent += selectedStyle.Render(line) + "\n"
+			} else if linkExpired(link) {
+				leftContent += dimStyle.Render(line) + "\n"
 			} else {
 				leftContent += line + "\n"
 			}
 
-			// Show short summary for all items
-			if link.Summary.Valid && link.Summary.String != "" {
-				summary := link.Summary.String
+			// Show short summary for all items, unless dense
+			if summary := listSummary(link); !m.dense && summary != "" {
 				if len(summary) > leftWidth-8 {
 					summary = summary[:leftWidth-11] + "..."
 				}
@@ -482,7 +916,10 @@ func (m LinksModel) View() string {
 			}
 		}
 
-		// Show scroll indicator when not all items fit in the window.
+		// Show scroll indicators when not all items fit in the window.
+		if below != "" {
+			leftContent += dimStyle.Render(below) + "\n"
+		}
 		if endIdx-startIdx < len(m.filteredLinks) {
 			leftContent += "\n" + dimStyle.Render(fmt.Sprintf("  [%d/%d links]", m.cursor+1, len(m.filteredLinks)))
 		}
@@ -536,11 +973,17 @@ func (m LinksModel) View() string {
 	var helpMsg string
 	switch m.focus {
 	case panelFocusList:
-		helpMsg = "Tab: detail • ↑/↓/j/k: navigate • PgUp/PgDn/Ctrl+U/D: jump • Enter/Ctrl+O: open • Ctrl+A: add • Ctrl+R: refetch • s: sort • Esc: search"
+		helpMsg = "Tab: detail • ↑/↓/j/k: navigate • PgUp/PgDn/Ctrl+U/D: jump • g/G: top/bottom • *: random • q: QR code • y: copy as Markdown • Enter/Ctrl+O: open • o: open saved copy • O: open & archive • Ctrl+A/n: add • Ctrl+R: refetch • e: edit • c: category • T: add to task • w: same site • !: flag • F: flagged only • a: archive • A: archive view • H: recently opened • d: delete • t: trash • s: sort • z: dense • Esc: search"
+		if m.showArchived {
+			helpMsg = "Tab: detail • ↑/↓/j/k: navigate • g/G: top/bottom • Enter/Ctrl+O: open • o: open saved copy • a: unarchive • c: category • d: delete • A: back to links • s: sort • Esc: search"
+		}
+		if m.showTrash {
+			helpMsg = "Tab: detail • ↑/↓/j/k: navigate • g/G: top/bottom • Enter/Ctrl+O: open • o: open saved copy • r: restore • D: delete forever • t: back to links • s: sort • Esc: search"
+		}
 	case panelFocusDetail:
-		helpMsg = "Tab: search • ↑/↓/j/k/PgUp/PgDn: scroll • Ctrl+O: open • Ctrl+R: refetch • Esc: search"
+		helpMsg = "Tab: search • ↑/↓/j/k/PgUp/PgDn: scroll • g/G: top/bottom • n/N: next/prev match • Ctrl+O: open • o: open saved copy • Ctrl+A: add • q: QR code • y: copy as Markdown • i/v/V: next/view/download image • Ctrl+R: refetch • S: re-summarize • Esc: search"
 	default:
-		helpMsg = "type to search • Tab: list • ↑/↓: navigate • Enter/Ctrl+O: open • Ctrl+A: add • Esc: clear"
+		helpMsg = "type to search • Tab: list • ↑/↓: navigate • Enter/Ctrl+O: open • Ctrl+A: add • Ctrl+F: toggle content search • Esc: clear"
 	}
 	helpText := "\n" + helpStyle.Render(helpMsg)
 
@@ -548,28 +991,42 @@ func (m LinksModel) View() string {
 }
 
 func (m *LinksModel) filterLinks() {
-	query := strings.ToLower(m.searchInput.Value())
-	if query == "" {
+	query := parseLinkQuery(m.searchInput.Value())
+	var scores map[int64]int
+	if m.searchInput.Value() == "" && m.domainFilter == "" && !m.attentionOnly {
 		// Copy slice so we can sort without mutating m.links
 		filtered := make([]models.Link, len(m.links))
 		copy(filtered, m.links)
 		m.filteredLinks = filtered
 	} else {
 		m.filteredLinks = []models.Link{}
+		scores = make(map[int64]int)
 		for _, link := range m.links {
-			if linkMatchesQuery(link.Url, link.Title.String, link.Content.String, link.Summary.String, query) {
+			if m.domainFilter != "" && link.Domain != m.domainFilter {
+				continue
+			}
+			if m.attentionOnly && !link.NeedsAttention {
+				continue
+			}
+			content := ""
+			if m.searchContent {
+				content = link.Content.String
+			}
+			if score, ok := linkMatchesQuery(link, m.linkTags[link.ID], content, query); ok {
 				m.filteredLinks = append(m.filteredLinks, link)
+				scores[link.ID] = score
 			}
 		}
 	}
 
-	// Apply sort
-	switch m.sortMode {
-	case linksSortDateAsc:
+	// Apply sort; the history view keeps the order links were opened in
+	switch {
+	case m.showOpened:
+	case m.sortMode == linksSortDateAsc:
 		sort.Slice(m.filteredLinks, func(i, j int) bool {
 			return m.filteredLinks[i].CreatedAt.Before(m.filteredLinks[j].CreatedAt)
 		})
-	case linksSortTitleAsc:
+	case m.sortMode == linksSortTitleAsc:
 		sort.Slice(m.filteredLinks, func(i, j int) bool {
 			ti := strings.ToLower(m.filteredLinks[i].Title.String)
 			tj := strings.ToLower(m.filteredLinks[j].Title.String)
@@ -581,7 +1038,7 @@ func (m *LinksModel) filterLinks() {
 			}
 			return ti < tj
 		})
-	case linksSortTitleDesc:
+	case m.sortMode == linksSortTitleDesc:
 		sort.Slice(m.filteredLinks, func(i, j int) bool {
 			ti := strings.ToLower(m.filteredLinks[i].Title.String)
 			tj := strings.ToLower(m.filteredLinks[j].Title.String)
@@ -593,12 +1050,27 @@ func (m *LinksModel) filterLinks() {
 			}
 			return ti > tj
 		})
+	case m.sortMode == linksSortLengthAsc:
+		sort.SliceStable(m.filteredLinks, func(i, j int) bool {
+			return m.filteredLinks[i].ContentLength < m.filteredLinks[j].ContentLength
+		})
+	case m.sortMode == linksSortLengthDesc:
+		sort.SliceStable(m.filteredLinks, func(i, j int) bool {
+			return m.filteredLinks[i].ContentLength > m.filteredLinks[j].ContentLength
+		})
 	default: // linksSortDateDesc
 		sort.Slice(m.filteredLinks, func(i, j int) bool {
 			return m.filteredLinks[i].CreatedAt.After(m.filteredLinks[j].CreatedAt)
 		})
 	}
 
+	// Best matches for the search words first, in sort order among equals
+	if len(query.words) > 0 {
+		sort.SliceStable(m.filteredLinks, func(i, j int) bool {
+			return scores[m.filteredLinks[i].ID] > scores[m.filteredLinks[j].ID]
+		})
+	}
+
 	// Reset cursor
 	if m.cursor >= len(m.filteredLinks) {
 		m.cursor = 0
@@ -619,6 +1091,9 @@ func (m *LinksModel) updateDetailView() {
 		doc.WriteString("# " + link.Title.String + "\n\n")
 	}
 
+	// Timestamps and length
+	doc.WriteString(linkInfoLine(link) + "\n\n")
+
 	// Summary
 	if link.Summary.Valid && link.Summary.String != "" {
 		doc.WriteString("**Summary:** " + link.Summary.String + "\n\n")
@@ -644,35 +1119,98 @@ func (m *LinksModel) updateDetailView() {
 		doc.WriteString("**Categories:** " + strings.Join(catNames, ", ") + "\n\n")
 	}
 
+	// Images
+	m.images, _ = m.db.Queries.GetLinkImages(m.ctx, link.ID)
+	if link.ID != m.imagesFor || m.imageIdx >= len(m.images) {
+		m.imageIdx = 0
+	}
+	m.imagesFor = link.ID
+	if len(m.images) > 0 {
+		doc.WriteString(imagesMarkdown(m.images, m.imageIdx))
+	}
+
 	// Content (already markdown from the extractor)
 	if link.Content.Valid && link.Content.String != "" {
 		doc.WriteString("---\n\n")
 		doc.WriteString(link.Content.String)
 	}
 
-	m.detailViewport.SetContent(renderMarkdown(doc.String(), m.detailViewport.Width))
+	rendered := renderMarkdown(doc.String(), m.detailViewport.Width, m.readingWidth)
+	m.detailViewport.SetContent(rendered)
 	m.detailViewport.GotoTop()
+	m.detailLines = plainLines(rendered)
+	m.matchIdx = -1
+}
+
+// jumpToMatch scrolls the detail view to the next (delta=1) or previous
+// (delta=-1) line matching the search query, wrapping at either end.
+func (m *LinksModel) jumpToMatch(delta int) tea.Cmd {
+	query := strings.TrimSpace(m.searchInput.Value())
+	if query == "" {
+		return notifyCmd("info", "No search query")
+	}
+	matches := matchLines(m.detailLines, parseLinkQuery(query).text())
+	if len(matches) == 0 {
+		return notifyCmd("info", "No matches for "+query)
+	}
+	if m.matchIdx < 0 && delta < 0 {
+		m.matchIdx = 0
+	}
+	m.matchIdx = (m.matchIdx + delta + len(matches)) % len(matches)
+	m.detailViewport.SetYOffset(matches[m.matchIdx])
+	return notifyCmd("info", fmt.Sprintf("Match %d of %d", m.matchIdx+1, len(matches)))
 }
 
 func (m LinksModel) loadLinks() tea.Cmd {
 	return func() tea.Msg {
-		// Load all links, not just by status
-		links, err := m.db.Queries.ListLinks(m.ctx, models.ListLinksParams{
-			Limit:  1000,
-			Offset: 0,
-		})
+		var links []models.Link
+		var err error
+		switch {
+		case m.showTrash:
+			links, err = m.db.Queries.ListDeletedLinks(m.ctx)
+		case m.showArchived:
+			links, err = m.db.Queries.ListLinksByStatus(m.ctx, models.ListLinksByStatusParams{
+				Status: "archived",
+				Limit:  1000,
+				Offset: 0,
+			})
+		case m.showOpened:
+			links, err = m.db.Queries.ListRecentlyOpenedLinks(m.ctx, 200)
+		default:
+			// Load every status except archived
+			links, err = m.db.Queries.ListUnarchivedLinks(m.ctx, models.ListUnarchivedLinksParams{
+				Limit:  1000,
+				Offset: 0,
+			})
+		}
 		if err != nil {
 			return errMsg{err: err}
 		}
-		return linksLoadedMsg{links: links}
+		tags, err := loadLinkTagNames(m.ctx, m.db)
+		if err != nil {
+			return errMsg{err: err}
+		}
+		return linksLoadedMsg{links: links, tags: tags}
 	}
 }
 
-func (m LinksModel) openLink(url string) tea.Cmd {
+func (m LinksModel) openLink(link models.Link) tea.Cmd {
 	return func() tea.Msg {
-		_ = browser.OpenURL(url)
+		if services.OpenURL(m.browser, link.Url) == nil {
+			m.db.RecordOpen(m.ctx, link.ID)
+		}
+		return linksVisitedMsg{links: []models.Link{link}}
+	}
+}
+
+// visitDetail reports the selected link as viewed when the detail panel
+// takes focus.
+func (m LinksModel) visitDetail() tea.Cmd {
+	if m.focus != panelFocusDetail || len(m.filteredLinks) == 0 || m.cursor >= len(m.filteredLinks) {
 		return nil
 	}
+	link := m.filteredLinks[m.cursor]
+	return func() tea.Msg { return linksVisitedMsg{links: []models.Link{link}} }
 }
 
 func (m LinksModel) deleteLink(linkID int64) tea.Cmd {
@@ -685,49 +1223,195 @@ func (m LinksModel) deleteLink(linkID int64) tea.Cmd {
 	}
 }
 
-type linkDeletedMsg struct{}
-
-type linkRefetchedMsg struct {
-	title string
-	err   error
+// restoreLink moves a link out of the trash.
+func (m LinksModel) restoreLink(linkID int64) tea.Cmd {
+	return func() tea.Msg {
+		if err := m.db.Queries.RestoreLink(m.ctx, linkID); err != nil {
+			return errMsg{err: err}
+		}
+		return linkRestoredMsg{}
+	}
 }
 
-func (m LinksModel) refetchCurrentLink(link models.Link) tea.Cmd {
+// purgeLink permanently removes a trashed link.
+func (m LinksModel) purgeLink(linkID int64) tea.Cmd {
 	return func() tea.Msg {
-		ctx := context.Background()
+		if err := m.db.Queries.PurgeLink(m.ctx, linkID); err != nil {
+			return errMsg{err: err}
+		}
+		return linkPurgedMsg{}
+	}
+}
 
-		html, err := m.fetcher.FetchURL(ctx, link.Url)
-		if err != nil {
-			return linkRefetchedMsg{err: fmt.Errorf("fetch failed: %w", err)}
+// setArchived archives a link, or moves it back to read later.
+func (m LinksModel) setArchived(linkID int64, archived bool) tea.Cmd {
+	return setLinkArchived(m.ctx, m.db, linkID, archived)
+}
+
+// setLinkArchived moves a link into the archive, or back to read later.
+func setLinkArchived(ctx context.Context, db *database.Database, linkID int64, archived bool) tea.Cmd {
+	status := "read_later"
+	if archived {
+		status = "archived"
+	}
+	return func() tea.Msg {
+		if err := db.Queries.UpdateLinkStatus(ctx, models.UpdateLinkStatusParams{Status: status, ID: linkID}); err != nil {
+			return errMsg{err: err}
 		}
-		_ = m.db.Queries.UpdateLinkFetchedAt(ctx, link.ID)
+		return linkArchivedMsg{archived: archived}
+	}
+}
 
-		title, text, err := m.extractor.ExtractText(html, link.Url)
+// setLinkNeedsAttention flags a link as broken or needing a re-save, or
+// clears the flag.
+func setLinkNeedsAttention(ctx context.Context, db *database.Database, linkID int64, flagged bool) tea.Cmd {
+	return func() tea.Msg {
+		err := db.Queries.SetLinkNeedsAttention(ctx, models.SetLinkNeedsAttentionParams{
+			NeedsAttention: flagged,
+			ID:             linkID,
+		})
 		if err != nil {
-			return linkRefetchedMsg{err: fmt.Errorf("extraction failed: %w", err)}
+			return errMsg{err: err}
 		}
-		content := m.extractor.TruncateText(text, 10000)
+		return linkAttentionMsg{flagged: flagged}
+	}
+}
 
-		var summary string
-		if m.summarizer != nil {
-			summary, _, _, _ = m.summarizer.Summarize(ctx, title, text)
-			_ = m.db.Queries.UpdateLinkSummarizedAt(ctx, link.ID)
+// denseListSetting is the settings key for the Links dense view.
+const denseListSetting = "links.dense"
+
+// loadDense reads the saved dense-view preference; a missing setting keeps
+// the default.
+func (m LinksModel) loadDense() tea.Cmd {
+	return func() tea.Msg {
+		value, err := m.db.Queries.GetSetting(m.ctx, denseListSetting)
+		if err != nil {
+			return nil
 		}
+		dense, _ := strconv.ParseBool(value)
+		return linksDenseLoadedMsg{dense: dense}
+	}
+}
 
-		_, err = m.db.Queries.UpdateLink(ctx, models.UpdateLinkParams{
-			ID:      link.ID,
-			Title:   sql.NullString{String: title, Valid: title != ""},
-			Content: sql.NullString{String: content, Valid: content != ""},
-			Summary: sql.NullString{String: summary, Valid: summary != ""},
-			Status:  link.Status,
+func (m LinksModel) saveDense() tea.Cmd {
+	dense := m.dense
+	return func() tea.Msg {
+		err := m.db.Queries.SetSetting(m.ctx, models.SetSettingParams{
+			Key:   denseListSetting,
+			Value: strconv.FormatBool(dense),
 		})
 		if err != nil {
-			return linkRefetchedMsg{err: fmt.Errorf("failed to save: %w", err)}
+			return errMsg{err: fmt.Errorf("failed to save dense view: %w", err)}
 		}
+		return nil
+	}
+}
+
+type linksDenseLoadedMsg struct {
+	dense bool
+}
+
+type linkDeletedMsg struct{}
+
+type linkRestoredMsg struct{}
+
+type linkPurgedMsg struct{}
+
+type linkArchivedMsg struct {
+	archived bool
+}
 
-		if title == "" {
-			title = link.Url
+type linkAttentionMsg struct {
+	flagged bool
+}
+
+type linkRefetchedMsg struct {
+	title string
+	err   error
+}
+
+// refetchSelected starts refetching the selected link, unless a refetch is
+// already running or nothing is selected, in which case it returns nil.
+func (m *LinksModel) refetchSelected() tea.Cmd {
+	if m.refetching || len(m.filteredLinks) == 0 || m.cursor >= len(m.filteredLinks) {
+		return nil
+	}
+	m.refetching = true
+	return tea.Batch(
+		m.refetchCurrentLink(m.filteredLinks[m.cursor]),
+		notifyCmd("info", "Refetching..."),
+	)
+}
+
+// selectJumpTarget moves the cursor to the link the command palette jumped
+// to, now that the list holding it has loaded.
+func (m *LinksModel) selectJumpTarget() tea.Cmd {
+	id := m.jumpTo
+	m.jumpTo = 0
+	for i, l := range m.filteredLinks {
+		if l.ID == id {
+			m.cursor = i
+			m.updateDetailView()
+			return nil
 		}
-		return linkRefetchedMsg{title: title}
 	}
+	if len(m.filteredLinks) > 0 {
+		m.updateDetailView()
+	}
+	return notifyCmd("warning", fmt.Sprintf("Link #%d is not in the first 1000 links of this view", id))
+}
+
+func (m LinksModel) refetchCurrentLink(link models.Link) tea.Cmd {
+	return func() tea.Msg {
+		title, err := refetchLink(context.Background(), m.db, m.fetcher, m.extractor, m.summarizer, link)
+		return linkRefetchedMsg{title: title, err: err}
+	}
+}
+
+// refetchLink fetches link again and saves its new title, content, and
+// summary. It returns the title to show for the link. Without a summarizer
+// the existing summary is kept.
+func refetchLink(ctx context.Context, db *database.Database, fetcher *services.Fetcher, extractor *services.Extractor, summarizer *services.Summarizer, link models.Link) (string, error) {
+	page, err := services.FetchPage(ctx, fetcher, extractor, link.Url)
+	if err != nil {
+		return "", err
+	}
+	_ = db.Queries.UpdateLinkFetchedAt(ctx, link.ID)
+	title, text := page.Title, page.Text
+	if link.CustomTitle {
+		title = link.Title.String
+	}
+	content := extractor.TruncateText(text, 10000)
+
+	summary := link.Summary
+	if summarizer != nil {
+		s, inTok, outTok, _ := summarizer.Summarize(ctx, title, text)
+		db.RecordLLMUsage(ctx, services.LLMModel, database.OpSummarize, inTok, outTok, services.LLMCost(inTok, outTok))
+		summary = sql.NullString{String: s, Valid: s != ""}
+		_ = db.Queries.UpdateLinkSummarizedAt(ctx, link.ID)
+	}
+
+	_, err = db.Queries.UpdateLink(ctx, models.UpdateLinkParams{
+		ID:            link.ID,
+		Title:         sql.NullString{String: title, Valid: title != ""},
+		Content:       sql.NullString{String: content, Valid: content != ""},
+		Summary:       summary,
+		Status:        link.Status,
+		ContentLength: services.WordCount(text),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to save: %w", err)
+	}
+	db.ArchiveHTML(ctx, link.ID, page.HTML)
+	db.SaveImages(ctx, link.ID, storedImages(page.Images))
+	_ = db.Queries.SetLinkNeedsAttention(ctx, models.SetLinkNeedsAttentionParams{ID: link.ID})
+	_ = db.Queries.DeleteLinkCapture(ctx, link.ID)
+	if summarizer != nil {
+		refreshShortSummary(ctx, db, summarizer, link.ID, title, summary.String)
+	}
+
+	if title == "" {
+		title = link.Url
+	}
+	return title, nil
 }
@@ -3,6 +3,9 @@ package tui
 import (
 	"context"
 	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/viewport"
@@ -42,6 +45,22 @@ func notifyCmd(level, message string) tea.Cmd {
 	return func() tea.Msg { return notifyMsg{level: level, message: message} }
 }
 
+// resizeSplitMsg is sent by tabs to move the divider between the list and
+// detail panels, which every tab shares.
+type resizeSplitMsg struct {
+	wider bool // widen the list panel rather than narrow it
+}
+
+// resizeSplit returns a tea.Cmd that fires a resizeSplitMsg.
+func resizeSplit(wider bool) tea.Cmd {
+	return func() tea.Msg { return resizeSplitMsg{wider: wider} }
+}
+
+// splitRatioLoadedMsg carries the split ratio saved by an earlier session.
+type splitRatioLoadedMsg struct {
+	ratio float64
+}
+
 func notifyKey(level string) string {
 	switch level {
 	case "warning":
@@ -54,14 +73,25 @@ func notifyKey(level string) string {
 }
 
 type Model struct {
-	currentTab Tab
-	db         *database.Database
-	ctx        context.Context
-	fetcher    *services.Fetcher
-	extractor  *services.Extractor
-	summarizer *services.Summarizer
-	width      int
-	height     int
+	currentTab    Tab
+	db            *database.Database
+	ctx           context.Context
+	fetcher       *services.Fetcher
+	extractor     *services.Extractor
+	summarizer    *services.Summarizer
+	webhook       *services.Webhook
+	statusRule    services.StatusRule
+	categoryRules services.CategoryRules
+	linkDefaults  services.LinkDefaults
+	tagger        *localTagger
+	width         int
+	height        int
+
+	// List panel's share of the width in split views
+	splitRatio float64
+
+	// Links with a background auto-refresh in flight, by ID
+	autoRefreshing map[int64]bool
 
 	// Tab models
 	linksModel      LinksModel
@@ -75,32 +105,51 @@ type Model struct {
 	addLinkModel     AddLinkModel
 	showAddLinkModal bool
 
+	// QR code overlay
+	showQRModal bool
+	qrURL       string
+
+	// Command palette overlay (Ctrl+K or :)
+	showPalette bool
+	palette     PaletteModel
+
+	// Quit confirmation, shown when ctrl+c would discard unsaved edits
+	confirmQuit bool
+
 	// LLM cost tracking
 	totalLLMCost float64
 
+	// Library size for the footer
+	linkTotal     int64
+	linkReadLater int64
+
 	// Notifications overlay
 	alert bubbleup.AlertModel
 
+	// Notifications to show once the program starts
+	startupNotices []notifyMsg
+
 	// Log panel
-	logSink        *logging.MemorySink
-	logViewport    viewport.Model
-	logReady       bool
-	showLogPanel   bool
+	logSink      *logging.MemorySink
+	logViewport  viewport.Model
+	logReady     bool
+	showLogPanel bool
+	logRelative  bool // show log times as "2s ago" rather than the clock
 }
 
-func NewModel(db *database.Database, apiKey string, logSink *logging.MemorySink) Model {
-	var summarizer *services.Summarizer
-	if apiKey != "" {
-		summarizer = services.NewSummarizer(apiKey)
-	}
-
-	fetcher := services.NewFetcher()
-	extractor := services.NewExtractor()
-
+// NewModel builds the TUI. summarizer may be nil when no OpenAI key is
+// configured.
+func NewModel(db *database.Database, fetcher *services.Fetcher, summarizer *services.Summarizer, extractor *services.Extractor, logSink *logging.MemorySink) Model {
 	linksModel := NewLinksModel(db)
 	linksModel.SetServices(fetcher, extractor, summarizer)
+	tasksModel := NewTasksModel(nil, db)
+	tasksModel.SetServices(fetcher, extractor, summarizer)
 	activitiesModel := NewActivitiesModel(db)
 	activitiesModel.SetServices(fetcher, extractor, summarizer)
+	// The add-link forms share one tag index, built on first use.
+	tagger := &localTagger{}
+	tasksModel.tagger = tagger
+	activitiesModel.tagger = tagger
 
 	alert := bubbleup.NewAlertModel(70, false, 4*time.Second).
 		WithMinWidth(20).
@@ -113,24 +162,215 @@ func NewModel(db *database.Database, apiKey string, logSink *logging.MemorySink)
 		fetcher:         fetcher,
 		extractor:       extractor,
 		summarizer:      summarizer,
+		tagger:          tagger,
 		linksModel:      linksModel,
+		tasksModel:      tasksModel,
 		activitiesModel: activitiesModel,
 		readLaterModel:  NewReadLaterModel(db),
 		tagsModel:       NewTagsModel(db),
 		categoriesModel: NewCategoriesModel(db),
 		alert:           alert,
 		logSink:         logSink,
+		autoRefreshing:  map[int64]bool{},
+		splitRatio:      defaultSplitRatio,
 	}
 }
 
+// AddStartupNotice queues a notification to show when the TUI starts.
+func (m *Model) AddStartupNotice(level, message string) {
+	m.startupNotices = append(m.startupNotices, notifyMsg{level: level, message: message})
+}
+
+// SetWebhook configures the webhook notified when a link is added.
+func (m *Model) SetWebhook(webhook *services.Webhook) {
+	m.webhook = webhook
+}
+
+// SetReadingWidth caps how wide the Links and Read Later detail text runs, in
+// columns; 0 lets it fill the panel.
+func (m *Model) SetReadingWidth(columns int) {
+	m.linksModel.readingWidth = columns
+	m.readLaterModel.readingWidth = columns
+}
+
+// SetBrowser sets the command links are opened with, as for
+// services.OpenURL; "" uses the system default opener.
+func (m *Model) SetBrowser(browser string) {
+	m.linksModel.browser = browser
+	m.readLaterModel.browser = browser
+	m.tasksModel.browser = browser
+	m.activitiesModel.browser = browser
+	m.tagsModel.browser = browser
+	m.categoriesModel.browser = browser
+}
+
+// SetCategoryRules sets the rules that pick a category for links added from
+// the TUI before the LLM's suggestion.
+func (m *Model) SetCategoryRules(rules services.CategoryRules) {
+	m.categoryRules = rules
+	m.tasksModel.categoryRules = rules
+	m.activitiesModel.categoryRules = rules
+}
+
+// SetLinkDefaults sets the category and tag for links added from the TUI
+// that get neither from a rule or the LLM.
+func (m *Model) SetLinkDefaults(defaults services.LinkDefaults) {
+	m.linkDefaults = defaults
+	m.tasksModel.linkDefaults = defaults
+	m.activitiesModel.linkDefaults = defaults
+}
+
+// SetStatusRule sets how links added from the TUI get their status.
+func (m *Model) SetStatusRule(rule services.StatusRule) {
+	m.statusRule = rule
+	m.tasksModel.statusRule = rule
+	m.activitiesModel.statusRule = rule
+}
+
 func (m Model) Init() tea.Cmd {
-	return tea.Batch(
+	cmds := []tea.Cmd{
 		m.linksModel.Init(),
+		m.tasksModel.Init(),
 		m.readLaterModel.Init(),
 		m.tagsModel.Init(),
 		m.categoriesModel.Init(),
 		m.alert.Init(),
-	)
+		m.validateSummarizer(),
+		m.loadLinkCounts(),
+		m.loadSplitRatio(),
+	}
+	for _, n := range m.startupNotices {
+		cmds = append(cmds, notifyCmd(n.level, n.message))
+	}
+	return tea.Batch(cmds...)
+}
+
+// loadLinkCounts counts the links in the library, and how many are waiting
+// to be read, for the footer.
+func (m Model) loadLinkCounts() tea.Cmd {
+	db := m.db
+	return func() tea.Msg {
+		rows, err := db.Queries.CountLinksByStatus(context.Background())
+		if err != nil {
+			return errMsg{err: err}
+		}
+		var counts linkCountsMsg
+		for _, r := range rows {
+			counts.total += r.Count
+			if r.Status == "read_later" {
+				counts.readLater = r.Count
+			}
+		}
+		return counts
+	}
+}
+
+// splitRatioSetting is the settings key for the split views' ratio.
+const splitRatioSetting = "split.ratio"
+
+// loadSplitRatio reads the saved split ratio; a missing or unreadable
+// setting keeps the default.
+func (m Model) loadSplitRatio() tea.Cmd {
+	db := m.db
+	return func() tea.Msg {
+		value, err := db.Queries.GetSetting(context.Background(), splitRatioSetting)
+		if err != nil {
+			return nil
+		}
+		ratio, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil
+		}
+		return splitRatioLoadedMsg{ratio: clampSplitRatio(ratio)}
+	}
+}
+
+func (m Model) saveSplitRatio() tea.Cmd {
+	db, ratio := m.db, m.splitRatio
+	return func() tea.Msg {
+		err := db.Queries.SetSetting(context.Background(), models.SetSettingParams{
+			Key:   splitRatioSetting,
+			Value: strconv.FormatFloat(ratio, 'f', 2, 64),
+		})
+		if err != nil {
+			return errMsg{err: fmt.Errorf("failed to save split ratio: %w", err)}
+		}
+		return nil
+	}
+}
+
+// setSplitRatio hands ratio to every tab and re-sends the window size so
+// they resize their panels to it.
+func (m *Model) setSplitRatio(ratio float64) tea.Cmd {
+	m.splitRatio = ratio
+	m.linksModel.splitRatio = ratio
+	m.tasksModel.splitRatio = ratio
+	m.activitiesModel.splitRatio = ratio
+	m.readLaterModel.splitRatio = ratio
+	m.tagsModel.splitRatio = ratio
+	m.categoriesModel.splitRatio = ratio
+	width, height := m.width, m.height
+	return func() tea.Msg {
+		return tea.WindowSizeMsg{Width: width, Height: height}
+	}
+}
+
+// validateSummarizer checks the OpenAI key in the background. A rejected key
+// disables summarization for the session and surfaces a single warning;
+// fetching and saving links keep working. A check that fails for any other
+// reason is only logged.
+func (m Model) validateSummarizer() tea.Cmd {
+	if m.summarizer == nil {
+		return nil
+	}
+	summarizer := m.summarizer
+	return func() tea.Msg {
+		if err := summarizer.Validate(context.Background()); err != nil {
+			if summarizer.Enabled() {
+				slog.Warn("could not check OpenAI API key; summarizing anyway", "error", err)
+				return nil
+			}
+			slog.Warn("summarization disabled", "error", err)
+			return notifyMsg{level: "warning", message: "OpenAI key rejected; summaries disabled"}
+		}
+		return nil
+	}
+}
+
+// notifyWebhook delivers a link-added event in the background. Failures are
+// logged only; the link is already saved.
+func (m Model) notifyWebhook(ev services.LinkAddedEvent) tea.Cmd {
+	if m.webhook == nil {
+		return nil
+	}
+	webhook := m.webhook
+	return func() tea.Msg {
+		if err := webhook.LinkAdded(context.Background(), ev); err != nil {
+			slog.Warn("webhook delivery failed", "url", ev.URL, "error", err)
+		}
+		return nil
+	}
+}
+
+// autoRefreshMinAge is how long after a fetch an auto-refresh link is
+// considered fresh, so flicking back and forth does not refetch it each time.
+const autoRefreshMinAge = 10 * time.Minute
+
+// autoRefresh refetches link in the background if it is marked auto-refresh
+// and was not fetched recently.
+func (m Model) autoRefresh(link models.Link) tea.Cmd {
+	if !link.AutoRefresh || m.autoRefreshing[link.ID] {
+		return nil
+	}
+	if link.FetchedAt.Valid && time.Since(link.FetchedAt.Time) < autoRefreshMinAge {
+		return nil
+	}
+	m.autoRefreshing[link.ID] = true
+	db, fetcher, extractor, summarizer := m.db, m.fetcher, m.extractor, m.summarizer
+	return func() tea.Msg {
+		title, err := refetchLink(context.Background(), db, fetcher, extractor, summarizer, link)
+		return linkAutoRefreshedMsg{id: link.ID, url: link.Url, title: title, err: err}
+	}
 }
 
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -149,12 +389,95 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, tea.Batch(cmds...)
 	}
 
+	// The add-link form reports newly added links for the webhook.
+	if a, ok := msg.(linkAddedMsg); ok {
+		cmds = append(cmds, m.notifyWebhook(a.event), m.loadLinkCounts())
+		return m, tea.Batch(cmds...)
+	}
+
+	if c, ok := msg.(linkCountsMsg); ok {
+		m.linkTotal = c.total
+		m.linkReadLater = c.readLater
+		return m, tea.Batch(cmds...)
+	}
+	// The split ratio is shared by every tab, so it lives here: it is loaded
+	// once at startup and moved with < and > from any tab.
+	switch s := msg.(type) {
+	case splitRatioLoadedMsg:
+		cmds = append(cmds, m.setSplitRatio(s.ratio))
+		return m, tea.Batch(cmds...)
+	case resizeSplitMsg:
+		ratio := m.splitRatio - splitStep
+		if s.wider {
+			ratio = m.splitRatio + splitStep
+		}
+		if ratio = clampSplitRatio(ratio); ratio != m.splitRatio {
+			cmds = append(cmds, m.setSplitRatio(ratio))
+			cmds = append(cmds, m.saveSplitRatio())
+		}
+		return m, tea.Batch(cmds...)
+	}
+
+	// Keep the footer counts current when a tab moves links around; the tab
+	// still handles the message below.
+	switch msg.(type) {
+	case linkDeletedMsg, linkRestoredMsg, linkArchivedMsg, tagLinksArchivedMsg, categoryLinksArchivedMsg:
+		cmds = append(cmds, m.loadLinkCounts())
+	}
+
+	// Summaries rewritten on request count toward the session's LLM spend;
+	// the tab or dialog that asked still handles the message below.
+	if r, ok := msg.(summaryRegeneratedMsg); ok {
+		m.totalLLMCost += r.cost
+	}
+
+	// Opening or viewing a link marked auto-refresh refetches it in the
+	// background.
+	if v, ok := msg.(linksVisitedMsg); ok {
+		for _, link := range v.links {
+			cmds = append(cmds, m.autoRefresh(link))
+		}
+		return m, tea.Batch(cmds...)
+	}
+	if r, ok := msg.(linkAutoRefreshedMsg); ok {
+		delete(m.autoRefreshing, r.id)
+		if r.err != nil {
+			slog.Warn("auto-refresh failed", "url", r.url, "error", r.err)
+			return m, tea.Batch(cmds...)
+		}
+		cmds = append(cmds, notifyCmd("info", "↻ Refreshed "+r.title))
+		// Only reload tabs that show link content; reloading the others
+		// would reset their selection.
+		if m.currentTab == TabLinks || m.currentTab == TabReadLater {
+			cmds = append(cmds, m.loadTabData())
+		}
+		return m, tea.Batch(cmds...)
+	}
+
 	// Surface DB / async errors as notifications.
 	if e, ok := msg.(errMsg); ok {
+		// A failed query never delivers its list, so stop waiting for it.
+		m.linksModel.loading = false
+		m.tasksModel.loading = false
+		m.activitiesModel.loading = false
+		m.readLaterModel.loading = false
+		m.tagsModel.loading = false
+		m.categoriesModel.loading = false
 		cmds = append(cmds, m.alert.NewAlertCmd(bubbleup.ErrorKey, e.err.Error()))
 		return m, tea.Batch(cmds...)
 	}
 
+	// A pending quit confirmation swallows the next key.
+	if m.confirmQuit {
+		if k, ok := msg.(tea.KeyMsg); ok {
+			m.confirmQuit = false
+			if k.String() == "y" || k.String() == "ctrl+c" {
+				return m, tea.Quit
+			}
+			return m, tea.Batch(cmds...)
+		}
+	}
+
 	// If add link modal is showing, delegate to it first.
 	if m.showAddLinkModal {
 		var cmd tea.Cmd
@@ -165,16 +488,90 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, tea.Batch(cmds...)
 	}
 
+	// Any key dismisses the QR code overlay.
+	if m.showQRModal {
+		if k, ok := msg.(tea.KeyMsg); ok {
+			if k.String() == "ctrl+c" {
+				return m.quit()
+			}
+			m.showQRModal = false
+			return m, tea.Batch(cmds...)
+		}
+	}
+
+	// The command palette takes every key while it is open.
+	if m.showPalette {
+		if k, ok := msg.(tea.KeyMsg); ok {
+			switch k.String() {
+			case "esc":
+				m.showPalette = false
+				return m, tea.Batch(cmds...)
+			case "ctrl+c":
+				return m.quit()
+			}
+			var cmd tea.Cmd
+			m.palette, cmd = m.palette.Update(msg)
+			cmds = append(cmds, cmd)
+			return m, tea.Batch(cmds...)
+		}
+	}
+
+	// Messages the command palette sends; tabs can also ask for it with :.
+	switch p := msg.(type) {
+	case openPaletteMsg:
+		m.showPalette = true
+		m.palette = NewPaletteModel(m.db)
+		return m, tea.Batch(cmds...)
+	case closePaletteMsg:
+		m.showPalette = false
+		return m, tea.Batch(cmds...)
+	case toggleLogPanelMsg:
+		cmds = append(cmds, m.toggleLogPanel())
+		return m, tea.Batch(cmds...)
+	case switchTabMsg:
+		m.currentTab = p.tab
+		cmds = append(cmds, m.loadTabData())
+		return m, tea.Batch(cmds...)
+	case jumpToLinkMsg:
+		var cmd tea.Cmd
+		m.currentTab = TabLinks
+		m.linksModel, cmd = m.linksModel.Update(msg)
+		cmds = append(cmds, cmd)
+		return m, tea.Batch(cmds...)
+	case refetchSelectedMsg:
+		if m.currentTab != TabLinks {
+			cmds = append(cmds, notifyCmd("info", "Refetch works on the Links tab"))
+			return m, tea.Batch(cmds...)
+		}
+	}
+
+	// Sub-models can fire this to show a QR code for a link's URL.
+	if q, ok := msg.(showQRCodeMsg); ok {
+		m.showQRModal = true
+		m.qrURL = q.url
+		return m, tea.Batch(cmds...)
+	}
+
 	// Sub-models can fire this to request the global add-link modal.
-	if _, ok := msg.(openAddLinkModalMsg); ok {
+	if o, ok := msg.(openAddLinkModalMsg); ok {
 		m.showAddLinkModal = true
 		m.addLinkModel = NewAddLinkModel()
+		m.addLinkModel.statusRule = m.statusRule
+		m.addLinkModel.categoryRules = m.categoryRules
+		m.addLinkModel.defaults = m.linkDefaults
+		m.addLinkModel.tagger = m.tagger
 		m.addLinkModel.width = m.width
 		m.addLinkModel.height = m.height
 		m.addLinkModel.inModal = true
 		cmds = append(cmds, func() tea.Msg {
 			return tea.WindowSizeMsg{Width: m.width, Height: m.height}
-		})
+		}, loadTaxonomy(m.db))
+		if o.url != "" {
+			var fetchCmd tea.Cmd
+			m.addLinkModel.urlInput.SetValue(o.url)
+			m.addLinkModel, fetchCmd = m.addLinkModel.startFetch(m.db, m.fetcher, m.ctx)
+			cmds = append(cmds, fetchCmd)
+		}
 		return m, tea.Batch(cmds...)
 	}
 
@@ -182,17 +579,19 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.KeyMsg:
 		switch msg.String() {
 		case "ctrl+c":
-			return m, tea.Quit
+			return m.quit()
 
 		case "ctrl+l":
-			m.showLogPanel = !m.showLogPanel
-			if m.showLogPanel {
-				m.refreshLogViewport()
-			}
-			// Re-send window size so tab models recalculate heights.
-			cmds = append(cmds, func() tea.Msg {
-				return tea.WindowSizeMsg{Width: m.width, Height: m.height}
-			})
+			cmds = append(cmds, m.toggleLogPanel())
+			return m, tea.Batch(cmds...)
+
+		case "ctrl+k":
+			cmds = append(cmds, openPalette)
+			return m, tea.Batch(cmds...)
+
+		case "ctrl+v":
+			// Save the URL on the clipboard in one keystroke.
+			cmds = append(cmds, addFromClipboardCmd())
 			return m, tea.Batch(cmds...)
 
 		case "ctrl+n":
@@ -206,9 +605,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, tea.Batch(cmds...)
 		}
 
-		// Forward PgUp/PgDn to the log viewport when the panel is visible.
+		// Forward PgUp/PgDn to the log viewport when the panel is visible,
+		// and let Ctrl+T switch its times between clock and relative.
 		if m.showLogPanel && m.logReady {
 			switch msg.String() {
+			case "ctrl+t":
+				m.logRelative = !m.logRelative
+				m.refreshLogViewport()
+				return m, tea.Batch(cmds...)
 			case "pgup", "pgdown":
 				var vpCmd tea.Cmd
 				m.logViewport, vpCmd = m.logViewport.Update(msg)
@@ -222,10 +626,6 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
-		m.tasksModel.width = m.width
-		m.tasksModel.height = m.height
-		m.activitiesModel.width = m.width
-		m.activitiesModel.height = m.height
 
 		// Initialise / resize the log viewport.
 		logInnerH := logPanelHeight - 4 // subtract border rows + title
@@ -244,32 +644,38 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 		// Forward WindowSizeMsg to all tab models so their viewports are
-		// initialized regardless of which tab is currently active.
-		var wCmd tea.Cmd
-		m.linksModel, wCmd = m.linksModel.Update(msg)
-		if wCmd != nil {
-			cmds = append(cmds, wCmd)
-		}
-		m.readLaterModel, wCmd = m.readLaterModel.Update(msg)
-		if wCmd != nil {
-			cmds = append(cmds, wCmd)
-		}
-		m.tagsModel, wCmd = m.tagsModel.Update(msg)
-		if wCmd != nil {
-			cmds = append(cmds, wCmd)
-		}
-		m.categoriesModel, wCmd = m.categoriesModel.Update(msg)
-		if wCmd != nil {
-			cmds = append(cmds, wCmd)
+		// initialized regardless of which tab is currently active. The
+		// current tab is included here, so skip the delegation below.
+		// Tabs are given the height left over by the log panel so their
+		// lists are sized to the rows they will actually be drawn in.
+		tabMsg := msg
+		if m.showLogPanel {
+			tabMsg.Height -= logPanelHeight + 1
 		}
+		var wCmd tea.Cmd
+		m.linksModel, wCmd = m.linksModel.Update(tabMsg)
+		cmds = append(cmds, wCmd)
+		m.tasksModel, wCmd = m.tasksModel.Update(tabMsg)
+		cmds = append(cmds, wCmd)
+		m.activitiesModel, wCmd = m.activitiesModel.Update(tabMsg)
+		cmds = append(cmds, wCmd)
+		m.readLaterModel, wCmd = m.readLaterModel.Update(tabMsg)
+		cmds = append(cmds, wCmd)
+		m.tagsModel, wCmd = m.tagsModel.Update(tabMsg)
+		cmds = append(cmds, wCmd)
+		m.categoriesModel, wCmd = m.categoriesModel.Update(tabMsg)
+		cmds = append(cmds, wCmd)
+		return m, tea.Batch(cmds...)
 
 	case tasksLoadedMsg:
-		m.tasksModel = NewTasksModel(msg.tasks, m.db)
-		m.tasksModel.SetServices(m.fetcher, m.extractor, m.summarizer)
-		m.tasksModel.width = m.width
-		m.tasksModel.height = m.height
+		// Tasks load at startup whichever tab is showing, so route them
+		// directly rather than through the current tab.
+		var tCmd tea.Cmd
+		m.tasksModel, tCmd = m.tasksModel.Update(msg)
+		if tCmd != nil {
+			cmds = append(cmds, tCmd)
+		}
 		return m, tea.Batch(cmds...)
-
 	}
 
 	// Delegate to current tab's model.
@@ -295,13 +701,26 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
+// toggleLogPanel shows or hides the log panel.
+func (m *Model) toggleLogPanel() tea.Cmd {
+	m.showLogPanel = !m.showLogPanel
+	if m.showLogPanel {
+		m.refreshLogViewport()
+	}
+	// Re-send window size so tab models recalculate heights.
+	width, height := m.width, m.height
+	return func() tea.Msg {
+		return tea.WindowSizeMsg{Width: width, Height: height}
+	}
+}
+
 // refreshLogViewport updates the log viewport content from the in-memory sink
 // and scrolls to the most-recent entry.
 func (m *Model) refreshLogViewport() {
 	if !m.logReady || m.logSink == nil {
 		return
 	}
-	content := m.logSink.Render(m.logViewport.Width)
+	content := m.logSink.Render(m.logViewport.Width, m.logRelative)
 	m.logViewport.SetContent(content)
 	m.logViewport.GotoBottom()
 }
@@ -316,7 +735,7 @@ func (m Model) updateAddLinkModal(msg tea.Msg) (Model, tea.Cmd) {
 			return m, nil
 		}
 		if msg.String() == "ctrl+c" {
-			return m, tea.Quit
+			return m.quit()
 		}
 
 	case addLinkCloseRequestedMsg:
@@ -338,14 +757,40 @@ func (m Model) updateAddLinkModal(msg tea.Msg) (Model, tea.Cmd) {
 	return m, tea.Batch(cmd, extraCmd)
 }
 
+// quit exits the program, first asking for confirmation if an add-link form
+// has unsaved edits.
+func (m Model) quit() (Model, tea.Cmd) {
+	unsaved := m.tasksModel.HasUnsavedChanges() || m.activitiesModel.HasUnsavedChanges()
+	if m.showAddLinkModal && m.addLinkModel.HasUnsavedChanges() {
+		unsaved = true
+	}
+	if unsaved {
+		m.confirmQuit = true
+		return m, nil
+	}
+	return m, tea.Quit
+}
+
 func (m Model) View() string {
 	if m.width == 0 {
 		return "Loading..."
 	}
 
 	var content string
-	if m.showAddLinkModal {
+	if m.confirmQuit {
+		content = m.renderQuitConfirm()
+	} else if m.showAddLinkModal {
 		content = m.renderAddLinkModal()
+	} else if m.showQRModal {
+		content = m.renderQRModal()
+	} else if m.showPalette {
+		modal := lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("10")).
+			Padding(1, 2).
+			Width(56).
+			Render(m.palette.View())
+		content = lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modal)
 	} else {
 		tabContent := m.renderTabs() + "\n" + m.renderCurrentTab()
 		if m.showLogPanel {
@@ -392,12 +837,41 @@ func (m Model) renderTabs() string {
 	tabBar := lipgloss.JoinHorizontal(lipgloss.Bottom, renderedTabs...)
 	header := lipgloss.JoinVertical(lipgloss.Left, title, tabBar)
 
-	separator := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("237")).
+	// Context line: the tab, then what is selected in it. A pending load is
+	// flagged so stale or empty content is not mistaken for the result.
+	crumbs := append([]string{tabs[m.currentTab]}, m.breadcrumb()...)
+	line := strings.Join(crumbs, " › ")
+	if m.tabLoading() {
+		line += " · loading…"
+	}
+	contextLine := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("243")).
 		Width(m.width).
-		Render(lipgloss.Border{}.Top)
+		MaxHeight(1).
+		Padding(0, 2).
+		Render(line)
 
-	return header + "\n" + separator
+	return header + "\n" + contextLine
+}
+
+// breadcrumb returns the current tab's context, e.g. the selected task and
+// its link count.
+func (m Model) breadcrumb() []string {
+	switch m.currentTab {
+	case TabLinks:
+		return m.linksModel.Breadcrumb()
+	case TabTasks:
+		return m.tasksModel.Breadcrumb()
+	case TabActivities:
+		return m.activitiesModel.Breadcrumb()
+	case TabReadLater:
+		return m.readLaterModel.Breadcrumb()
+	case TabTags:
+		return m.tagsModel.Breadcrumb()
+	case TabCategories:
+		return m.categoriesModel.Breadcrumb()
+	}
+	return nil
 }
 
 func (m Model) renderCurrentTab() string {
@@ -424,10 +898,26 @@ func (m Model) renderCurrentTab() string {
 		content = m.categoriesModel.View()
 	}
 
-	footerText := "Ctrl+A: add link • Ctrl+N/P: prev/next tab • Ctrl+L: logs • Ctrl+C: quit"
+	// Ctrl+A adds to whatever the current tab lists.
+	addAction := "add link"
+	switch m.currentTab {
+	case TabTasks:
+		addAction = "new task"
+	case TabActivities:
+		addAction = "new activity"
+	case TabTags:
+		addAction = "new tag"
+	case TabCategories:
+		addAction = "new category"
+	}
+	footerText := "Ctrl+A: " + addAction + " • Ctrl+V: add copied URL • Ctrl+K: commands • Ctrl+N/P: prev/next tab • Ctrl+L: logs • Ctrl+C: quit"
+	if m.linkTotal > 0 {
+		footerText += fmt.Sprintf(" • %s (%s to read)", linkCount(int(m.linkTotal)), formatCount(m.linkReadLater))
+	}
+	footerText += " • " + m.llmStatus()
 	if m.totalLLMCost > 0 {
 		costStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("243"))
-		footerText += costStyle.Render(fmt.Sprintf(" • LLM: $%.5f", m.totalLLMCost))
+		footerText += costStyle.Render(fmt.Sprintf(" $%.5f", m.totalLLMCost))
 	}
 	footer := "\n" + lipgloss.NewStyle().
 		Foreground(lipgloss.Color("241")).
@@ -439,6 +929,18 @@ func (m Model) renderCurrentTab() string {
 	return contentStyle.Render(content) + footer
 }
 
+// llmStatus says whether new links will be summarized: off without an
+// OpenAI key, or once the key has been rejected.
+func (m Model) llmStatus() string {
+	switch {
+	case m.summarizer == nil:
+		return "LLM: off"
+	case !m.summarizer.Enabled():
+		return "LLM: off (key rejected)"
+	}
+	return "LLM: on (" + services.LLMModel + ")"
+}
+
 func (m Model) renderLogPanel() string {
 	titleStyle := lipgloss.NewStyle().
 		Bold(true).
@@ -448,7 +950,7 @@ func (m Model) renderLogPanel() string {
 		Foreground(lipgloss.Color("243"))
 
 	title := titleStyle.Render("Logs") +
-		hintStyle.Render("  PgUp/PgDn: scroll • Ctrl+L: close")
+		hintStyle.Render("  PgUp/PgDn: scroll • Ctrl+T: relative times • Ctrl+L: close")
 
 	var body string
 	if m.logReady {
@@ -500,43 +1002,131 @@ func (m Model) renderAddLinkModal() string {
 	)
 }
 
-func (m Model) loadTabData() tea.Cmd {
+func (m Model) renderQuitConfirm() string {
+	content := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("11")).Render("Discard unsaved changes?") + "\n\n" +
+		lipgloss.NewStyle().Foreground(lipgloss.Color("243")).Render("y: quit • any other key: go back")
+
+	modal := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("11")).
+		Padding(1, 2).
+		Render(content)
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modal)
+}
+
+func (m Model) renderQRModal() string {
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("6"))
+
+	dimStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("243"))
+
+	var body string
+	code, err := renderQRCode(m.qrURL)
+	if err != nil {
+		body = lipgloss.NewStyle().Foreground(lipgloss.Color("9")).Render("Could not render QR code: " + err.Error())
+	} else {
+		body = code
+	}
+
+	url := m.qrURL
+	if len(url) > 60 {
+		url = url[:57] + "..."
+	}
+
+	content := titleStyle.Render("QR Code") + "\n\n" +
+		body + "\n" +
+		lipgloss.NewStyle().Foreground(lipgloss.Color("12")).Render(url) + "\n\n" +
+		dimStyle.Render("Press any key to close")
+
+	modal := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("10")).
+		Padding(1, 2).
+		Render(content)
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modal)
+}
+
+// loadTabData reloads the current tab and marks it loading until the
+// result arrives.
+func (m *Model) loadTabData() tea.Cmd {
 	switch m.currentTab {
 	case TabLinks:
+		m.linksModel.loading = true
 		return m.linksModel.loadLinks()
 	case TabTasks:
-		return m.loadTasks()
+		m.tasksModel.loading = true
+		return m.tasksModel.loadTasks()
 	case TabActivities:
+		m.activitiesModel.loading = true
 		return m.activitiesModel.loadActivities()
 	case TabReadLater:
+		m.readLaterModel.loading = true
 		return m.readLaterModel.loadLinks()
 	case TabTags:
+		m.tagsModel.loading = true
 		return m.tagsModel.loadTags()
 	case TabCategories:
+		m.categoriesModel.loading = true
 		return m.categoriesModel.loadCategories()
 	}
 	return nil
 }
 
+// tabLoading reports whether the current tab is waiting for its list.
+func (m Model) tabLoading() bool {
+	switch m.currentTab {
+	case TabLinks:
+		return m.linksModel.loading
+	case TabTasks:
+		return m.tasksModel.loading
+	case TabActivities:
+		return m.activitiesModel.loading
+	case TabReadLater:
+		return m.readLaterModel.loading
+	case TabTags:
+		return m.tagsModel.loading
+	case TabCategories:
+		return m.categoriesModel.loading
+	}
+	return false
+}
+
 // Messages
+// linksVisitedMsg is fired by any tab when links are opened in the browser
+// or their content is shown, so the root model can auto-refresh them.
+type linksVisitedMsg struct {
+	links []models.Link
+}
+
+// linkAutoRefreshedMsg reports a finished background auto-refresh.
+type linkAutoRefreshedMsg struct {
+	id    int64
+	url   string
+	title string
+	err   error
+}
+
+// linkCountsMsg carries the library size shown in the footer.
+type linkCountsMsg struct {
+	total     int64
+	readLater int64
+}
+
 // openAddLinkModalMsg is fired by any tab to ask the root model to open the
-// global add-link modal.
-type openAddLinkModalMsg struct{}
+// global add-link modal. With a url, the modal starts processing it at once.
+type openAddLinkModalMsg struct {
+	url string
+}
 
 type linksLoadedMsg struct {
 	links []models.Link
+	tags  map[int64][]string
 }
 
 type errMsg struct {
 	err error
 }
-
-func (m Model) loadTasks() tea.Cmd {
-	return func() tea.Msg {
-		tasks, err := m.db.Queries.ListTasks(context.Background())
-		if err != nil {
-			return errMsg{err: err}
-		}
-		return tasksLoadedMsg{tasks: tasks}
-	}
-}
@@ -0,0 +1,234 @@
+package tui
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"mccwk.com/lm/internal/database"
+	"mccwk.com/lm/internal/models"
+)
+
+// paletteRows is the most commands the palette lists at once.
+const paletteRows = 8
+
+// paletteCommand is an entry in the command palette: choosing it sends msg
+// as if the matching key had been pressed.
+type paletteCommand struct {
+	name string
+	key  string // the usual shortcut, shown as a reminder
+	msg  tea.Msg
+}
+
+// paletteCommands are the commands the palette offers besides jumping to a
+// link.
+var paletteCommands = []paletteCommand{
+	{name: "Add link", key: "Ctrl+A", msg: openAddLinkModalMsg{}},
+	{name: "Refetch current link", key: "Ctrl+R", msg: refetchSelectedMsg{}},
+	{name: "Toggle logs", key: "Ctrl+L", msg: toggleLogPanelMsg{}},
+	{name: "Go to Links", msg: switchTabMsg{tab: TabLinks}},
+	{name: "Go to Tasks", msg: switchTabMsg{tab: TabTasks}},
+	{name: "Go to Activities", msg: switchTabMsg{tab: TabActivities}},
+	{name: "Go to Read Later", msg: switchTabMsg{tab: TabReadLater}},
+	{name: "Go to Tags", msg: switchTabMsg{tab: TabTags}},
+	{name: "Go to Categories", msg: switchTabMsg{tab: TabCategories}},
+}
+
+// PaletteModel is the command palette: type a link ID or paste a URL to jump
+// to that link in the Links tab, or type part of a command's name to run it.
+type PaletteModel struct {
+	input    textinput.Model
+	filtered []paletteCommand
+	cursor   int
+
+	db  *database.Database
+	ctx context.Context
+}
+
+func NewPaletteModel(db *database.Database) PaletteModel {
+	input := textinput.New()
+	input.Placeholder = "link ID, URL, or command..."
+	input.Width = 44
+	input.Prompt = ": "
+	input.Focus()
+
+	m := PaletteModel{
+		input: input,
+		db:    db,
+		ctx:   context.Background(),
+	}
+	m.filter()
+	return m
+}
+
+func (m PaletteModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m PaletteModel) Update(msg tea.Msg) (PaletteModel, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "up", "ctrl+p":
+			m.cursor = max(m.cursor-1, 0)
+			return m, nil
+		case "down", "ctrl+n":
+			m.cursor = max(min(m.cursor+1, len(m.filtered)-1), 0)
+			return m, nil
+		case "enter":
+			return m, m.run()
+		}
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	if _, ok := msg.(tea.KeyMsg); ok {
+		m.filter()
+	}
+	return m, cmd
+}
+
+// filter keeps the commands whose names contain every typed word.
+func (m *PaletteModel) filter() {
+	words := strings.Fields(strings.ToLower(m.input.Value()))
+	m.filtered = m.filtered[:0]
+	for _, c := range paletteCommands {
+		name := strings.ToLower(c.name)
+		match := true
+		for _, w := range words {
+			if !strings.Contains(name, w) {
+				match = false
+				break
+			}
+		}
+		if match {
+			m.filtered = append(m.filtered, c)
+		}
+	}
+	if m.cursor >= len(m.filtered) {
+		m.cursor = max(len(m.filtered)-1, 0)
+	}
+}
+
+// target returns the link ID or URL typed into the palette, if any. An ID
+// may be written as 42 or #42.
+func (m PaletteModel) target() (id int64, url string) {
+	value := strings.TrimSpace(m.input.Value())
+	if n, err := strconv.ParseInt(strings.TrimPrefix(value, "#"), 10, 64); err == nil && n > 0 {
+		return n, ""
+	}
+	if u, ok := clipboardURL(value); ok {
+		return 0, u
+	}
+	return 0, ""
+}
+
+// run closes the palette and carries out what was typed or selected.
+func (m PaletteModel) run() tea.Cmd {
+	closeCmd := func() tea.Msg { return closePaletteMsg{} }
+	if id, url := m.target(); id != 0 || url != "" {
+		return tea.Sequence(closeCmd, m.findLink(id, url))
+	}
+	if m.cursor >= len(m.filtered) {
+		return nil
+	}
+	run := m.filtered[m.cursor].msg
+	return tea.Sequence(closeCmd, func() tea.Msg { return run })
+}
+
+// findLink looks up the link to jump to. A URL that is not saved yet opens
+// the add-link form with it instead.
+func (m PaletteModel) findLink(id int64, url string) tea.Cmd {
+	db, ctx := m.db, m.ctx
+	return func() tea.Msg {
+		var link models.Link
+		var err error
+		if id != 0 {
+			link, err = db.Queries.GetLink(ctx, id)
+		} else {
+			link, err = db.Queries.GetLinkByURL(ctx, url)
+		}
+		switch {
+		case errors.Is(err, sql.ErrNoRows) && url != "":
+			return openAddLinkModalMsg{url: url}
+		case errors.Is(err, sql.ErrNoRows):
+			return notifyMsg{level: "warning", message: fmt.Sprintf("No link #%d", id)}
+		case err != nil:
+			return errMsg{err: err}
+		}
+		return jumpToLinkMsg{link: link}
+	}
+}
+
+func (m PaletteModel) View() string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("6"))
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("243"))
+	selectedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("10")).Bold(true)
+
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("Command Palette") + "\n\n")
+	b.WriteString(m.input.View() + "\n\n")
+
+	switch id, url := m.target(); {
+	case id != 0:
+		b.WriteString(selectedStyle.Render(fmt.Sprintf("• Jump to link #%d", id)) + "\n")
+	case url != "":
+		if len(url) > 40 {
+			url = url[:37] + "..."
+		}
+		b.WriteString(selectedStyle.Render("• Jump to "+url) + "\n")
+	case len(m.filtered) == 0:
+		b.WriteString(dimStyle.Render("No matching command.") + "\n")
+	default:
+		start, end := listWindow(len(m.filtered), m.cursor, paletteRows, func(int) int { return 1 })
+		above, below := moreIndicators(start, end, len(m.filtered))
+		if above != "" {
+			b.WriteString(dimStyle.Render(above) + "\n")
+		}
+		for i := start; i < end; i++ {
+			c := m.filtered[i]
+			var hint string
+			if c.key != "" {
+				hint = dimStyle.Render("  " + c.key)
+			}
+			if i == m.cursor {
+				b.WriteString(selectedStyle.Render("• "+c.name) + hint + "\n")
+			} else {
+				b.WriteString("  " + c.name + hint + "\n")
+			}
+		}
+		if below != "" {
+			b.WriteString(dimStyle.Render(below) + "\n")
+		}
+	}
+
+	b.WriteString("\n" + dimStyle.Render("↑/↓: select • Enter: run • Esc: close"))
+	return b.String()
+}
+
+// openPaletteMsg asks the root model to show the command palette.
+type openPaletteMsg struct{}
+
+func openPalette() tea.Msg { return openPaletteMsg{} }
+
+type closePaletteMsg struct{}
+
+// jumpToLinkMsg shows link in the Links tab.
+type jumpToLinkMsg struct {
+	link models.Link
+}
+
+// refetchSelectedMsg refetches the link selected in the Links tab.
+type refetchSelectedMsg struct{}
+
+type toggleLogPanelMsg struct{}
+
+type switchTabMsg struct {
+	tab Tab
+}
@@ -0,0 +1,27 @@
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/skip2/go-qrcode"
+)
+
+// showQRCodeMsg is fired by any tab to ask the root model to display a QR code
+// overlay for the given URL.
+type showQRCodeMsg struct {
+	url string
+}
+
+// showQRCodeCmd returns a tea.Cmd that fires a showQRCodeMsg.
+func showQRCodeCmd(url string) tea.Cmd {
+	return func() tea.Msg { return showQRCodeMsg{url: url} }
+}
+
+// renderQRCode encodes url as a QR code drawn with half-block characters, so
+// each terminal row holds two rows of modules and the code stays roughly square.
+func renderQRCode(url string) (string, error) {
+	qr, err := qrcode.New(url, qrcode.Medium)
+	if err != nil {
+		return "", err
+	}
+	return qr.ToSmallString(false), nil
+}
@@ -3,35 +3,46 @@ package tui
 import (
 	"context"
 	"fmt"
+	"math/rand"
+	"sort"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
-	"github.com/pkg/browser"
 
 	"mccwk.com/lm/internal/database"
 	"mccwk.com/lm/internal/models"
+	"mccwk.com/lm/internal/services"
 )
 
 type ReadLaterModel struct {
 	links         []models.Link
+	linkTags      map[int64][]string // tag names by link ID, for tag: searches
 	filteredLinks []models.Link
 	cursor        int
+	loading       bool        // a list load is in flight
+	count         countPrefix // pending numeric prefix, e.g. the 5 in 5j
 	db            *database.Database
 	ctx           context.Context
 
 	// Search and focus
 	searchInput textinput.Model
+	cleared     clearedSearch
 	focus       panelFocus
 
 	// Detail view
 	detailViewport viewport.Model
 	viewportReady  bool
-
-	width  int
-	height int
+	detailLines    []string // plain-text lines of the detail view, for n/N search
+	matchIdx       int      // index of the current n/N search match, -1 if none
+
+	width        int
+	height       int
+	splitRatio   float64 // list panel's share of the width, set by Model
+	readingWidth int     // widest the detail text runs, 0 for the panel width; set by Model
+	browser      string  // command links open with, "" for the system opener; set by Model
 }
 
 func NewReadLaterModel(db *database.Database) ReadLaterModel {
@@ -46,6 +57,7 @@ func NewReadLaterModel(db *database.Database) ReadLaterModel {
 		ctx:         context.Background(),
 		searchInput: searchInput,
 		focus:       panelFocusSearch,
+		loading:     true,
 	}
 }
 
@@ -61,11 +73,7 @@ func (m ReadLaterModel) Update(msg tea.Msg) (ReadLaterModel, tea.Cmd) {
 		m.width = msg.Width
 		m.height = msg.Height
 
-		leftWidth := int(float64(m.width) * 0.35)
-		if leftWidth < 30 {
-			leftWidth = 30
-		}
-		rightWidth := m.width - leftWidth - 8
+		_, rightWidth := splitWidths(m.width, m.splitRatio)
 		detailHeight := m.height - 12
 		if detailHeight < 5 {
 			detailHeight = 5
@@ -84,7 +92,7 @@ func (m ReadLaterModel) Update(msg tea.Msg) (ReadLaterModel, tea.Cmd) {
 		return m, nil
 
 	case tea.KeyMsg:
-		halfPage := (m.height - 15) / 2
+		halfPage := listRows(m.height, 4) / 2
 		if halfPage < 1 {
 			halfPage = 1
 		}
@@ -106,19 +114,53 @@ func (m ReadLaterModel) Update(msg tea.Msg) (ReadLaterModel, tea.Cmd) {
 				m.searchInput.Blur()
 			}
 			return m, nil
+		case "left", "h":
+			// ←/h and →/l move between the list and detail panels; in the
+			// search box they are ordinary input.
+			if m.focus != panelFocusSearch {
+				m.focus = focusLeft(m.focus)
+				return m, nil
+			}
+		case "right", "l":
+			if m.focus != panelFocusSearch {
+				m.focus = focusRight(m.focus)
+				return m, nil
+			}
+		case "<", ">":
+			// < and > narrow and widen the list panel on every tab.
+			if m.focus != panelFocusSearch {
+				return m, resizeSplit(msg.String() == ">")
+			}
+		case ":":
+			if m.focus != panelFocusSearch {
+				return m, openPalette
+			}
 		}
 
 		switch m.focus {
 		case panelFocusList:
+			// Digits build a count prefix applied to the next up/down move.
+			if m.count.push(msg.String()) {
+				return m, nil
+			}
+			n := m.count.take()
 			switch msg.String() {
 			case "up", "k":
 				if m.cursor > 0 {
-					m.cursor--
+					m.cursor = max(m.cursor-n, 0)
 					m.updateDetailView()
 				}
 			case "down", "j":
 				if m.cursor < len(m.filteredLinks)-1 {
-					m.cursor++
+					m.cursor = min(m.cursor+n, len(m.filteredLinks)-1)
+					m.updateDetailView()
+				}
+			case "g", "home":
+				m.cursor = 0
+				m.updateDetailView()
+			case "G", "end":
+				if len(m.filteredLinks) > 0 {
+					m.cursor = len(m.filteredLinks) - 1
 					m.updateDetailView()
 				}
 			case "pgup", "ctrl+u":
@@ -133,11 +175,41 @@ func (m ReadLaterModel) Update(msg tea.Msg) (ReadLaterModel, tea.Cmd) {
 					m.cursor = len(m.filteredLinks) - 1
 				}
 				m.updateDetailView()
+			case "q":
+				if len(m.filteredLinks) > 0 && m.cursor < len(m.filteredLinks) {
+					return m, showQRCodeCmd(m.filteredLinks[m.cursor].Url)
+				}
+			case "y":
+				if len(m.filteredLinks) > 0 && m.cursor < len(m.filteredLinks) {
+					return m, copyMarkdownLinkCmd(m.filteredLinks[m.cursor])
+				}
+			case "*":
+				// Jump to a random link to help rediscover old entries,
+				// never the one already selected.
+				if len(m.filteredLinks) > 1 {
+					next := rand.Intn(len(m.filteredLinks) - 1)
+					if next >= m.cursor {
+						next++
+					}
+					m.cursor = next
+					m.updateDetailView()
+				}
 			case "enter", "ctrl+o":
 				if len(m.filteredLinks) > 0 && m.cursor < len(m.filteredLinks) {
-					return m, m.openLink(m.filteredLinks[m.cursor].Url)
+					return m, m.openLink(m.filteredLinks[m.cursor])
 				}
-			case "ctrl+a":
+			case "o":
+				// Read the saved copy when the live page is gone or paywalled.
+				if len(m.filteredLinks) > 0 && m.cursor < len(m.filteredLinks) {
+					return m, openSavedCopyCmd(m.ctx, m.db, m.browser, m.filteredLinks[m.cursor])
+				}
+			case "O":
+				// Open and archive in one go, for working through the queue.
+				if len(m.filteredLinks) > 0 && m.cursor < len(m.filteredLinks) {
+					link := m.filteredLinks[m.cursor]
+					return m, tea.Batch(m.openLink(link), setLinkArchived(m.ctx, m.db, link.ID, true))
+				}
+			case "ctrl+a", "n":
 				return m, func() tea.Msg { return openAddLinkModalMsg{} }
 			case "esc":
 				m.focus = panelFocusSearch
@@ -152,6 +224,39 @@ func (m ReadLaterModel) Update(msg tea.Msg) (ReadLaterModel, tea.Cmd) {
 					m.detailViewport, cmd = m.detailViewport.Update(msg)
 					return m, cmd
 				}
+			case "q":
+				if len(m.filteredLinks) > 0 && m.cursor < len(m.filteredLinks) {
+					return m, showQRCodeCmd(m.filteredLinks[m.cursor].Url)
+				}
+			case "y":
+				if len(m.filteredLinks) > 0 && m.cursor < len(m.filteredLinks) {
+					return m, copyMarkdownLinkCmd(m.filteredLinks[m.cursor])
+				}
+			case "c", "C":
+				// Copy the content itself, as Markdown or as plain text.
+				if len(m.filteredLinks) > 0 && m.cursor < len(m.filteredLinks) {
+					return m, copyContentCmd(m.filteredLinks[m.cursor], msg.String() == "C", m.detailViewport.Width, m.readingWidth)
+				}
+			case "o":
+				if len(m.filteredLinks) > 0 && m.cursor < len(m.filteredLinks) {
+					return m, openSavedCopyCmd(m.ctx, m.db, m.browser, m.filteredLinks[m.cursor])
+				}
+			case "n":
+				if m.viewportReady {
+					return m, m.jumpToMatch(1)
+				}
+			case "N":
+				if m.viewportReady {
+					return m, m.jumpToMatch(-1)
+				}
+			case "g", "home":
+				if m.viewportReady {
+					m.detailViewport.GotoTop()
+				}
+			case "G", "end":
+				if m.viewportReady {
+					m.detailViewport.GotoBottom()
+				}
 			case "up", "k":
 				if m.viewportReady {
 					m.detailViewport.ScrollUp(1)
@@ -160,6 +265,8 @@ func (m ReadLaterModel) Update(msg tea.Msg) (ReadLaterModel, tea.Cmd) {
 				if m.viewportReady {
 					m.detailViewport.ScrollDown(1)
 				}
+			case "ctrl+a":
+				return m, func() tea.Msg { return openAddLinkModalMsg{} }
 			case "esc":
 				m.focus = panelFocusSearch
 				m.searchInput.Focus()
@@ -182,15 +289,20 @@ func (m ReadLaterModel) Update(msg tea.Msg) (ReadLaterModel, tea.Cmd) {
 				return m, nil
 			case "enter", "ctrl+o":
 				if len(m.filteredLinks) > 0 && m.cursor < len(m.filteredLinks) {
-					return m, m.openLink(m.filteredLinks[m.cursor].Url)
+					return m, m.openLink(m.filteredLinks[m.cursor])
 				}
 				return m, nil
 			case "ctrl+a":
 				return m, func() tea.Msg { return openAddLinkModalMsg{} }
+			case "n":
+				// With nothing to search, n adds a link as in the list.
+				if len(m.links) == 0 && m.searchInput.Value() == "" {
+					return m, func() tea.Msg { return openAddLinkModalMsg{} }
+				}
 			case "esc":
-				m.searchInput.SetValue("")
+				notice := m.cleared.esc(&m.searchInput)
 				m.filterLinks()
-				return m, nil
+				return m, notice
 			}
 			m.searchInput, cmd = m.searchInput.Update(msg)
 			m.filterLinks()
@@ -200,11 +312,23 @@ func (m ReadLaterModel) Update(msg tea.Msg) (ReadLaterModel, tea.Cmd) {
 			return m, cmd
 		}
 
+	case linkArchivedMsg:
+		return m, tea.Batch(m.loadLinks(), notifyCmd("info", "Archived"))
+
 	case readLaterLoadedMsg:
+		selected := selectedID(m.filteredLinks, m.cursor, linkIDOf)
+		offset := m.detailViewport.YOffset
+		m.loading = false
 		m.links = msg.links
+		m.linkTags = msg.tags
 		m.filterLinks()
+		m.cursor = reselect(m.filteredLinks, selected, m.cursor, linkIDOf)
 		if len(m.filteredLinks) > 0 {
 			m.updateDetailView()
+			// Still on the same link: keep the reading position.
+			if m.filteredLinks[m.cursor].ID == selected {
+				m.detailViewport.SetYOffset(offset)
+			}
 		}
 		return m, nil
 	}
@@ -212,16 +336,18 @@ func (m ReadLaterModel) Update(msg tea.Msg) (ReadLaterModel, tea.Cmd) {
 	return m, nil
 }
 
+// Breadcrumb describes what the tab is showing, for the context line under
+// the tab bar.
+func (m ReadLaterModel) Breadcrumb() []string {
+	return []string{listPosition(m.cursor, len(m.filteredLinks))}
+}
+
 func (m ReadLaterModel) View() string {
 	if m.width == 0 {
 		return "Loading..."
 	}
 
-	leftWidth := int(float64(m.width) * 0.35)
-	if leftWidth < 30 {
-		leftWidth = 30
-	}
-	rightWidth := m.width - leftWidth - 8
+	leftWidth, rightWidth := splitWidths(m.width, m.splitRatio)
 
 	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("6"))
 	selectedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("10")).Bold(true)
@@ -247,21 +373,23 @@ func (m ReadLaterModel) View() string {
 	if len(m.filteredLinks) == 0 {
 		if m.searchInput.Value() != "" {
 			leftContent += dimStyle.Render("No links match your search.\n")
+		} else if m.loading {
+			leftContent += dimStyle.Render("Loading...\n")
 		} else {
-			leftContent += dimStyle.Render("No links to read later. Add one with Ctrl+A!\n")
+			leftContent += dimStyle.Render("No links to read later. Press Ctrl+A or n to add one!\n")
 		}
 	} else {
-		maxLinks := m.height - 15
-		if maxLinks < 3 {
-			maxLinks = 3
-		}
-		startIdx := 0
-		endIdx := len(m.filteredLinks)
-		if m.cursor >= maxLinks {
-			startIdx = m.cursor - maxLinks + 1
-		}
-		if endIdx > startIdx+maxLinks {
-			endIdx = startIdx + maxLinks
+		maxLinks := listRows(m.height, 4)
+		// Only the selected link shows its summary.
+		startIdx, endIdx := listWindow(len(m.filteredLinks), m.cursor, maxLinks, func(i int) int {
+			if link := m.filteredLinks[i]; i == m.cursor && listSummary(link) != "" {
+				return 2
+			}
+			return 1
+		})
+		above, below := moreIndicators(startIdx, endIdx, len(m.filteredLinks))
+		if above != "" {
+			leftContent += dimStyle.Render(above) + "\n"
 		}
 
 		for i := startIdx; i < endIdx; i++ {
@@ -274,24 +402,34 @@ func (m ReadLaterModel) View() string {
 			if title == "" {
 				title = link.Url
 			}
+			if link.NeedsAttention {
+				title = attentionMarker + " " + title
+			}
+			if marker := expiryMarker(link); marker != "" {
+				title = marker + " " + title
+			}
 			if len(title) > leftWidth-8 {
 				title = title[:leftWidth-11] + "..."
 			}
 			line := fmt.Sprintf("%s%s", cursor, title)
 			if i == m.cursor {
 				leftContent += selectedStyle.Render(line) + "\n"
-				if link.Summary.Valid && link.Summary.String != "" {
-					summary := link.Summary.String
+				if summary := listSummary(link); summary != "" {
 					if len(summary) > leftWidth-8 {
 						summary = summary[:leftWidth-11] + "..."
 					}
 					leftContent += dimStyle.Render("  "+summary) + "\n"
 				}
+			} else if linkExpired(link) {
+				leftContent += dimStyle.Render(line) + "\n"
 			} else {
 				leftContent += line + "\n"
 			}
 		}
-		if len(m.filteredLinks) > maxLinks {
+		if below != "" {
+			leftContent += dimStyle.Render(below) + "\n"
+		}
+		if endIdx-startIdx < len(m.filteredLinks) {
 			leftContent += "\n" + dimStyle.Render(fmt.Sprintf("  [%d/%d links]", m.cursor+1, len(m.filteredLinks)))
 		}
 	}
@@ -332,9 +470,9 @@ func (m ReadLaterModel) View() string {
 	var helpMsg string
 	switch m.focus {
 	case panelFocusList:
-		helpMsg = "Tab: detail • ↑/↓/j/k: navigate • PgUp/PgDn/Ctrl+U/D: jump • Enter/Ctrl+O: open • Ctrl+A: add • Esc: search"
+		helpMsg = "Tab: detail • ↑/↓/j/k: navigate • PgUp/PgDn/Ctrl+U/D: jump • g/G: top/bottom • *: random • q: QR code • y: copy as Markdown • Enter/Ctrl+O: open • o: open saved copy • O: open & archive • Ctrl+A/n: add • Esc: search"
 	case panelFocusDetail:
-		helpMsg = "Tab: search • ↑/↓/j/k/PgUp/PgDn: scroll • Ctrl+O: open • Esc: search"
+		helpMsg = "Tab: search • ↑/↓/j/k/PgUp/PgDn: scroll • g/G: top/bottom • n/N: next/prev match • Ctrl+O: open • o: open saved copy • Ctrl+A: add • q: QR code • y: copy as Markdown • Esc: search"
 	default:
 		helpMsg = "type to search • Tab: list • ↑/↓: navigate • Enter/Ctrl+O: open • Ctrl+A: add • Esc: clear"
 	}
@@ -344,20 +482,25 @@ func (m ReadLaterModel) View() string {
 }
 
 func (m *ReadLaterModel) filterLinks() {
-	query := strings.ToLower(m.searchInput.Value())
-	if query == "" {
+	if m.searchInput.Value() == "" {
 		m.filteredLinks = m.links
 		if m.cursor >= len(m.filteredLinks) {
 			m.cursor = 0
 		}
 		return
 	}
+	query := parseLinkQuery(m.searchInput.Value())
 	m.filteredLinks = []models.Link{}
+	scores := make(map[int64]int)
 	for _, link := range m.links {
-		if linkMatchesQuery(link.Url, link.Title.String, link.Content.String, link.Summary.String, query) {
+		if score, ok := linkMatchesQuery(link, m.linkTags[link.ID], link.Content.String, query); ok {
 			m.filteredLinks = append(m.filteredLinks, link)
+			scores[link.ID] = score
 		}
 	}
+	sort.SliceStable(m.filteredLinks, func(i, j int) bool {
+		return scores[m.filteredLinks[i].ID] > scores[m.filteredLinks[j].ID]
+	})
 	if m.cursor >= len(m.filteredLinks) {
 		m.cursor = 0
 	}
@@ -374,6 +517,7 @@ func (m *ReadLaterModel) updateDetailView() {
 	if link.Title.Valid && link.Title.String != "" {
 		doc.WriteString("# " + link.Title.String + "\n\n")
 	}
+	doc.WriteString(linkInfoLine(link) + "\n\n")
 	if link.Summary.Valid && link.Summary.String != "" {
 		doc.WriteString("**Summary:** " + link.Summary.String + "\n\n")
 	}
@@ -382,8 +526,30 @@ func (m *ReadLaterModel) updateDetailView() {
 		doc.WriteString(link.Content.String)
 	}
 
-	m.detailViewport.SetContent(renderMarkdown(doc.String(), m.detailViewport.Width))
+	rendered := renderMarkdown(doc.String(), m.detailViewport.Width, m.readingWidth)
+	m.detailViewport.SetContent(rendered)
 	m.detailViewport.GotoTop()
+	m.detailLines = plainLines(rendered)
+	m.matchIdx = -1
+}
+
+// jumpToMatch scrolls the detail view to the next (delta=1) or previous
+// (delta=-1) line matching the search query, wrapping at either end.
+func (m *ReadLaterModel) jumpToMatch(delta int) tea.Cmd {
+	query := strings.TrimSpace(m.searchInput.Value())
+	if query == "" {
+		return notifyCmd("info", "No search query")
+	}
+	matches := matchLines(m.detailLines, parseLinkQuery(query).text())
+	if len(matches) == 0 {
+		return notifyCmd("info", "No matches for "+query)
+	}
+	if m.matchIdx < 0 && delta < 0 {
+		m.matchIdx = 0
+	}
+	m.matchIdx = (m.matchIdx + delta + len(matches)) % len(matches)
+	m.detailViewport.SetYOffset(matches[m.matchIdx])
+	return notifyCmd("info", fmt.Sprintf("Match %d of %d", m.matchIdx+1, len(matches)))
 }
 
 func (m ReadLaterModel) loadLinks() tea.Cmd {
@@ -396,17 +562,24 @@ func (m ReadLaterModel) loadLinks() tea.Cmd {
 		if err != nil {
 			return errMsg{err: err}
 		}
-		return readLaterLoadedMsg{links: links}
+		tags, err := loadLinkTagNames(m.ctx, m.db)
+		if err != nil {
+			return errMsg{err: err}
+		}
+		return readLaterLoadedMsg{links: links, tags: tags}
 	}
 }
 
-func (m ReadLaterModel) openLink(url string) tea.Cmd {
+func (m ReadLaterModel) openLink(link models.Link) tea.Cmd {
 	return func() tea.Msg {
-		_ = browser.OpenURL(url)
-		return nil
+		if services.OpenURL(m.browser, link.Url) == nil {
+			m.db.RecordOpen(m.ctx, link.ID)
+		}
+		return linksVisitedMsg{links: []models.Link{link}}
 	}
 }
 
 type readLaterLoadedMsg struct {
 	links []models.Link
+	tags  map[int64][]string
 }
@@ -0,0 +1,121 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"mccwk.com/lm/internal/database"
+	"mccwk.com/lm/internal/models"
+	"mccwk.com/lm/internal/services"
+)
+
+// openSavedCopyCmd writes link's saved content as a standalone HTML page, as
+// lm archive-html does, and opens that in the browser instead of the live
+// URL, for pages that have gone or moved behind a paywall. Pages go to a
+// private folder in the user's cache directory, one per link, replaced on
+// each open.
+func openSavedCopyCmd(ctx context.Context, db *database.Database, browser string, link models.Link) tea.Cmd {
+	return func() tea.Msg {
+		if strings.TrimSpace(link.Content.String) == "" {
+			return notifyMsg{level: "warning", message: "No content saved for this link"}
+		}
+		path, err := writeSavedCopy(ctx, db, link)
+		if err != nil {
+			return notifyMsg{level: "error", message: "Could not write saved copy: " + err.Error()}
+		}
+		if err := services.OpenURL(browser, "file://"+filepath.ToSlash(path)); err != nil {
+			return notifyMsg{level: "error", message: "Could not open saved copy: " + err.Error()}
+		}
+		db.RecordOpen(ctx, link.ID)
+		return linksVisitedMsg{links: []models.Link{link}}
+	}
+}
+
+// writeSavedCopy writes link's page for openSavedCopyCmd and returns its path.
+// The page is written to a temporary file and renamed into place, so a
+// symlink planted at the final path is replaced rather than followed.
+func writeSavedCopy(ctx context.Context, db *database.Database, link models.Link) (string, error) {
+	page, err := archivePage(ctx, db, link)
+	if err != nil {
+		return "", err
+	}
+	dir, err := savedCopyDir()
+	if err != nil {
+		return "", err
+	}
+	f, err := os.CreateTemp(dir, ".saved-*.html")
+	if err != nil {
+		return "", err
+	}
+	if err := services.WriteArchiveHTML(f, page); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+	path := filepath.Join(dir, services.ArchiveFilename(link.ID, link.Title.String))
+	if err := os.Rename(f.Name(), path); err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+	return path, nil
+}
+
+// savedCopyDir returns the private folder saved copies are written to,
+// lm/saved in the user's cache directory, creating it if needed. Saved pages
+// can hold anything the user reads, so the folder must be a real directory
+// owned by the user and closed to everyone else.
+func savedCopyDir() (string, error) {
+	cache, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Join(cache, "lm", "saved")
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", err
+	}
+	info, err := os.Lstat(dir)
+	if err != nil {
+		return "", err
+	}
+	if !info.IsDir() || !ownedByUser(info) {
+		return "", fmt.Errorf("%s is not a directory owned by you", dir)
+	}
+	if info.Mode().Perm() != 0o700 {
+		if err := os.Chmod(dir, 0o700); err != nil {
+			return "", err
+		}
+	}
+	return dir, nil
+}
+
+// archivePage returns link, with its categories and tags, as a page for
+// services.WriteArchiveHTML.
+func archivePage(ctx context.Context, db *database.Database, link models.Link) (services.ArchivePage, error) {
+	categories, tags, err := db.LinkLabels(ctx, link.ID)
+	if err != nil {
+		return services.ArchivePage{}, err
+	}
+	page := services.ArchivePage{
+		URL:        link.Url,
+		Title:      link.Title.String,
+		Summary:    link.Summary.String,
+		Content:    link.Content.String,
+		Status:     link.Status,
+		Categories: categories,
+		Tags:       tags,
+		Added:      link.CreatedAt,
+	}
+	if link.FetchedAt.Valid {
+		page.Fetched = link.FetchedAt.Time
+	}
+	return page, nil
+}
@@ -0,0 +1,12 @@
+//go:build !unix
+
+package tui
+
+import "os"
+
+// ownedByUser reports whether info describes a file owned by the current
+// user. Outside Unix the user's cache directory is private already, so any
+// directory there counts.
+func ownedByUser(info os.FileInfo) bool {
+	return true
+}
@@ -0,0 +1,134 @@
+package tui
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"mccwk.com/lm/internal/database"
+	"mccwk.com/lm/internal/models"
+	"mccwk.com/lm/internal/services"
+)
+
+// linkQuery is a parsed link search: free-text words plus the operators
+// tag:, site:, source:, and is:. A link must match all of them.
+type linkQuery struct {
+	words    []string // matched in the URL, title, summary, or content, or fuzzily in the title; 'word only exactly
+	tags     []string // tag:go, the link must carry each tag
+	sites    []string // site:github.com, the link's domain or a subdomain of it
+	sources  []string // source:pocket, where the link came from; source:rss matches rss:<feed>
+	statuses []string // is:unread, is:fav, is:archived
+	flagged  bool     // is:flagged
+	expiry   string   // is:expired or is:expiring, a services.ExpiryState
+}
+
+// queryStatuses maps is: values to link statuses.
+var queryStatuses = map[string]string{
+	"unread":   "read_later",
+	"fav":      "remember",
+	"archived": "archived",
+}
+
+// parseLinkQuery splits a search box query into operators and free text.
+// Unknown operators and operators with no value are kept as free text, so
+// URLs still search as typed.
+func parseLinkQuery(query string) linkQuery {
+	var q linkQuery
+	for _, word := range strings.Fields(strings.ToLower(query)) {
+		op, value, ok := strings.Cut(word, ":")
+		if !ok || value == "" {
+			q.words = append(q.words, word)
+			continue
+		}
+		switch op {
+		case "tag":
+			q.tags = append(q.tags, value)
+		case "site":
+			q.sites = append(q.sites, strings.TrimPrefix(value, "www."))
+		case "source":
+			q.sources = append(q.sources, value)
+		case "is":
+			if value == "flagged" {
+				q.flagged = true
+			} else if value == services.ExpiryExpired || value == services.ExpiryExpiring {
+				q.expiry = value
+			} else if status, ok := queryStatuses[value]; ok {
+				q.statuses = append(q.statuses, status)
+			} else {
+				q.words = append(q.words, word)
+			}
+		default:
+			q.words = append(q.words, word)
+		}
+	}
+	return q
+}
+
+// text returns the query's free-text words, for highlighting matches.
+func (q linkQuery) text() string {
+	words := make([]string, len(q.words))
+	for i, w := range q.words {
+		words[i] = strings.TrimPrefix(w, "'")
+	}
+	return strings.Join(words, " ")
+}
+
+// linkMatchesQuery reports whether link matches every part of q: each word
+// (see matchWords; the link's name is its title, or its URL if untitled) and
+// each operator. tags are the link's tag names. score ranks the match among
+// others for the same query.
+func linkMatchesQuery(link models.Link, tags []string, content string, q linkQuery) (score int, ok bool) {
+	for _, status := range q.statuses {
+		if link.Status != status {
+			return 0, false
+		}
+	}
+	if q.flagged && !link.NeedsAttention {
+		return 0, false
+	}
+	if q.expiry != "" && services.ExpiryState(link.ExpiresAt, time.Now()) != q.expiry {
+		return 0, false
+	}
+	for _, site := range q.sites {
+		if link.Domain != site && !strings.HasSuffix(link.Domain, "."+site) {
+			return 0, false
+		}
+	}
+	for _, source := range q.sources {
+		have := strings.ToLower(link.Source)
+		if have != source && !strings.HasPrefix(have, source+":") {
+			return 0, false
+		}
+	}
+	for _, want := range q.tags {
+		found := false
+		for _, tag := range tags {
+			if strings.EqualFold(tag, want) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return 0, false
+		}
+	}
+	name := link.Title.String
+	if name == "" {
+		name = link.Url
+	}
+	return matchWords(q.words, name, link.Url, link.Summary.String, link.SummaryShort.String, content)
+}
+
+// loadLinkTagNames returns the names of every link's tags, keyed by link ID,
+// for tag: searches.
+func loadLinkTagNames(ctx context.Context, db *database.Database) (map[int64][]string, error) {
+	rows, err := db.Queries.ListLinkTagNames(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tags := make(map[int64][]string)
+	for _, r := range rows {
+		tags[r.LinkID] = append(tags[r.LinkID], r.Name)
+	}
+	return tags, nil
+}
@@ -0,0 +1,112 @@
+package tui
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"mccwk.com/lm/internal/database"
+	"mccwk.com/lm/internal/models"
+	"mccwk.com/lm/internal/services"
+)
+
+// summaryRegeneratedMsg reports a summary rewritten on request: the page
+// summary, or with short set, the one-line one. cost is what the call cost
+// in USD, failed or not.
+type summaryRegeneratedMsg struct {
+	short bool
+	text  string
+	cost  float64
+	err   error
+}
+
+var errNoSummarizer = errors.New("summaries need an OpenAI API key")
+
+// regenerateSummaryCmd writes a new summary of linkID's saved content and
+// saves it. The short summary is left as it is; Ctrl+T rewrites that.
+func regenerateSummaryCmd(ctx context.Context, db *database.Database, summarizer *services.Summarizer, linkID int64, title string) tea.Cmd {
+	return func() tea.Msg {
+		if summarizer == nil {
+			return summaryRegeneratedMsg{err: errNoSummarizer}
+		}
+		link, err := db.Queries.GetLink(ctx, linkID)
+		if err != nil {
+			return summaryRegeneratedMsg{err: fmt.Errorf("failed to load link: %w", err)}
+		}
+		if link.Content.String == "" {
+			return summaryRegeneratedMsg{err: errors.New("no content saved to summarize; refetch the link first")}
+		}
+		summary, inTok, outTok, err := summarizer.Summarize(ctx, title, link.Content.String)
+		cost := services.LLMCost(inTok, outTok)
+		db.RecordLLMUsage(ctx, services.LLMModel, database.OpSummarize, inTok, outTok, cost)
+		if err != nil {
+			return summaryRegeneratedMsg{cost: cost, err: err}
+		}
+		err = db.Queries.SetLinkSummary(ctx, models.SetLinkSummaryParams{
+			Summary: sql.NullString{String: summary, Valid: summary != ""},
+			ID:      linkID,
+		})
+		if err != nil {
+			return summaryRegeneratedMsg{cost: cost, err: fmt.Errorf("failed to save summary: %w", err)}
+		}
+		return summaryRegeneratedMsg{text: summary, cost: cost}
+	}
+}
+
+// regenerateShortSummaryCmd writes a new one-line summary of summary for
+// linkID and saves it.
+func regenerateShortSummaryCmd(ctx context.Context, db *database.Database, summarizer *services.Summarizer, linkID int64, title, summary string) tea.Cmd {
+	return func() tea.Msg {
+		if summarizer == nil {
+			return summaryRegeneratedMsg{short: true, err: errNoSummarizer}
+		}
+		short, inTok, outTok, err := saveShortSummary(ctx, db, summarizer, linkID, title, summary)
+		cost := services.LLMCost(inTok, outTok)
+		if err != nil {
+			return summaryRegeneratedMsg{short: true, cost: cost, err: err}
+		}
+		return summaryRegeneratedMsg{short: true, text: short, cost: cost}
+	}
+}
+
+// saveShortSummary asks summarizer for a one-line version of summary,
+// records the call's usage, and stores the line as linkID's short summary.
+// It returns the line and the tokens used.
+func saveShortSummary(ctx context.Context, db *database.Database, summarizer *services.Summarizer, linkID int64, title, summary string) (short string, inputTok, outputTok int, err error) {
+	short, inputTok, outputTok, err = summarizer.ShortSummary(ctx, title, summary)
+	db.RecordLLMUsage(ctx, services.LLMModel, database.OpShortSummary, inputTok, outputTok, services.LLMCost(inputTok, outputTok))
+	if err != nil {
+		return "", inputTok, outputTok, err
+	}
+	err = db.Queries.SetLinkSummaryShort(ctx, models.SetLinkSummaryShortParams{
+		SummaryShort: sql.NullString{String: short, Valid: short != ""},
+		ID:           linkID,
+	})
+	return short, inputTok, outputTok, err
+}
+
+// refreshShortSummary follows linkID's new summary with a new short summary,
+// or clears the old one, as summarizer.RefreshShortSummary decides, and
+// records the call's usage. Failures are logged rather than returned, since
+// the summary itself was saved. It returns the short summary now stored and
+// the tokens used.
+func refreshShortSummary(ctx context.Context, db *database.Database, summarizer *services.Summarizer, linkID int64, title, summary string) (short string, inputTok, outputTok int) {
+	short, inputTok, outputTok, err := summarizer.RefreshShortSummary(ctx, title, summary)
+	db.RecordLLMUsage(ctx, services.LLMModel, database.OpShortSummary, inputTok, outputTok, services.LLMCost(inputTok, outputTok))
+	if err != nil {
+		slog.Warn("short summary failed", "link_id", linkID, "error", err)
+		return "", inputTok, outputTok
+	}
+	err = db.Queries.SetLinkSummaryShort(ctx, models.SetLinkSummaryShortParams{
+		SummaryShort: sql.NullString{String: short, Valid: short != ""},
+		ID:           linkID,
+	})
+	if err != nil {
+		slog.Warn("failed to save short summary", "link_id", linkID, "error", err)
+	}
+	return short, inputTok, outputTok
+}
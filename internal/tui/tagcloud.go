@@ -0,0 +1,186 @@
+package tui
+
+import (
+	"math"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"mccwk.com/lm/internal/models"
+)
+
+// cloudGap is the number of spaces between tags on a tag cloud line.
+const cloudGap = 2
+
+// cloudWeights styles tags by weight, from tags with no links (0) to the
+// most-used ones (4), since a terminal cannot size the text itself.
+var cloudWeights = [5]lipgloss.Style{
+	lipgloss.NewStyle().Foreground(lipgloss.Color("240")),
+	lipgloss.NewStyle().Foreground(lipgloss.Color("250")),
+	lipgloss.NewStyle().Foreground(lipgloss.Color("12")),
+	lipgloss.NewStyle().Foreground(lipgloss.Color("10")).Bold(true),
+	lipgloss.NewStyle().Foreground(lipgloss.Color("11")).Bold(true),
+}
+
+// tagCloud is the Tags tab's overview: every tag laid out as words that flow
+// across the screen, styled by how many links carry them.
+type tagCloud struct {
+	tags     []models.CountLinksByTagRow // nil until loaded
+	cursor   int
+	maxCount int64
+}
+
+// newTagCloud builds a cloud of tags with the cursor on the tag selectedID,
+// or the first tag if it is not there.
+func newTagCloud(tags []models.CountLinksByTagRow, selectedID int64) tagCloud {
+	c := tagCloud{tags: tags}
+	for i, t := range tags {
+		c.maxCount = max(c.maxCount, t.Count)
+		if t.ID == selectedID {
+			c.cursor = i
+		}
+	}
+	return c
+}
+
+// selected returns the tag under the cursor.
+func (c tagCloud) selected() (models.CountLinksByTagRow, bool) {
+	if c.cursor >= len(c.tags) {
+		return models.CountLinksByTagRow{}, false
+	}
+	return c.tags[c.cursor], true
+}
+
+// tagWeight places count on a log scale from 1 to 4 against the most-used
+// tag's count, so a few very large tags do not flatten the rest; unused tags
+// weigh 0.
+func tagWeight(count, maxCount int64) int {
+	if count == 0 || maxCount == 0 {
+		return 0
+	}
+	w := 1 + int(3*math.Log1p(float64(count))/math.Log1p(float64(maxCount)))
+	return min(w, 4)
+}
+
+// layout flows the tags into lines at most width columns wide. It returns
+// the indexes of the tags on each line and the column each tag starts at.
+func (c tagCloud) layout(width int) (rows [][]int, starts []int) {
+	starts = make([]int, len(c.tags))
+	var row []int
+	used := 0
+	for i, t := range c.tags {
+		w := min(lipgloss.Width(t.Name), width)
+		if len(row) > 0 && used+cloudGap+w > width {
+			rows = append(rows, row)
+			row, used = nil, 0
+		}
+		if len(row) > 0 {
+			used += cloudGap
+		}
+		starts[i] = used
+		row = append(row, i)
+		used += w
+	}
+	if len(row) > 0 {
+		rows = append(rows, row)
+	}
+	return rows, starts
+}
+
+// move handles a navigation key: ←/→ step through the tags in order, ↑/↓
+// go to the nearest tag on the line above or below, and g/G jump to the
+// ends. It reports whether the key was a navigation key.
+func (c *tagCloud) move(key string, width int) bool {
+	switch key {
+	case "left", "h":
+		c.cursor = max(c.cursor-1, 0)
+	case "right", "l":
+		c.cursor = max(min(c.cursor+1, len(c.tags)-1), 0)
+	case "g", "home":
+		c.cursor = 0
+	case "G", "end":
+		c.cursor = max(len(c.tags)-1, 0)
+	case "up", "k", "down", "j":
+		rows, starts := c.layout(width)
+		row := rowOf(rows, c.cursor)
+		if key == "up" || key == "k" {
+			row--
+		} else {
+			row++
+		}
+		if row < 0 || row >= len(rows) {
+			return true
+		}
+		best := rows[row][0]
+		for _, i := range rows[row] {
+			if abs(starts[i]-starts[c.cursor]) < abs(starts[best]-starts[c.cursor]) {
+				best = i
+			}
+		}
+		c.cursor = best
+	default:
+		return false
+	}
+	return true
+}
+
+// rowOf returns the line of a layout holding tag i.
+func rowOf(rows [][]int, i int) int {
+	for r, idx := range rows {
+		if i <= idx[len(idx)-1] {
+			return r
+		}
+	}
+	return 0
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// view renders the lines of the cloud that fit in maxRows rows, keeping the
+// cursor's line visible.
+func (c tagCloud) view(width, maxRows int) string {
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("243"))
+
+	rows, _ := c.layout(width)
+	start, end := listWindow(len(rows), rowOf(rows, c.cursor), maxRows, func(int) int { return 1 })
+	above, below := moreIndicators(start, end, len(rows))
+
+	var b strings.Builder
+	if above != "" {
+		b.WriteString(dimStyle.Render(above) + "\n")
+	}
+	for _, idx := range rows[start:end] {
+		words := make([]string, 0, len(idx))
+		for _, i := range idx {
+			t := c.tags[i]
+			style := cloudWeights[tagWeight(t.Count, c.maxCount)]
+			if i == c.cursor {
+				style = style.Reverse(true)
+			}
+			words = append(words, style.Render(ansi.Truncate(t.Name, width, "…")))
+		}
+		b.WriteString(strings.Join(words, strings.Repeat(" ", cloudGap)) + "\n")
+	}
+	if below != "" {
+		b.WriteString(dimStyle.Render(below) + "\n")
+	}
+	return b.String()
+}
+
+// legend renders the weights from fewest links to most.
+func (c tagCloud) legend() string {
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("243"))
+	var b strings.Builder
+	b.WriteString(dimStyle.Render("fewer links "))
+	for _, style := range cloudWeights[1:] {
+		b.WriteString(style.Render("■"))
+	}
+	b.WriteString(dimStyle.Render(" more links"))
+	return b.String()
+}
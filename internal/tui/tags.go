@@ -9,10 +9,10 @@ import (
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
-	"github.com/pkg/browser"
 
 	"mccwk.com/lm/internal/database"
 	"mccwk.com/lm/internal/models"
+	"mccwk.com/lm/internal/services"
 )
 
 type tagsMode int
@@ -20,30 +20,47 @@ type tagsMode int
 const (
 	tagsViewMode tagsMode = iota
 	tagsCreateMode
+	tagsCloudMode
 )
 
 type TagsModel struct {
 	tags         []models.Tag
 	filteredTags []models.Tag
 	cursor       int
+	loading      bool        // a list load is in flight
+	count        countPrefix // pending numeric prefix, e.g. the 5 in 5j
 	db           *database.Database
 	ctx          context.Context
 	mode         tagsMode
 	links        []models.Link
 
+	// Tags often used alongside the selected one, and the one of them the
+	// links shown must also carry (zero ID for none)
+	related []models.ListRelatedTagsRow
+	pair    models.ListRelatedTagsRow
+
 	// Search and focus
 	searchInput textinput.Model
+	cleared     clearedSearch
 	focus       panelFocus
 
 	// Detail viewport for links panel
 	detailViewport viewport.Model
 	viewportReady  bool
 
+	// Awaiting y/n before archiving every link in the selected tag
+	confirmArchive bool
+
 	// Create mode
 	nameInput textinput.Model
 
-	width  int
-	height int
+	// Cloud mode
+	cloud tagCloud
+
+	width      int
+	height     int
+	splitRatio float64 // list panel's share of the width, set by Model
+	browser    string  // command links open with, "" for the system opener; set by Model
 }
 
 func NewTagsModel(db *database.Database) TagsModel {
@@ -65,6 +82,7 @@ func NewTagsModel(db *database.Database) TagsModel {
 		searchInput: searchInput,
 		nameInput:   nameInput,
 		focus:       panelFocusSearch,
+		loading:     true,
 	}
 }
 
@@ -78,11 +96,7 @@ func (m TagsModel) Update(msg tea.Msg) (TagsModel, tea.Cmd) {
 		m.width = msg.Width
 		m.height = msg.Height
 
-		leftWidth := int(float64(m.width) * 0.35)
-		if leftWidth < 30 {
-			leftWidth = 30
-		}
-		rightWidth := m.width - leftWidth - 8
+		_, rightWidth := splitWidths(m.width, m.splitRatio)
 
 		detailHeight := m.height - 12
 		if detailHeight < 5 {
@@ -106,11 +120,25 @@ func (m TagsModel) Update(msg tea.Msg) (TagsModel, tea.Cmd) {
 			return m.handleViewMode(msg)
 		case tagsCreateMode:
 			return m.handleCreateMode(msg)
+		case tagsCloudMode:
+			return m.handleCloudMode(msg)
+		}
+
+	case tagCloudLoadedMsg:
+		var selectedID int64
+		if m.cursor < len(m.filteredTags) {
+			selectedID = m.filteredTags[m.cursor].ID
 		}
+		m.cloud = newTagCloud(msg.tags, selectedID)
+		return m, nil
 
 	case tagsLoadedMsg:
+		id := func(t models.Tag) int64 { return t.ID }
+		selected := selectedID(m.filteredTags, m.cursor, id)
+		m.loading = false
 		m.tags = msg.tags
 		m.filterTags()
+		m.cursor = reselect(m.filteredTags, selected, m.cursor, id)
 		if len(m.filteredTags) > 0 {
 			return m, m.loadTagLinks(m.filteredTags[m.cursor].ID)
 		}
@@ -125,19 +153,38 @@ func (m TagsModel) Update(msg tea.Msg) (TagsModel, tea.Cmd) {
 
 	case tagLinksLoadedMsg:
 		m.links = msg.links
+		m.related = msg.related
+		m.pair = msg.pair
 		m.updateLinksView()
 		return m, nil
+
+	case tagLinksArchivedMsg:
+		var cmds []tea.Cmd
+		if len(m.filteredTags) > 0 && m.cursor < len(m.filteredTags) {
+			cmds = append(cmds, m.loadTagPairLinks(m.filteredTags[m.cursor].ID, m.pair))
+		}
+		cmds = append(cmds, notifyCmd("success", fmt.Sprintf("Archived %d links", msg.count)))
+		return m, tea.Batch(cmds...)
 	}
 
 	return m, nil
 }
 
 func (m TagsModel) handleViewMode(msg tea.KeyMsg) (TagsModel, tea.Cmd) {
-	halfPage := (m.height - 15) / 2
+	halfPage := listRows(m.height, 4) / 2
 	if halfPage < 1 {
 		halfPage = 1
 	}
 
+	// A pending archive confirmation swallows the next key.
+	if m.confirmArchive {
+		m.confirmArchive = false
+		if msg.String() == "y" && len(m.filteredTags) > 0 && m.cursor < len(m.filteredTags) {
+			return m, m.archiveTagLinks(m.filteredTags[m.cursor].ID)
+		}
+		return m, nil
+	}
+
 	// Tab / Shift+Tab cycle focus between search → list → detail.
 	switch msg.String() {
 	case "tab":
@@ -156,25 +203,61 @@ func (m TagsModel) handleViewMode(msg tea.KeyMsg) (TagsModel, tea.Cmd) {
 			m.searchInput.Blur()
 		}
 		return m, nil
+	case "left", "h":
+		// ←/h and →/l move between the list and detail panels; in the
+		// search box they are ordinary input.
+		if m.focus != panelFocusSearch {
+			m.focus = focusLeft(m.focus)
+			return m, nil
+		}
+	case "right", "l":
+		if m.focus != panelFocusSearch {
+			m.focus = focusRight(m.focus)
+			return m, nil
+		}
+	case "<", ">":
+		// < and > narrow and widen the list panel on every tab.
+		if m.focus != panelFocusSearch {
+			return m, resizeSplit(msg.String() == ">")
+		}
+	case ":":
+		if m.focus != panelFocusSearch {
+			return m, openPalette
+		}
 	}
 
 	switch m.focus {
 	case panelFocusList:
+		// Digits build a count prefix applied to the next up/down move.
+		if m.count.push(msg.String()) {
+			return m, nil
+		}
+		n := m.count.take()
 		switch msg.String() {
 		case "up", "k":
 			if m.cursor > 0 {
-				m.cursor--
+				m.cursor = max(m.cursor-n, 0)
 				if len(m.filteredTags) > 0 {
 					return m, m.loadTagLinks(m.filteredTags[m.cursor].ID)
 				}
 			}
 		case "down", "j":
 			if m.cursor < len(m.filteredTags)-1 {
-				m.cursor++
+				m.cursor = min(m.cursor+n, len(m.filteredTags)-1)
 				if len(m.filteredTags) > 0 {
 					return m, m.loadTagLinks(m.filteredTags[m.cursor].ID)
 				}
 			}
+		case "g", "home":
+			if len(m.filteredTags) > 0 {
+				m.cursor = 0
+				return m, m.loadTagLinks(m.filteredTags[m.cursor].ID)
+			}
+		case "G", "end":
+			if len(m.filteredTags) > 0 {
+				m.cursor = len(m.filteredTags) - 1
+				return m, m.loadTagLinks(m.filteredTags[m.cursor].ID)
+			}
 		case "pgup", "ctrl+u":
 			m.cursor -= halfPage
 			if m.cursor < 0 {
@@ -191,11 +274,16 @@ func (m TagsModel) handleViewMode(msg tea.KeyMsg) (TagsModel, tea.Cmd) {
 			if len(m.filteredTags) > 0 {
 				return m, m.loadTagLinks(m.filteredTags[m.cursor].ID)
 			}
-		case "ctrl+a":
-			m.mode = tagsCreateMode
-			m.focus = panelFocusSearch
-			m.searchInput.Blur()
-			m.nameInput.Focus()
+		case "ctrl+a", "n":
+			m.startCreate()
+		case "c":
+			return m.openCloud()
+		case "+":
+			return m.cyclePair()
+		case "A":
+			if len(m.links) > 0 {
+				m.confirmArchive = true
+			}
 		case "d":
 			if len(m.filteredTags) > 0 && m.cursor < len(m.filteredTags) {
 				return m, m.deleteTag(m.filteredTags[m.cursor].ID)
@@ -218,6 +306,14 @@ func (m TagsModel) handleViewMode(msg tea.KeyMsg) (TagsModel, tea.Cmd) {
 				m.detailViewport, cmd = m.detailViewport.Update(msg)
 				return m, cmd
 			}
+		case "g", "home":
+			if m.viewportReady {
+				m.detailViewport.GotoTop()
+			}
+		case "G", "end":
+			if m.viewportReady {
+				m.detailViewport.GotoBottom()
+			}
 		case "up", "k":
 			if m.viewportReady {
 				m.detailViewport.ScrollUp(1)
@@ -226,6 +322,17 @@ func (m TagsModel) handleViewMode(msg tea.KeyMsg) (TagsModel, tea.Cmd) {
 			if m.viewportReady {
 				m.detailViewport.ScrollDown(1)
 			}
+		case "A":
+			if len(m.links) > 0 {
+				m.confirmArchive = true
+			}
+		case "ctrl+a":
+			m.startCreate()
+			return m, nil
+		case "c":
+			return m.openCloud()
+		case "+":
+			return m.cyclePair()
 		case "ctrl+o":
 			if len(m.links) > 0 {
 				return m, m.openLinks()
@@ -273,19 +380,23 @@ func (m TagsModel) handleViewMode(msg tea.KeyMsg) (TagsModel, tea.Cmd) {
 			}
 			return m, nil
 		case "ctrl+a":
-			m.mode = tagsCreateMode
-			m.searchInput.Blur()
-			m.nameInput.Focus()
+			m.startCreate()
 			return m, nil
+		case "n":
+			// With nothing to search, n starts a new tag as in the list.
+			if len(m.tags) == 0 && m.searchInput.Value() == "" {
+				m.startCreate()
+				return m, nil
+			}
 		case "ctrl+o":
 			if len(m.links) > 0 {
 				return m, m.openLinks()
 			}
 			return m, nil
 		case "esc":
-			m.searchInput.SetValue("")
+			notice := m.cleared.esc(&m.searchInput)
 			m.filterTags()
-			return m, nil
+			return m, notice
 		}
 		// All other keys feed the search input
 		var cmd tea.Cmd
@@ -302,6 +413,69 @@ func (m TagsModel) handleViewMode(msg tea.KeyMsg) (TagsModel, tea.Cmd) {
 	}
 }
 
+// startCreate opens the new-tag form.
+func (m *TagsModel) startCreate() {
+	m.mode = tagsCreateMode
+	m.focus = panelFocusSearch
+	m.searchInput.Blur()
+	m.nameInput.Focus()
+}
+
+// cyclePair narrows the links shown to those also carrying the next related
+// tag, and after the last one shows all of the tag's links again.
+func (m TagsModel) cyclePair() (TagsModel, tea.Cmd) {
+	if len(m.filteredTags) == 0 || m.cursor >= len(m.filteredTags) || len(m.related) == 0 {
+		return m, nil
+	}
+	next := models.ListRelatedTagsRow{}
+	if m.pair.ID == 0 {
+		next = m.related[0]
+	} else {
+		for i, r := range m.related {
+			if r.ID == m.pair.ID && i+1 < len(m.related) {
+				next = m.related[i+1]
+			}
+		}
+	}
+	return m, m.loadTagPairLinks(m.filteredTags[m.cursor].ID, next)
+}
+
+// openCloud switches to the tag cloud, loading fresh link counts.
+func (m TagsModel) openCloud() (TagsModel, tea.Cmd) {
+	m.mode = tagsCloudMode
+	m.cloud = tagCloud{}
+	return m, m.loadCloud()
+}
+
+// handleCloudMode moves through the tag cloud. Enter goes back to the list
+// with the chosen tag selected and its links showing.
+func (m TagsModel) handleCloudMode(msg tea.KeyMsg) (TagsModel, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "c":
+		m.mode = tagsViewMode
+		return m, nil
+	case "enter":
+		m.mode = tagsViewMode
+		tag, ok := m.cloud.selected()
+		if !ok {
+			return m, nil
+		}
+		m.searchInput.SetValue("")
+		m.filterTags()
+		for i, t := range m.filteredTags {
+			if t.ID == tag.ID {
+				m.cursor = i
+				m.focus = panelFocusList
+				m.searchInput.Blur()
+				return m, m.loadTagLinks(tag.ID)
+			}
+		}
+		return m, nil
+	}
+	m.cloud.move(msg.String(), m.cloudWidth())
+	return m, nil
+}
+
 func (m TagsModel) handleCreateMode(msg tea.KeyMsg) (TagsModel, tea.Cmd) {
 	var cmd tea.Cmd
 
@@ -324,7 +498,7 @@ func (m TagsModel) handleCreateMode(msg tea.KeyMsg) (TagsModel, tea.Cmd) {
 }
 
 func (m *TagsModel) filterTags() {
-	query := strings.ToLower(m.searchInput.Value())
+	query := m.searchInput.Value()
 	if query == "" {
 		m.filteredTags = m.tags
 		if m.cursor >= len(m.filteredTags) {
@@ -333,12 +507,9 @@ func (m *TagsModel) filterTags() {
 		return
 	}
 
-	m.filteredTags = []models.Tag{}
-	for _, tag := range m.tags {
-		if strings.Contains(strings.ToLower(tag.Name), query) {
-			m.filteredTags = append(m.filteredTags, tag)
-		}
-	}
+	m.filteredTags = filterRanked(m.tags, query, func(tag models.Tag) (string, []string) {
+		return tag.Name, nil
+	})
 	if m.cursor >= len(m.filteredTags) {
 		m.cursor = 0
 	}
@@ -353,7 +524,24 @@ func (m *TagsModel) updateLinksView() {
 	urlStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("12"))
 
 	var content strings.Builder
-	if len(m.links) == 0 {
+	if len(m.related) > 0 {
+		pairStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("10")).Bold(true)
+		names := make([]string, 0, len(m.related))
+		for _, r := range m.related {
+			name := fmt.Sprintf("%s (%d)", r.Name, r.Count)
+			if r.ID == m.pair.ID {
+				name = pairStyle.Render(name)
+			}
+			names = append(names, name)
+		}
+		// lipgloss wraps around the highlight's escape codes, which
+		// wrapText would count as text.
+		related := dimStyle.Render("Often tagged with: ") + strings.Join(names, " · ")
+		content.WriteString(lipgloss.NewStyle().Width(m.detailViewport.Width).Render(related) + "\n\n")
+	}
+	if len(m.links) == 0 && m.pair.ID != 0 {
+		content.WriteString(dimStyle.Render("No links with both tags."))
+	} else if len(m.links) == 0 {
 		content.WriteString(dimStyle.Render("No links with this tag."))
 	} else {
 		for _, link := range m.links {
@@ -363,8 +551,7 @@ func (m *TagsModel) updateLinksView() {
 			}
 			content.WriteString(fmt.Sprintf("• %s\n", title))
 			content.WriteString(urlStyle.Render("  "+link.Url) + "\n")
-			if link.Summary.Valid && link.Summary.String != "" {
-				summary := link.Summary.String
+			if summary := listSummary(link); summary != "" {
 				wrapped := wrapText(summary, m.detailViewport.Width-4)
 				content.WriteString(dimStyle.Render("  "+wrapped) + "\n")
 			}
@@ -375,12 +562,35 @@ func (m *TagsModel) updateLinksView() {
 	m.detailViewport.GotoTop()
 }
 
+// Breadcrumb describes what the tab is showing, for the context line under
+// the tab bar.
+func (m TagsModel) Breadcrumb() []string {
+	if m.mode == tagsCreateMode {
+		return []string{"New tag"}
+	}
+	if m.mode == tagsCloudMode {
+		if tag, ok := m.cloud.selected(); ok {
+			return []string{"Tag cloud", tag.Name, linkCount(int(tag.Count))}
+		}
+		return []string{"Tag cloud"}
+	}
+	if len(m.filteredTags) == 0 || m.cursor >= len(m.filteredTags) {
+		return []string{"No tags"}
+	}
+	if m.pair.ID != 0 {
+		return []string{m.filteredTags[m.cursor].Name, "+ " + m.pair.Name, linkCount(len(m.links))}
+	}
+	return []string{m.filteredTags[m.cursor].Name, linkCount(len(m.links))}
+}
+
 func (m TagsModel) View() string {
 	switch m.mode {
 	case tagsViewMode:
 		return m.viewTags()
 	case tagsCreateMode:
 		return m.viewCreateTag()
+	case tagsCloudMode:
+		return m.viewCloud()
 	}
 	return ""
 }
@@ -390,11 +600,7 @@ func (m TagsModel) viewTags() string {
 		return "Loading..."
 	}
 
-	leftWidth := int(float64(m.width) * 0.35)
-	if leftWidth < 30 {
-		leftWidth = 30
-	}
-	rightWidth := m.width - leftWidth - 8
+	leftWidth, rightWidth := splitWidths(m.width, m.splitRatio)
 
 	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("6"))
 	selectedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("10")).Bold(true)
@@ -421,21 +627,17 @@ func (m TagsModel) viewTags() string {
 	if len(m.filteredTags) == 0 {
 		if m.searchInput.Value() != "" {
 			leftContent.WriteString(dimStyle.Render("No tags match your search.\n"))
+		} else if m.loading {
+			leftContent.WriteString(dimStyle.Render("Loading...\n"))
 		} else {
-			leftContent.WriteString(dimStyle.Render("No tags yet. Press Ctrl+A to create one!\n"))
+			leftContent.WriteString(dimStyle.Render("No tags yet. Press Ctrl+A or n to create one!\n"))
 		}
 	} else {
-		maxItems := m.height - 15
-		if maxItems < 3 {
-			maxItems = 3
-		}
-		startIdx := 0
-		endIdx := len(m.filteredTags)
-		if m.cursor >= maxItems {
-			startIdx = m.cursor - maxItems + 1
-		}
-		if endIdx > startIdx+maxItems {
-			endIdx = startIdx + maxItems
+		maxItems := listRows(m.height, 4)
+		startIdx, endIdx := listWindow(len(m.filteredTags), m.cursor, maxItems, func(int) int { return 1 })
+		above, below := moreIndicators(startIdx, endIdx, len(m.filteredTags))
+		if above != "" {
+			leftContent.WriteString(dimStyle.Render(above) + "\n")
 		}
 
 		for i := startIdx; i < endIdx; i++ {
@@ -451,7 +653,10 @@ func (m TagsModel) viewTags() string {
 				leftContent.WriteString(line + "\n")
 			}
 		}
-		if len(m.filteredTags) > maxItems {
+		if below != "" {
+			leftContent.WriteString(dimStyle.Render(below) + "\n")
+		}
+		if endIdx-startIdx < len(m.filteredTags) {
 			leftContent.WriteString("\n" + dimStyle.Render(fmt.Sprintf("  [%d/%d tags]", m.cursor+1, len(m.filteredTags))))
 		}
 	}
@@ -472,7 +677,11 @@ func (m TagsModel) viewTags() string {
 	var rightContent string
 	if len(m.filteredTags) > 0 && m.cursor < len(m.filteredTags) {
 		tag := m.filteredTags[m.cursor]
-		header := titleStyle.Render("Links for: "+tag.Name) + "\n\n"
+		name := tag.Name
+		if m.pair.ID != 0 {
+			name += " + " + m.pair.Name
+		}
+		header := titleStyle.Render("Links for: "+name) + "\n\n"
 
 		if m.viewportReady {
 			rightContent = header + m.detailViewport.View()
@@ -495,17 +704,71 @@ func (m TagsModel) viewTags() string {
 	var helpMsg string
 	switch m.focus {
 	case panelFocusList:
-		helpMsg = "Tab: detail • ↑/↓/j/k: navigate • PgUp/PgDn/Ctrl+U/D: jump • Ctrl+A: new tag • d: delete • Ctrl+O: open links • Esc: search"
+		helpMsg = "Tab: detail • ↑/↓/j/k: navigate • PgUp/PgDn/Ctrl+U/D: jump • g/G: top/bottom • c: cloud • +: also tagged • Ctrl+A/n: new tag • d: delete • A: archive all • Ctrl+O: open links • Esc: search"
 	case panelFocusDetail:
-		helpMsg = "Tab: search • ↑/↓/j/k/PgUp/PgDn: scroll • Ctrl+O: open links • Esc: search"
+		helpMsg = "Tab: search • ↑/↓/j/k/PgUp/PgDn: scroll • g/G: top/bottom • c: cloud • +: also tagged • Ctrl+A: new tag • A: archive all • Ctrl+O: open links • Esc: search"
 	default:
 		helpMsg = "type to search • Tab: list • ↑/↓: navigate • Ctrl+A: new tag • Ctrl+O: open links • Esc: clear"
 	}
+	if m.confirmArchive && len(m.filteredTags) > 0 && m.cursor < len(m.filteredTags) {
+		helpStyle = helpStyle.Foreground(lipgloss.Color("11")).Bold(true)
+		name := m.filteredTags[m.cursor].Name
+		if m.pair.ID != 0 {
+			name += " + " + m.pair.Name
+		}
+		helpMsg = fmt.Sprintf("Archive all %d links tagged %q? y: confirm • any other key: cancel", len(m.links), name)
+	}
 	helpText := "\n" + helpStyle.Render(helpMsg)
 
 	return mainContent + helpText
 }
 
+// cloudWidth is the width of the tag cloud panel's content.
+func (m TagsModel) cloudWidth() int {
+	return m.width - 6
+}
+
+func (m TagsModel) viewCloud() string {
+	if m.width == 0 {
+		return "Loading..."
+	}
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("6"))
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("243"))
+	panelStyle := lipgloss.NewStyle().
+		Width(m.width - 4).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("12")).
+		Padding(1)
+
+	var content strings.Builder
+	content.WriteString(titleStyle.Render("Tag cloud"))
+	if m.cloud.tags != nil {
+		content.WriteString(dimStyle.Render(fmt.Sprintf("  %d tags", len(m.cloud.tags))))
+	}
+	content.WriteString("\n\n")
+
+	switch {
+	case m.cloud.tags == nil:
+		content.WriteString(dimStyle.Render("Loading...\n"))
+	case len(m.cloud.tags) == 0:
+		content.WriteString(dimStyle.Render("No tags yet. Press Esc, then Ctrl+A to create one!\n"))
+	default:
+		// The title takes two rows above the cloud and the legend two below.
+		content.WriteString(m.cloud.view(m.cloudWidth(), listRows(m.height, 2)))
+		legend := m.cloud.legend()
+		if tag, ok := m.cloud.selected(); ok {
+			legend += dimStyle.Render(fmt.Sprintf("   •   %s: %s", tag.Name, linkCount(int(tag.Count))))
+		}
+		content.WriteString("\n" + legend)
+	}
+
+	helpStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+	helpText := "\n" + helpStyle.Render("←/→/h/l: previous/next • ↑/↓/k/j: line above/below • g/G: first/last • Enter: show links • Esc/c: back to list")
+
+	return panelStyle.Render(content.String()) + helpText
+}
+
 func (m TagsModel) viewCreateTag() string {
 	titleStyle := lipgloss.NewStyle().
 		Bold(true).
@@ -540,13 +803,49 @@ func (m TagsModel) loadTags() tea.Cmd {
 	}
 }
 
+// loadCloud counts the links carrying each tag, for the tag cloud.
+func (m TagsModel) loadCloud() tea.Cmd {
+	return func() tea.Msg {
+		tags, err := m.db.Queries.CountLinksByTag(m.ctx)
+		if err != nil {
+			return errMsg{err: err}
+		}
+		return tagCloudLoadedMsg{tags: tags}
+	}
+}
+
 func (m TagsModel) loadTagLinks(tagID int64) tea.Cmd {
+	return m.loadTagPairLinks(tagID, models.ListRelatedTagsRow{})
+}
+
+// relatedTagsLimit is how many related tags the detail panel lists.
+const relatedTagsLimit = 8
+
+// loadTagPairLinks loads the tag's links that also carry pair, or all of
+// them for a zero pair, along with the tags most often used with it.
+func (m TagsModel) loadTagPairLinks(tagID int64, pair models.ListRelatedTagsRow) tea.Cmd {
 	return func() tea.Msg {
-		links, err := m.db.Queries.GetLinksForTag(m.ctx, tagID)
+		var links []models.Link
+		var err error
+		if pair.ID == 0 {
+			links, err = m.db.Queries.GetLinksForTag(m.ctx, tagID)
+		} else {
+			links, err = m.db.Queries.GetLinksForTagPair(m.ctx, models.GetLinksForTagPairParams{
+				TagID:      tagID,
+				OtherTagID: pair.ID,
+			})
+		}
+		if err != nil {
+			return errMsg{err: err}
+		}
+		related, err := m.db.Queries.ListRelatedTags(m.ctx, models.ListRelatedTagsParams{
+			TagID: tagID,
+			Limit: relatedTagsLimit,
+		})
 		if err != nil {
 			return errMsg{err: err}
 		}
-		return tagLinksLoadedMsg{links: links}
+		return tagLinksLoadedMsg{links: links, related: related, pair: pair}
 	}
 }
 
@@ -563,9 +862,38 @@ func (m TagsModel) createTag(name string) tea.Cmd {
 func (m TagsModel) openLinks() tea.Cmd {
 	return func() tea.Msg {
 		for _, link := range m.links {
-			_ = browser.OpenURL(link.Url)
+			if services.OpenURL(m.browser, link.Url) == nil {
+				m.db.RecordOpen(m.ctx, link.ID)
+			}
+		}
+		return linksVisitedMsg{links: m.links}
+	}
+}
+
+// archiveTagLinks marks every link in the tag as archived, or with a related
+// tag chosen with +, just the links shown.
+func (m TagsModel) archiveTagLinks(tagID int64) tea.Cmd {
+	if m.pair.ID != 0 {
+		links := m.links
+		return func() tea.Msg {
+			for _, link := range links {
+				err := m.db.Queries.UpdateLinkStatus(m.ctx, models.UpdateLinkStatusParams{Status: "archived", ID: link.ID})
+				if err != nil {
+					return errMsg{err: err}
+				}
+			}
+			return tagLinksArchivedMsg{count: int64(len(links))}
 		}
-		return nil
+	}
+	return func() tea.Msg {
+		n, err := m.db.Queries.UpdateLinksStatusForTag(m.ctx, models.UpdateLinksStatusForTagParams{
+			Status: "archived",
+			TagID:  tagID,
+		})
+		if err != nil {
+			return errMsg{err: err}
+		}
+		return tagLinksArchivedMsg{count: n}
 	}
 }
 
@@ -589,6 +917,16 @@ type tagsLoadedMsg struct {
 
 type tagCreatedMsg struct{}
 
+type tagCloudLoadedMsg struct {
+	tags []models.CountLinksByTagRow
+}
+
 type tagLinksLoadedMsg struct {
-	links []models.Link
+	links   []models.Link
+	related []models.ListRelatedTagsRow
+	pair    models.ListRelatedTagsRow
+}
+
+type tagLinksArchivedMsg struct {
+	count int64
 }
@@ -10,7 +10,6 @@ import (
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
-	"github.com/pkg/browser"
 
 	"mccwk.com/lm/internal/database"
 	"mccwk.com/lm/internal/models"
@@ -29,19 +28,31 @@ type TasksModel struct {
 	tasks         []models.Task
 	filteredTasks []models.Task
 	cursor        int
+	loading       bool        // a list load is in flight
+	count         countPrefix // pending numeric prefix, e.g. the 5 in 5j
 	db            *database.Database
 	ctx           context.Context
 	fetcher       *services.Fetcher
 	extractor     *services.Extractor
 	summarizer    *services.Summarizer
+	statusRule    services.StatusRule
+	categoryRules services.CategoryRules
+	linkDefaults  services.LinkDefaults
+	tagger        *localTagger
 	links         []models.Link
+	linksTaskID   int64 // task the links belong to
+	linkCursor    int   // selected link in the detail panel
 	showLinks     bool
 
+	// Links opened per task, keyed by task ID
+	progress map[int64]models.CountTaskLinksOpenedRow
+
 	// Mode management
 	mode tasksMode
 
 	// Search and focus
 	searchInput textinput.Model
+	cleared     clearedSearch
 	focus       panelFocus
 
 	// Create task inputs
@@ -56,8 +67,10 @@ type TasksModel struct {
 	detailViewport viewport.Model
 	viewportReady  bool
 
-	width  int
-	height int
+	width      int
+	height     int
+	splitRatio float64 // list panel's share of the width, set by Model
+	browser    string  // command links open with, "" for the system opener; set by Model
 }
 
 func NewTasksModel(tasks []models.Task, db *database.Database) TasksModel {
@@ -87,11 +100,16 @@ func NewTasksModel(tasks []models.Task, db *database.Database) TasksModel {
 		descInput:     descInput,
 		ctx:           context.Background(),
 		focus:         panelFocusSearch,
+		loading:       true,
 	}
 }
 
+func (m TasksModel) Init() tea.Cmd {
+	return m.loadTasks()
+}
+
 func (m *TasksModel) filterTasks() {
-	query := strings.ToLower(m.searchInput.Value())
+	query := m.searchInput.Value()
 	if query == "" {
 		m.filteredTasks = m.tasks
 		if m.cursor >= len(m.filteredTasks) {
@@ -99,13 +117,9 @@ func (m *TasksModel) filterTasks() {
 		}
 		return
 	}
-	m.filteredTasks = []models.Task{}
-	for _, t := range m.tasks {
-		if strings.Contains(strings.ToLower(t.Name), query) ||
-			(t.Description.Valid && strings.Contains(strings.ToLower(t.Description.String), query)) {
-			m.filteredTasks = append(m.filteredTasks, t)
-		}
-	}
+	m.filteredTasks = filterRanked(m.tasks, query, func(t models.Task) (string, []string) {
+		return t.Name, []string{t.Description.String}
+	})
 	if m.cursor >= len(m.filteredTasks) {
 		m.cursor = 0
 	}
@@ -117,6 +131,12 @@ func (m *TasksModel) SetServices(fetcher *services.Fetcher, extractor *services.
 	m.summarizer = summarizer
 }
 
+// HasUnsavedChanges reports whether the embedded add-link form has edits
+// that quitting would lose.
+func (m TasksModel) HasUnsavedChanges() bool {
+	return m.mode == tasksAddLinkMode && m.addLinkModel.HasUnsavedChanges()
+}
+
 func (m TasksModel) Update(msg tea.Msg) (TasksModel, tea.Cmd) {
 	var cmd tea.Cmd
 
@@ -126,11 +146,7 @@ func (m TasksModel) Update(msg tea.Msg) (TasksModel, tea.Cmd) {
 		m.height = msg.Height
 
 		// Calculate responsive widths for split view
-		leftWidth := int(float64(m.width) * 0.35)
-		if leftWidth < 30 {
-			leftWidth = 30
-		}
-		rightWidth := m.width - leftWidth - 8
+		_, rightWidth := splitWidths(m.width, m.splitRatio)
 
 		// Calculate height for detail viewport
 		detailHeight := m.height - 12
@@ -194,13 +210,34 @@ func (m TasksModel) Update(msg tea.Msg) (TasksModel, tea.Cmd) {
 		return m, nil
 
 	case taskLinksLoadedMsg:
+		if msg.taskID != m.linksTaskID {
+			m.linkCursor = 0
+			m.detailViewport.GotoTop()
+		}
 		m.links = msg.links
+		m.linksTaskID = msg.taskID
+		m.linkCursor = max(min(m.linkCursor, len(m.links)-1), 0)
 		m.showLinks = true
 		return m, nil
 
+	case taskLinksReorderFailedMsg:
+		return m, tea.Batch(
+			m.loadTaskLinks(msg.taskID),
+			notifyCmd("error", "Reorder failed: "+msg.err.Error()),
+		)
+
+	case taskProgressLoadedMsg:
+		m.progress = msg.progress
+		return m, nil
+
 	case tasksLoadedMsg:
+		id := func(t models.Task) int64 { return t.ID }
+		selected := selectedID(m.filteredTasks, m.cursor, id)
+		m.loading = false
 		m.tasks = msg.tasks
+		m.progress = msg.progress
 		m.filterTasks()
+		m.cursor = reselect(m.filteredTasks, selected, m.cursor, id)
 		if len(m.filteredTasks) > 0 && m.cursor < len(m.filteredTasks) {
 			return m, m.loadTaskLinks(m.filteredTasks[m.cursor].ID)
 		}
@@ -227,7 +264,7 @@ func (m TasksModel) Update(msg tea.Msg) (TasksModel, tea.Cmd) {
 }
 
 func (m TasksModel) handleViewMode(msg tea.KeyMsg) (TasksModel, tea.Cmd) {
-	halfPage := (m.height - 15) / 2
+	halfPage := listRows(m.height, 4) / 2
 	if halfPage < 1 {
 		halfPage = 1
 	}
@@ -250,25 +287,63 @@ func (m TasksModel) handleViewMode(msg tea.KeyMsg) (TasksModel, tea.Cmd) {
 			m.searchInput.Blur()
 		}
 		return m, nil
+	case "left", "h":
+		// ←/h and →/l move between the list and detail panels; in the
+		// search box they are ordinary input.
+		if m.focus != panelFocusSearch {
+			m.focus = focusLeft(m.focus)
+			return m, nil
+		}
+	case "right", "l":
+		if m.focus != panelFocusSearch {
+			m.focus = focusRight(m.focus)
+			return m, nil
+		}
+	case "<", ">":
+		// < and > narrow and widen the list panel on every tab.
+		if m.focus != panelFocusSearch {
+			return m, resizeSplit(msg.String() == ">")
+		}
+	case ":":
+		if m.focus != panelFocusSearch {
+			return m, openPalette
+		}
 	}
 
 	switch m.focus {
 	case panelFocusList:
+		// Digits build a count prefix applied to the next up/down move.
+		if m.count.push(msg.String()) {
+			return m, nil
+		}
+		n := m.count.take()
 		switch msg.String() {
 		case "up", "k":
 			if m.cursor > 0 {
-				m.cursor--
+				m.cursor = max(m.cursor-n, 0)
 				if len(m.filteredTasks) > 0 {
 					return m, m.loadTaskLinks(m.filteredTasks[m.cursor].ID)
 				}
 			}
 		case "down", "j":
 			if m.cursor < len(m.filteredTasks)-1 {
-				m.cursor++
+				m.cursor = min(m.cursor+n, len(m.filteredTasks)-1)
 				if len(m.filteredTasks) > 0 {
 					return m, m.loadTaskLinks(m.filteredTasks[m.cursor].ID)
 				}
 			}
+		case "g", "home":
+			if len(m.filteredTasks) > 0 {
+				m.cursor = 0
+				m.detailViewport.GotoTop()
+				return m, m.loadTaskLinks(m.filteredTasks[m.cursor].ID)
+			}
+		case "G", "end":
+			if len(m.filteredTasks) > 0 {
+				m.cursor = len(m.filteredTasks) - 1
+				m.detailViewport.GotoTop()
+				return m, m.loadTaskLinks(m.filteredTasks[m.cursor].ID)
+			}
 		case "pgup", "ctrl+u":
 			if m.cursor-halfPage >= 0 {
 				m.cursor -= halfPage
@@ -287,13 +362,8 @@ func (m TasksModel) handleViewMode(msg tea.KeyMsg) (TasksModel, tea.Cmd) {
 			if len(m.filteredTasks) > 0 {
 				return m, m.loadTaskLinks(m.filteredTasks[m.cursor].ID)
 			}
-		case "ctrl+a":
-			m.mode = tasksCreateMode
-			m.createFocus = 0
-			m.focus = panelFocusSearch
-			m.searchInput.Blur()
-			m.nameInput.Focus()
-			m.descInput.Blur()
+		case "ctrl+a", "n":
+			m.startCreate()
 		case "space":
 			if len(m.filteredTasks) > 0 && m.cursor < len(m.filteredTasks) {
 				task := m.filteredTasks[m.cursor]
@@ -317,23 +387,48 @@ func (m TasksModel) handleViewMode(msg tea.KeyMsg) (TasksModel, tea.Cmd) {
 				m.detailViewport, cmd = m.detailViewport.Update(msg)
 				return m, cmd
 			}
+		case "g", "home":
+			m.linkCursor = 0
+			if m.viewportReady {
+				m.detailViewport.GotoTop()
+			}
+		case "G", "end":
+			m.linkCursor = max(len(m.links)-1, 0)
+			if m.viewportReady {
+				m.detailViewport.GotoBottom()
+			}
 		case "up", "k":
-			if m.viewportReady && m.showLinks {
-				m.detailViewport.ScrollUp(1)
+			if m.linkCursor > 0 {
+				m.linkCursor--
+				m.scrollToLink()
 			}
 		case "down", "j":
-			if m.viewportReady && m.showLinks {
-				m.detailViewport.ScrollDown(1)
+			if m.linkCursor < len(m.links)-1 {
+				m.linkCursor++
+				m.scrollToLink()
 			}
+		case "K":
+			return m, m.moveLink(-1)
+		case "J":
+			return m, m.moveLink(1)
 		case "ctrl+a":
-			if len(m.filteredTasks) > 0 && m.cursor < len(m.filteredTasks) {
+			// With no task to add a link to, create one instead.
+			if len(m.filteredTasks) == 0 {
+				m.startCreate()
+				return m, nil
+			}
+			if m.cursor < len(m.filteredTasks) {
 				m.mode = tasksAddLinkMode
 				taskID := m.filteredTasks[m.cursor].ID
 				m.addLinkModel = NewAddLinkModelForTask(&taskID)
+				m.addLinkModel.statusRule = m.statusRule
+				m.addLinkModel.categoryRules = m.categoryRules
+				m.addLinkModel.defaults = m.linkDefaults
+				m.addLinkModel.tagger = m.tagger
 				m.addLinkModel.inModal = true
-				return m, func() tea.Msg {
+				return m, tea.Batch(func() tea.Msg {
 					return tea.WindowSizeMsg{Width: m.width, Height: m.height}
-				}
+				}, loadTaxonomy(m.db))
 			}
 		case "ctrl+o":
 			if m.showLinks && len(m.links) > 0 {
@@ -384,21 +479,23 @@ func (m TasksModel) handleViewMode(msg tea.KeyMsg) (TasksModel, tea.Cmd) {
 			}
 			return m, nil
 		case "ctrl+a":
-			m.mode = tasksCreateMode
-			m.createFocus = 0
-			m.searchInput.Blur()
-			m.nameInput.Focus()
-			m.descInput.Blur()
+			m.startCreate()
 			return m, nil
+		case "n":
+			// With nothing to search, n starts a new task as in the list.
+			if len(m.tasks) == 0 && m.searchInput.Value() == "" {
+				m.startCreate()
+				return m, nil
+			}
 		case "ctrl+o", "enter":
 			if m.showLinks && len(m.links) > 0 {
 				return m, m.openLinks()
 			}
 			return m, nil
 		case "esc":
-			m.searchInput.SetValue("")
+			notice := m.cleared.esc(&m.searchInput)
 			m.filterTasks()
-			return m, nil
+			return m, notice
 		}
 		// All other keys feed the search input
 		var cmd tea.Cmd
@@ -415,6 +512,16 @@ func (m TasksModel) handleViewMode(msg tea.KeyMsg) (TasksModel, tea.Cmd) {
 	}
 }
 
+// startCreate opens the new-task form.
+func (m *TasksModel) startCreate() {
+	m.mode = tasksCreateMode
+	m.createFocus = 0
+	m.focus = panelFocusSearch
+	m.searchInput.Blur()
+	m.nameInput.Focus()
+	m.descInput.Blur()
+}
+
 func (m TasksModel) handleCreateMode(msg tea.KeyMsg) (TasksModel, tea.Cmd) {
 	switch msg.String() {
 	case "esc":
@@ -451,6 +558,22 @@ func (m TasksModel) handleCreateMode(msg tea.KeyMsg) (TasksModel, tea.Cmd) {
 	return m, cmd
 }
 
+// Breadcrumb describes what the tab is showing, for the context line under
+// the tab bar.
+func (m TasksModel) Breadcrumb() []string {
+	if m.mode == tasksCreateMode {
+		return []string{"New task"}
+	}
+	if len(m.filteredTasks) == 0 || m.cursor >= len(m.filteredTasks) {
+		return []string{"No tasks"}
+	}
+	crumbs := []string{m.filteredTasks[m.cursor].Name}
+	if m.mode == tasksAddLinkMode {
+		return append(crumbs, "Add link")
+	}
+	return append(crumbs, linkCount(len(m.links)))
+}
+
 func (m TasksModel) View() string {
 	switch m.mode {
 	case tasksViewMode:
@@ -502,11 +625,7 @@ func (m TasksModel) viewTasks() string {
 	}
 
 	// Calculate responsive widths
-	leftWidth := int(float64(m.width) * 0.35)
-	if leftWidth < 30 {
-		leftWidth = 30
-	}
-	rightWidth := m.width - leftWidth - 8
+	leftWidth, rightWidth := splitWidths(m.width, m.splitRatio)
 
 	titleStyle := lipgloss.NewStyle().
 		Bold(true).
@@ -540,21 +659,28 @@ func (m TasksModel) viewTasks() string {
 	if len(m.filteredTasks) == 0 {
 		if m.searchInput.Value() != "" {
 			leftContent.WriteString(dimStyle.Render("No tasks match your search.\n"))
+		} else if m.loading {
+			leftContent.WriteString(dimStyle.Render("Loading...\n"))
 		} else {
-			leftContent.WriteString(dimStyle.Render("No tasks yet. Press Ctrl+A to create one!\n"))
+			leftContent.WriteString(dimStyle.Render("No tasks yet. Press Ctrl+A or n to create one!\n"))
 		}
 	} else {
-		maxTasks := m.height - 15
-		if maxTasks < 3 {
-			maxTasks = 3
-		}
-		startIdx := 0
-		endIdx := len(m.filteredTasks)
-		if m.cursor >= maxTasks {
-			startIdx = m.cursor - maxTasks + 1
-		}
-		if endIdx > startIdx+maxTasks {
-			endIdx = startIdx + maxTasks
+		maxTasks := listRows(m.height, 4)
+		startIdx, endIdx := listWindow(len(m.filteredTasks), m.cursor, maxTasks, func(i int) int {
+			// A task takes a line for its name, plus its description and
+			// reading progress when it has them.
+			task, n := m.filteredTasks[i], 1
+			if task.Description.Valid && task.Description.String != "" {
+				n++
+			}
+			if p, ok := m.progress[task.ID]; ok && p.Total > 0 {
+				n++
+			}
+			return n
+		})
+		above, below := moreIndicators(startIdx, endIdx, len(m.filteredTasks))
+		if above != "" {
+			leftContent.WriteString(dimStyle.Render(above) + "\n")
 		}
 
 		for i := startIdx; i < endIdx; i++ {
@@ -584,8 +710,14 @@ func (m TasksModel) viewTasks() string {
 				}
 				leftContent.WriteString(dimStyle.Render("  "+desc) + "\n")
 			}
+			if p, ok := m.progress[task.ID]; ok && p.Total > 0 {
+				leftContent.WriteString(dimStyle.Render(fmt.Sprintf("  %s %d/%d links read", progressBar(p.Opened, p.Total, 10), p.Opened, p.Total)) + "\n")
+			}
+		}
+		if below != "" {
+			leftContent.WriteString(dimStyle.Render(below) + "\n")
 		}
-		if len(m.filteredTasks) > maxTasks {
+		if endIdx-startIdx < len(m.filteredTasks) {
 			leftContent.WriteString("\n" + dimStyle.Render(fmt.Sprintf("  [%d/%d tasks]", m.cursor+1, len(m.filteredTasks))))
 		}
 	}
@@ -615,29 +747,10 @@ func (m TasksModel) viewTasks() string {
 			if len(m.links) == 0 {
 				rightBuilder.WriteString(dimStyle.Render("No links yet. Tab to detail panel, then Ctrl+A to add."))
 			} else {
-				// Build content for viewport
-				var detailContent strings.Builder
-				for _, link := range m.links {
-					title := link.Title.String
-					if title == "" {
-						title = link.Url
-					}
-					detailContent.WriteString(fmt.Sprintf("• %s\n", title))
-
-					// Show URL in dim style
-					detailContent.WriteString(dimStyle.Render("  "+link.Url) + "\n")
-
-					// Show summary if available
-					if link.Summary.Valid && link.Summary.String != "" {
-						summary := link.Summary.String
-						wrapped := wrapText(summary, rightWidth-6)
-						detailContent.WriteString(dimStyle.Render("  "+wrapped) + "\n")
-					}
-					detailContent.WriteString("\n")
-				}
+				detailContent, _ := m.linkDetail(rightWidth)
 
 				if m.viewportReady {
-					m.detailViewport.SetContent(detailContent.String())
+					m.detailViewport.SetContent(detailContent)
 					rightBuilder.WriteString(m.detailViewport.View())
 
 					// Show scroll indicator
@@ -647,7 +760,7 @@ func (m TasksModel) viewTasks() string {
 						rightBuilder.WriteString(scrollInfo)
 					}
 				} else {
-					rightBuilder.WriteString(detailContent.String())
+					rightBuilder.WriteString(detailContent)
 				}
 
 				rightBuilder.WriteString("\n\n" + dimStyle.Render("Ctrl+O: open all links"))
@@ -671,9 +784,9 @@ func (m TasksModel) viewTasks() string {
 	var helpMsg string
 	switch m.focus {
 	case panelFocusList:
-		helpMsg = "Tab: detail • ↑/↓/j/k: navigate • PgUp/PgDn/Ctrl+U/D: jump • Ctrl+A: new task • Space: toggle • Ctrl+O: open links • Esc: search"
+		helpMsg = "Tab: detail • ↑/↓/j/k: navigate • PgUp/PgDn/Ctrl+U/D: jump • g/G: top/bottom • Ctrl+A/n: new task • Space: toggle • Ctrl+O: open links • Esc: search"
 	case panelFocusDetail:
-		helpMsg = "Tab: search • ↑/↓/j/k/PgUp/PgDn: scroll • Ctrl+A: add link • Ctrl+O: open links • Esc: search"
+		helpMsg = "Tab: search • ↑/↓/j/k: select link • J/K: move link down/up • PgUp/PgDn: scroll • g/G: top/bottom • Ctrl+A: add link • Ctrl+O: open links • Esc: search"
 	default: // panelFocusSearch
 		helpMsg = "type to search • Tab: list • ↑/↓: navigate • Ctrl+A: new task • Ctrl+O: open links • Esc: clear"
 	}
@@ -682,6 +795,85 @@ func (m TasksModel) viewTasks() string {
 	return mainContent + helpText
 }
 
+// linkDetail renders the selected task's links for the detail viewport,
+// highlighting the selected one while the panel has focus. It also returns
+// the line each link starts on.
+func (m TasksModel) linkDetail(rightWidth int) (string, []int) {
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("10")).
+		Bold(true)
+	dimStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("243"))
+
+	var b strings.Builder
+	starts := make([]int, len(m.links))
+	line := 0
+	for i, link := range m.links {
+		starts[i] = line
+		title := link.Title.String
+		if title == "" {
+			title = link.Url
+		}
+		if m.focus == panelFocusDetail && i == m.linkCursor {
+			b.WriteString(selectedStyle.Render("▶ "+title) + "\n")
+		} else {
+			b.WriteString(fmt.Sprintf("• %s\n", title))
+		}
+
+		// Show URL in dim style
+		b.WriteString(dimStyle.Render("  "+link.Url) + "\n")
+		line += 2
+
+		// Show summary if available
+		if summary := listSummary(link); summary != "" {
+			wrapped := wrapText(summary, rightWidth-6)
+			b.WriteString(dimStyle.Render("  "+wrapped) + "\n")
+			line += strings.Count(wrapped, "\n") + 1
+		}
+		b.WriteString("\n")
+		line++
+	}
+	return b.String(), starts
+}
+
+// scrollToLink scrolls the detail viewport just far enough to show the
+// selected link.
+func (m *TasksModel) scrollToLink() {
+	if !m.viewportReady || m.linkCursor >= len(m.links) {
+		return
+	}
+	_, rightWidth := splitWidths(m.width, m.splitRatio)
+	content, starts := m.linkDetail(rightWidth)
+	m.detailViewport.SetContent(content)
+
+	top := starts[m.linkCursor]
+	bottom := strings.Count(content, "\n") - 1 // the blank line after the last link
+	if m.linkCursor+1 < len(starts) {
+		bottom = starts[m.linkCursor+1] - 1
+	}
+	if bottom >= m.detailViewport.YOffset+m.detailViewport.Height {
+		m.detailViewport.SetYOffset(bottom - m.detailViewport.Height + 1)
+	}
+	if top < m.detailViewport.YOffset {
+		m.detailViewport.SetYOffset(top)
+	}
+}
+
+// moveLink moves the selected link by delta places within its task and
+// saves the new order.
+func (m *TasksModel) moveLink(delta int) tea.Cmd {
+	to := m.linkCursor + delta
+	if m.linksTaskID == 0 || to < 0 || to >= len(m.links) {
+		return nil
+	}
+	links := append([]models.Link(nil), m.links...)
+	links[m.linkCursor], links[to] = links[to], links[m.linkCursor]
+	m.links = links
+	m.linkCursor = to
+	m.scrollToLink()
+	return m.saveTaskLinkOrder(m.linksTaskID, links)
+}
+
 func (m TasksModel) viewCreateTask() string {
 	titleStyle := lipgloss.NewStyle().
 		Bold(true).
@@ -708,12 +900,34 @@ func (m TasksModel) viewCreateTask() string {
 
 func (m TasksModel) loadTasks() tea.Cmd {
 	return func() tea.Msg {
-		tasks, err := m.db.Queries.ListTasks(context.Background())
-		if err != nil {
-			return errMsg{err: err}
-		}
-		return tasksLoadedMsg{tasks: tasks}
+		return loadTasksMsg(m.db)
+	}
+}
+
+// loadTasksMsg loads every task along with its reading progress.
+func loadTasksMsg(db *database.Database) tea.Msg {
+	tasks, err := db.Queries.ListTasks(context.Background())
+	if err != nil {
+		return errMsg{err: err}
+	}
+	progress, err := loadTaskProgress(db)
+	if err != nil {
+		return errMsg{err: err}
+	}
+	return tasksLoadedMsg{tasks: tasks, progress: progress}
+}
+
+// loadTaskProgress counts each task's links and how many have been opened.
+func loadTaskProgress(db *database.Database) (map[int64]models.CountTaskLinksOpenedRow, error) {
+	rows, err := db.Queries.CountTaskLinksOpened(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	progress := make(map[int64]models.CountTaskLinksOpenedRow, len(rows))
+	for _, r := range rows {
+		progress[r.TaskID] = r
 	}
+	return progress, nil
 }
 
 func (m TasksModel) loadTaskLinks(taskID int64) tea.Cmd {
@@ -722,19 +936,56 @@ func (m TasksModel) loadTaskLinks(taskID int64) tea.Cmd {
 		if err != nil {
 			return errMsg{err: err}
 		}
-		return taskLinksLoadedMsg{links: links}
+		return taskLinksLoadedMsg{taskID: taskID, links: links}
 	}
 }
 
-func (m TasksModel) openLinks() tea.Cmd {
+// saveTaskLinkOrder numbers a task's links in the order given, reloading
+// them from the database if that fails.
+func (m TasksModel) saveTaskLinkOrder(taskID int64, links []models.Link) tea.Cmd {
 	return func() tea.Msg {
-		for _, link := range m.links {
-			_ = browser.OpenURL(link.Url)
+		for i, link := range links {
+			err := m.db.Queries.UpdateTaskLinkOrder(context.Background(), models.UpdateTaskLinkOrderParams{
+				SortOrder: int64(i),
+				LinkID:    link.ID,
+				TaskID:    taskID,
+			})
+			if err != nil {
+				return taskLinksReorderFailedMsg{taskID: taskID, err: err}
+			}
 		}
 		return nil
 	}
 }
 
+// openLinks opens every link of the selected task and marks them as read
+// for the task's progress.
+func (m TasksModel) openLinks() tea.Cmd {
+	var taskID int64
+	if len(m.filteredTasks) > 0 && m.cursor < len(m.filteredTasks) {
+		taskID = m.filteredTasks[m.cursor].ID
+	}
+	visited := func() tea.Msg { return linksVisitedMsg{links: m.links} }
+	return tea.Batch(visited, func() tea.Msg {
+		for _, link := range m.links {
+			if services.OpenURL(m.browser, link.Url) == nil {
+				m.db.RecordOpen(m.ctx, link.ID)
+			}
+		}
+		if taskID == 0 {
+			return nil
+		}
+		if err := m.db.Queries.MarkTaskLinksOpened(context.Background(), taskID); err != nil {
+			return errMsg{err: err}
+		}
+		progress, err := loadTaskProgress(m.db)
+		if err != nil {
+			return errMsg{err: err}
+		}
+		return taskProgressLoadedMsg{progress: progress}
+	})
+}
+
 func (m TasksModel) createTask(name, description string) tea.Cmd {
 	return func() tea.Msg {
 		_, err := m.db.Queries.CreateTask(context.Background(), models.CreateTaskParams{
@@ -774,20 +1025,29 @@ func (m TasksModel) toggleTaskCompletion(taskID int64, completed bool) tea.Cmd {
 			return errMsg{err: err}
 		}
 		// Reload tasks
-		tasks, err := m.db.Queries.ListTasks(context.Background())
-		if err != nil {
-			return errMsg{err: err}
-		}
-		return tasksLoadedMsg{tasks: tasks}
+		return loadTasksMsg(m.db)
 	}
 }
 
 type taskLinksLoadedMsg struct {
-	links []models.Link
+	taskID int64
+	links  []models.Link
+}
+
+// taskLinksReorderFailedMsg reports that a task's new link order could not
+// be saved, so the links shown no longer match the database.
+type taskLinksReorderFailedMsg struct {
+	taskID int64
+	err    error
 }
 
 type tasksLoadedMsg struct {
-	tasks []models.Task
+	tasks    []models.Task
+	progress map[int64]models.CountTaskLinksOpenedRow
+}
+
+type taskProgressLoadedMsg struct {
+	progress map[int64]models.CountTaskLinksOpenedRow
 }
 
 type taskCreatedMsg struct{}
@@ -1,16 +1,31 @@
 package tui
 
 import (
+	"fmt"
+	"math"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/x/ansi"
+
+	"mccwk.com/lm/internal/models"
+	"mccwk.com/lm/internal/services"
 )
 
 // renderMarkdown renders a markdown string for display in the terminal using
 // glamour.  width is the viewport width; glamour's default style adds 2-char
-// margins on each side, so the word-wrap is set to width-4.
-func renderMarkdown(md string, width int) string {
+// margins on each side, so the word-wrap is set to width-4.  Where that is
+// more than maxColumn (0 for no limit), text wraps at maxColumn instead and
+// is centered in the viewport, so lines stay readable on wide terminals.
+func renderMarkdown(md string, width, maxColumn int) string {
 	ww := width - 4
+	if maxColumn > 0 && ww > maxColumn {
+		ww = maxColumn
+	}
 	if ww < 20 {
 		ww = 20
 	}
@@ -25,6 +40,13 @@ func renderMarkdown(md string, width int) string {
 	if err != nil {
 		return md
 	}
+	if pad := (width - ww) / 2; ww < width-4 && pad > 0 {
+		lines := strings.Split(out, "\n")
+		for i, line := range lines {
+			lines[i] = strings.Repeat(" ", pad) + line
+		}
+		out = strings.Join(lines, "\n")
+	}
 	return out
 }
 
@@ -44,6 +66,103 @@ func cycleFocusForward(f panelFocus) panelFocus { return (f + 1) % 3 }
 // cycleFocusBackward retreats focus in the reverse order.
 func cycleFocusBackward(f panelFocus) panelFocus { return (f + 2) % 3 }
 
+// focusLeft moves focus from the detail panel to the list beside it.
+func focusLeft(f panelFocus) panelFocus {
+	if f == panelFocusDetail {
+		return panelFocusList
+	}
+	return f
+}
+
+// focusRight moves focus from the list to the detail panel beside it.
+func focusRight(f panelFocus) panelFocus {
+	if f == panelFocusList {
+		return panelFocusDetail
+	}
+	return f
+}
+
+// The list panel of a split view takes defaultSplitRatio of the width until
+// the divider is moved with < and >, splitStep at a time, between
+// minSplitRatio and maxSplitRatio.
+const (
+	defaultSplitRatio = 0.35
+	minSplitRatio     = 0.2
+	maxSplitRatio     = 0.65
+	splitStep         = 0.05
+)
+
+// splitWidths returns the widths of the list and detail panels of a split
+// view width columns wide. The list takes ratio of the width, or the default
+// when ratio is unset, and never less than 30 columns.
+func splitWidths(width int, ratio float64) (left, right int) {
+	if ratio <= 0 {
+		ratio = defaultSplitRatio
+	}
+	left = int(float64(width) * ratio)
+	if left < 30 {
+		left = 30
+	}
+	return left, width - left - 8
+}
+
+// clampSplitRatio keeps ratio within the allowed range, rounded to whole
+// percent so repeated steps do not drift.
+func clampSplitRatio(ratio float64) float64 {
+	ratio = math.Round(ratio*100) / 100
+	return min(max(ratio, minSplitRatio), maxSplitRatio)
+}
+
+// countPrefix accumulates a vim-style numeric prefix for list movement, so
+// "10j" moves down ten items.
+type countPrefix int
+
+// maxCountPrefix keeps an over-long prefix from growing without bound.
+const maxCountPrefix = 9999
+
+// push appends key to the pending count if it is a digit. A leading "0" is not
+// treated as a count. It reports whether the key was consumed.
+func (c *countPrefix) push(key string) bool {
+	if len(key) != 1 || key[0] < '0' || key[0] > '9' || (key == "0" && *c == 0) {
+		return false
+	}
+	if n := *c*10 + countPrefix(key[0]-'0'); n <= maxCountPrefix {
+		*c = n
+	}
+	return true
+}
+
+// take returns the pending count (1 if none was typed) and clears it.
+func (c *countPrefix) take() int {
+	n := int(*c)
+	*c = 0
+	if n < 1 {
+		return 1
+	}
+	return n
+}
+
+// clearedSearch remembers the query Esc last cleared from a search box, so a
+// long query cleared by accident can be brought back with a second Esc.
+type clearedSearch string
+
+// esc clears input, remembering its query, or, if input is already empty,
+// restores the query it last cleared. It returns a notice for the status
+// line, or nil.
+func (c *clearedSearch) esc(input *textinput.Model) tea.Cmd {
+	if query := input.Value(); query != "" {
+		*c = clearedSearch(query)
+		input.SetValue("")
+		return notifyCmd("info", "Search cleared • Esc again to restore it")
+	}
+	if *c != "" {
+		input.SetValue(string(*c))
+		input.CursorEnd()
+		*c = ""
+	}
+	return nil
+}
+
 // panelBorderColor returns the border colour for a panel depending on whether
 // it currently holds focus (active=green, inactive=dim).
 func panelBorderColor(focused bool) string {
@@ -53,20 +172,227 @@ func panelBorderColor(focused bool) string {
 	return "8"
 }
 
-// linkMatchesQuery returns true when a link matches every whitespace-separated
-// word in the query (case-insensitive AND search). Word order is ignored.
-func linkMatchesQuery(url, title, content, summary, query string) bool {
+// attentionMarker marks links flagged as needing attention in lists and
+// detail views.
+const attentionMarker = "⚑"
+
+// expiryMarker marks links that have expired (⌛) or expire soon (⏳) in
+// lists, or returns "" for others.
+func expiryMarker(link models.Link) string {
+	switch services.ExpiryState(link.ExpiresAt, time.Now()) {
+	case services.ExpiryExpired:
+		return "⌛"
+	case services.ExpiryExpiring:
+		return "⏳"
+	}
+	return ""
+}
+
+// linkExpired reports whether link's expiry date has passed. Expired links
+// are dimmed in lists rather than removed.
+func linkExpired(link models.Link) bool {
+	return services.ExpiryState(link.ExpiresAt, time.Now()) == services.ExpiryExpired
+}
+
+// listSummary returns the summary to show for link in a list: its one-line
+// summary if it has one, else its summary.
+func listSummary(link models.Link) string {
+	if link.SummaryShort.String != "" {
+		return link.SummaryShort.String
+	}
+	return link.Summary.String
+}
+
+// linkInfoLine renders when a link was added and last fetched, how long its
+// content is, whether it auto-refreshes, whether it needs attention, and when
+// it expires, as markdown for the detail view.
+func linkInfoLine(link models.Link) string {
+	fetched := "never"
+	if link.FetchedAt.Valid {
+		fetched = link.FetchedAt.Time.Local().Format("2006-01-02")
+	}
+	line := fmt.Sprintf("Added: %s • Fetched: %s", link.CreatedAt.Local().Format("2006-01-02"), fetched)
+	if link.Source != "" {
+		line += " • Source: " + link.Source
+	}
+	if link.ContentLength > 0 {
+		line += fmt.Sprintf(" • %s words", formatCount(link.ContentLength))
+	}
+	if link.AutoRefresh {
+		line += " • ↻ auto-refresh"
+	}
+	if link.NeedsAttention {
+		line += " • " + attentionMarker + " needs attention"
+	}
+	if link.ExpiresAt.Valid {
+		date := link.ExpiresAt.Time.Local().Format("2006-01-02")
+		switch services.ExpiryState(link.ExpiresAt, time.Now()) {
+		case services.ExpiryExpired:
+			line += " • ⌛ expired " + date
+		case services.ExpiryExpiring:
+			line += " • ⏳ expires " + date
+		default:
+			line += " • Expires: " + date
+		}
+	}
+	return "*" + line + "*"
+}
+
+// formatCount renders n with comma thousands separators, e.g. 4,210.
+func formatCount(n int64) string {
+	if n < 0 {
+		return "-" + formatCount(-n)
+	}
+	s := strconv.FormatInt(n, 10)
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+	return s
+}
+
+// linkCount renders n as "1 link" or "n links".
+func linkCount(n int) string {
+	if n == 1 {
+		return "1 link"
+	}
+	return formatCount(int64(n)) + " links"
+}
+
+// listPosition renders the cursor position in a list of links, e.g.
+// "3 of 42 links".
+func listPosition(cursor, n int) string {
+	if n == 0 {
+		return "no links"
+	}
+	return fmt.Sprintf("%d of %s", cursor+1, linkCount(n))
+}
+
+// listRows returns how many rows a tab's list can fill in a window height
+// rows tall: what is left after the app's header and footer (7), the list
+// panel's border and padding (4), the help line (1), the position counter
+// under a scrolled list (2), and the preamble rows drawn above the list,
+// such as the search box. It never returns less than 3.
+func listRows(height, preamble int) int {
+	return max(height-14-preamble, 3)
+}
+
+// listWindow returns the items [start, end) of an n-item list to draw so the
+// cursor stays visible and they take at most maxRows rows, where rows(i) is
+// how many rows item i takes (more when a summary or description is shown
+// under it). When the list does not fit, two rows are kept back for the
+// lines from moreIndicators.
+func listWindow(n, cursor, maxRows int, rows func(i int) int) (start, end int) {
+	start, end = fitWindow(n, cursor, maxRows, rows)
+	if start > 0 || end < n {
+		start, end = fitWindow(n, cursor, max(maxRows-2, 1), rows)
+	}
+	return start, end
+}
+
+// fitWindow is listWindow without room for the indicators. The cursor's item
+// is always included, even when it alone is taller than maxRows.
+func fitWindow(n, cursor, maxRows int, rows func(i int) int) (start, end int) {
+	if n == 0 {
+		return 0, 0
+	}
+	cursor = min(max(cursor, 0), n-1)
+
+	// Walk back from the cursor until the rows run out.
+	used := 0
+	for i := cursor; i >= 0; i-- {
+		used += rows(i)
+		if used > maxRows {
+			start = min(i+1, cursor)
+			break
+		}
+	}
+
+	// Then fill forward from start.
+	used = 0
+	for end = start; end < n; end++ {
+		r := rows(end)
+		if used+r > maxRows && end > cursor {
+			break
+		}
+		used += r
+	}
+	return start, end
+}
+
+// selectedID returns the ID of the item at cursor, or 0 if cursor is past the
+// end of items.
+func selectedID[T any](items []T, cursor int, id func(T) int64) int64 {
+	if cursor < 0 || cursor >= len(items) {
+		return 0
+	}
+	return id(items[cursor])
+}
+
+// reselect returns the cursor for items after a reload: wherever the item
+// with ID selected is now, so new or reordered rows don't move the selection
+// to a different item, or cursor unchanged if that item has gone.
+func reselect[T any](items []T, selected int64, cursor int, id func(T) int64) int {
+	if selected == 0 {
+		return cursor
+	}
+	for i, item := range items {
+		if id(item) == selected {
+			return i
+		}
+	}
+	return cursor
+}
+
+// linkIDOf returns link's ID, for selectedID and reselect.
+func linkIDOf(link models.Link) int64 { return link.ID }
+
+// moreIndicators returns the "▲ n more above" and "▼ n more below" lines for
+// the items of an n-item list outside the window [start, end); each is ""
+// when nothing is hidden on that side.
+func moreIndicators(start, end, n int) (above, below string) {
+	if start > 0 {
+		above = fmt.Sprintf("  ▲ %d more above", start)
+	}
+	if end < n {
+		below = fmt.Sprintf("  ▼ %d more below", n-end)
+	}
+	return above, below
+}
+
+// plainLines strips ANSI styling from rendered viewport content and returns
+// its lowercased lines, so they can be searched for query terms.
+func plainLines(rendered string) []string {
+	return strings.Split(strings.ToLower(ansi.Strip(rendered)), "\n")
+}
+
+// matchLines returns the indexes of lines containing any whitespace-separated
+// word of query. lines are expected to be lowercased (see plainLines).
+func matchLines(lines []string, query string) []int {
 	words := strings.Fields(strings.ToLower(query))
 	if len(words) == 0 {
-		return true
+		return nil
 	}
-	haystack := strings.ToLower(url + " " + title + " " + content + " " + summary)
-	for _, w := range words {
-		if !strings.Contains(haystack, w) {
-			return false
+	var matches []int
+	for i, line := range lines {
+		for _, w := range words {
+			if strings.Contains(line, w) {
+				matches = append(matches, i)
+				break
+			}
 		}
 	}
-	return true
+	return matches
+}
+
+// progressBar renders done out of total as a bar of the given width,
+// e.g. "███░░░░░░░".
+func progressBar(done, total int64, width int) string {
+	filled := 0
+	if total > 0 {
+		filled = int(done * int64(width) / total)
+	}
+	filled = min(max(filled, 0), width)
+	return strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
 }
 
 // wrapText wraps text to the specified width, breaking on word boundaries
@@ -0,0 +1,237 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"mccwk.com/lm/internal/database"
+	"mccwk.com/lm/internal/models"
+)
+
+// workPickerRows is the most tasks and activities the picker lists at once.
+const workPickerRows = 8
+
+// workItem is a task or activity a link can be added to.
+type workItem struct {
+	activity bool // an activity rather than a task
+	id       int64
+	name     string
+	linked   bool // the link is already in it
+}
+
+func (w workItem) kind() string {
+	if w.activity {
+		return "activity"
+	}
+	return "task"
+}
+
+// WorkPickerModel is a dialog that adds an existing link to a task or
+// activity, so it need not be fetched again through the task's own add-link
+// form. Typing filters the list; completed tasks are left out.
+type WorkPickerModel struct {
+	link     models.Link
+	input    textinput.Model
+	items    []workItem // nil until loaded
+	filtered []workItem
+	cursor   int
+	saving   bool
+
+	db  *database.Database
+	ctx context.Context
+}
+
+func NewWorkPickerModel(link models.Link, db *database.Database) WorkPickerModel {
+	input := textinput.New()
+	input.Placeholder = "filter tasks and activities..."
+	input.Width = 40
+	input.Prompt = "> "
+	input.Focus()
+
+	return WorkPickerModel{
+		link:  link,
+		input: input,
+		db:    db,
+		ctx:   context.Background(),
+	}
+}
+
+func (m WorkPickerModel) Init() tea.Cmd {
+	return tea.Batch(m.loadItems(), textinput.Blink)
+}
+
+func (m WorkPickerModel) Update(msg tea.Msg) (WorkPickerModel, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case workItemsLoadedMsg:
+		m.items = msg.items
+		m.filter()
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.saving {
+			return m, nil
+		}
+		switch msg.String() {
+		case "up":
+			m.cursor = max(m.cursor-1, 0)
+			return m, nil
+		case "down":
+			m.cursor = max(min(m.cursor+1, len(m.filtered)-1), 0)
+			return m, nil
+		case "enter":
+			if m.cursor >= len(m.filtered) {
+				return m, nil
+			}
+			item := m.filtered[m.cursor]
+			if item.linked {
+				return m, notifyCmd("info", fmt.Sprintf("Already in %s %q", item.kind(), item.name))
+			}
+			m.saving = true
+			return m, m.addToItem(item)
+		}
+	}
+
+	m.input, cmd = m.input.Update(msg)
+	if _, ok := msg.(tea.KeyMsg); ok {
+		m.filter()
+	}
+	return m, cmd
+}
+
+// filter keeps the items whose names contain the typed text.
+func (m *WorkPickerModel) filter() {
+	query := strings.ToLower(strings.TrimSpace(m.input.Value()))
+	m.filtered = m.filtered[:0]
+	for _, item := range m.items {
+		if strings.Contains(strings.ToLower(item.name), query) {
+			m.filtered = append(m.filtered, item)
+		}
+	}
+	if m.cursor >= len(m.filtered) {
+		m.cursor = max(len(m.filtered)-1, 0)
+	}
+}
+
+func (m WorkPickerModel) View() string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("6"))
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("243"))
+	selectedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("10")).Bold(true)
+
+	title := m.link.Title.String
+	if title == "" {
+		title = m.link.Url
+	}
+	if len(title) > 50 {
+		title = title[:47] + "..."
+	}
+
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("Add to Task or Activity") + "\n\n")
+	b.WriteString(dimStyle.Render(title) + "\n\n")
+	b.WriteString(m.input.View() + "\n\n")
+
+	switch {
+	case m.items == nil:
+		b.WriteString(dimStyle.Render("Loading...") + "\n")
+	case len(m.items) == 0:
+		b.WriteString(dimStyle.Render("No open tasks or activities yet.") + "\n")
+	case len(m.filtered) == 0:
+		b.WriteString(dimStyle.Render("Nothing matches.") + "\n")
+	default:
+		start, end := listWindow(len(m.filtered), m.cursor, workPickerRows, func(int) int { return 1 })
+		above, below := moreIndicators(start, end, len(m.filtered))
+		if above != "" {
+			b.WriteString(dimStyle.Render(above) + "\n")
+		}
+		for i := start; i < end; i++ {
+			item := m.filtered[i]
+			line := fmt.Sprintf("%-8s  %s", item.kind(), item.name)
+			if item.linked {
+				line += " ✓"
+			}
+			if i == m.cursor {
+				b.WriteString(selectedStyle.Render("• "+line) + "\n")
+			} else {
+				b.WriteString("  " + line + "\n")
+			}
+		}
+		if below != "" {
+			b.WriteString(dimStyle.Render(below) + "\n")
+		}
+	}
+
+	b.WriteString("\n" + dimStyle.Render("↑/↓: select • Enter: add link • ✓: already added • Esc: cancel"))
+	return b.String()
+}
+
+// loadItems lists the open tasks and every activity, noting which already
+// hold the link.
+func (m WorkPickerModel) loadItems() tea.Cmd {
+	linkID := m.link.ID
+	return func() tea.Msg {
+		tasks, err := m.db.Queries.ListIncompleteTasks(m.ctx)
+		if err != nil {
+			return errMsg{err: err}
+		}
+		activities, err := m.db.Queries.ListActivities(m.ctx)
+		if err != nil {
+			return errMsg{err: err}
+		}
+		inTasks, err := m.db.Queries.GetTasksForLink(m.ctx, linkID)
+		if err != nil {
+			return errMsg{err: err}
+		}
+		inActivities, err := m.db.Queries.GetActivitiesForLink(m.ctx, linkID)
+		if err != nil {
+			return errMsg{err: err}
+		}
+
+		linkedTasks := make(map[int64]bool, len(inTasks))
+		for _, t := range inTasks {
+			linkedTasks[t.ID] = true
+		}
+		linkedActivities := make(map[int64]bool, len(inActivities))
+		for _, a := range inActivities {
+			linkedActivities[a.ID] = true
+		}
+
+		items := make([]workItem, 0, len(tasks)+len(activities))
+		for _, t := range tasks {
+			items = append(items, workItem{id: t.ID, name: t.Name, linked: linkedTasks[t.ID]})
+		}
+		for _, a := range activities {
+			items = append(items, workItem{activity: true, id: a.ID, name: a.Name, linked: linkedActivities[a.ID]})
+		}
+		return workItemsLoadedMsg{items: items}
+	}
+}
+
+// addToItem adds the link to the task or activity.
+func (m WorkPickerModel) addToItem(item workItem) tea.Cmd {
+	linkID := m.link.ID
+	return func() tea.Msg {
+		var err error
+		if item.activity {
+			err = m.db.Queries.LinkActivity(m.ctx, models.LinkActivityParams{LinkID: linkID, ActivityID: item.id})
+		} else {
+			err = m.db.Queries.LinkTask(m.ctx, models.LinkTaskParams{LinkID: linkID, TaskID: item.id})
+		}
+		return linkAddedToWorkMsg{item: item, err: err}
+	}
+}
+
+type workItemsLoadedMsg struct {
+	items []workItem
+}
+
+type linkAddedToWorkMsg struct {
+	item workItem
+	err  error
+}
@@ -9,7 +9,16 @@ CREATE TABLE links (
     created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
     updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
     fetched_at DATETIME,
-    summarized_at DATETIME
+    summarized_at DATETIME,
+    deleted_at DATETIME, -- set when the link is moved to the trash
+    domain TEXT NOT NULL DEFAULT '', -- host of url, lowercased, without "www."
+    content_length INTEGER NOT NULL DEFAULT 0, -- word count of the extracted text
+    auto_refresh BOOLEAN NOT NULL DEFAULT 0, -- refetch in the background when opened or viewed
+    needs_attention BOOLEAN NOT NULL DEFAULT 0, -- flagged by hand as broken or needing a re-save
+    custom_title BOOLEAN NOT NULL DEFAULT 0, -- title was edited by hand; refetches keep it
+    source TEXT NOT NULL DEFAULT '', -- where the link came from: cli, manual, pocket, urls, lm
+    expires_at DATETIME, -- when a time-sensitive link stops being useful; NULL if never
+    summary_short TEXT -- one-line summary for lists; summary is the longer one
 );
 
 -- Create tasks table
@@ -27,7 +36,8 @@ CREATE TABLE categories (
     id INTEGER PRIMARY KEY AUTOINCREMENT,
     name TEXT NOT NULL UNIQUE,
     description TEXT,
-    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    default_tags TEXT NOT NULL DEFAULT ''
 );
 
 -- Create tags table
@@ -51,6 +61,8 @@ CREATE TABLE link_tasks (
     link_id INTEGER NOT NULL,
     task_id INTEGER NOT NULL,
     created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    opened BOOLEAN NOT NULL DEFAULT 0,
+    sort_order INTEGER NOT NULL DEFAULT 0,
     PRIMARY KEY (link_id, task_id),
     FOREIGN KEY (link_id) REFERENCES links(id) ON DELETE CASCADE,
     FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE
@@ -86,14 +98,68 @@ CREATE TABLE link_activities (
     FOREIGN KEY (activity_id) REFERENCES activities(id) ON DELETE CASCADE
 );
 
+-- Create settings table (preferences set from the TUI)
+CREATE TABLE settings (
+    key TEXT PRIMARY KEY,
+    value TEXT NOT NULL
+);
+
+-- Create link_archives table (fetched HTML, kept when ARCHIVE_HTML is set)
+CREATE TABLE link_archives (
+    link_id INTEGER PRIMARY KEY,
+    html TEXT NOT NULL,
+    archived_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (link_id) REFERENCES links(id) ON DELETE CASCADE
+);
+
+-- Create link_captures table (links saved with 'lm add --capture', waiting for 'lm process')
+CREATE TABLE link_captures (
+    link_id INTEGER PRIMARY KEY,
+    captured_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (link_id) REFERENCES links(id) ON DELETE CASCADE
+);
+
+-- Create link_images table (images in each link's content, kept when KEEP_IMAGES is set)
+CREATE TABLE link_images (
+    link_id INTEGER NOT NULL,
+    position INTEGER NOT NULL,
+    url TEXT NOT NULL,
+    alt TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY (link_id, position),
+    FOREIGN KEY (link_id) REFERENCES links(id) ON DELETE CASCADE
+);
+
+-- Create link_opens table (one row each time a link is opened in the browser)
+CREATE TABLE link_opens (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    link_id INTEGER NOT NULL,
+    opened_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (link_id) REFERENCES links(id) ON DELETE CASCADE
+);
+
+-- Create llm_usage table (one row per LLM call, for 'lm stats --llm')
+CREATE TABLE llm_usage (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    model TEXT NOT NULL,
+    operation TEXT NOT NULL, -- summarize, short_summary, or suggest_metadata (database.Op*)
+    input_tokens INTEGER NOT NULL,
+    output_tokens INTEGER NOT NULL,
+    cost_usd REAL NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
 -- Create indexes for better query performance
 CREATE INDEX idx_links_status ON links(status);
 CREATE INDEX idx_links_created_at ON links(created_at DESC);
+CREATE INDEX idx_links_deleted_at ON links(deleted_at);
+CREATE INDEX idx_links_domain ON links(domain);
 CREATE INDEX idx_tasks_completed ON tasks(completed);
 CREATE INDEX idx_link_tasks_task_id ON link_tasks(task_id);
 CREATE INDEX idx_link_categories_category_id ON link_categories(category_id);
 CREATE INDEX idx_link_tags_tag_id ON link_tags(tag_id);
 CREATE INDEX idx_link_activities_activity_id ON link_activities(activity_id);
+CREATE INDEX idx_llm_usage_created_at ON llm_usage(created_at);
+CREATE INDEX idx_link_opens_link_id ON link_opens(link_id);
 
 -- Create full-text search virtual table for links
 CREATE VIRTUAL TABLE links_fts USING fts5(